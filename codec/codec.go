@@ -0,0 +1,125 @@
+package codec
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Error is a field that cannot be converted
+type Error struct {
+	Field string
+	Line  int // the line of the directive for Unmarshal, 0 for Marshal
+	Err   error
+}
+
+// Error returns the field, the line of the directive and the reason
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: field %s: %v", e.Line, e.Field, e.Err)
+	}
+	return fmt.Sprintf("field %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the reason
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// field is a struct field and the directive it maps to
+type field struct {
+	name      string // the directive name
+	goName    string
+	index     []int
+	omitEmpty bool
+	params    bool // a slice is the parameters of one directive
+	args      bool // the parameters of the block directive of the struct
+}
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// fields returns the fields of a struct type, embedded structs are flattened
+func fields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	var list []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("nginx")
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+			for _, f := range fields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				list = append(list, f)
+			}
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = snakeCase(sf.Name)
+		}
+		f := field{name: name, goName: sf.Name, index: []int{i}}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "params":
+				f.params = true
+			case "args":
+				f.args = true
+			}
+		}
+		list = append(list, f)
+	}
+	fieldCache.Store(t, list)
+	return list
+}
+
+// snakeCase returns the directive name of a field name, like proxy_read_timeout for ProxyReadTimeout
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				sb.WriteByte('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isParam reports whether values of the type are a single parameter
+func isParam(t reflect.Type) bool {
+	if t == durationType || t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isParams reports whether values of the type are the parameters of one directive
+func isParams(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && isParam(t.Elem())
+}
@@ -0,0 +1,233 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+type Proxy struct {
+	ProxyPass        string        `nginx:"proxy_pass"`
+	ProxyReadTimeout time.Duration `nginx:",omitempty"`
+	BufferSize       Size          `nginx:"proxy_buffer_size,omitempty"`
+	Headers          [][]string    `nginx:"proxy_set_header"`
+}
+
+type Location struct {
+	Match []string `nginx:",args"`
+	Proxy
+	Internal *bool `nginx:"internal_redirect"`
+}
+
+type Server struct {
+	Listen     []string   `nginx:"listen"`
+	ServerName []string   `nginx:"server_name,params"`
+	Gzip       bool       `nginx:"gzip"`
+	MaxBody    Size       `nginx:"client_max_body_size,omitempty"`
+	Locations  []Location `nginx:"location"`
+	Ignored    string     `nginx:"-"`
+}
+
+type HTTP struct {
+	KeepaliveTimeout time.Duration
+	Servers          []Server `nginx:"server"`
+}
+
+type Main struct {
+	WorkerProcesses string `nginx:",omitempty"`
+	HTTP            *HTTP  `nginx:"http"`
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+	v := Main{
+		WorkerProcesses: "auto",
+		HTTP: &HTTP{
+			KeepaliveTimeout: 90 * time.Second,
+			Servers: []Server{{
+				Listen:     []string{"80", "443"},
+				ServerName: []string{"example.com", "www.example.com"},
+				Gzip:       true,
+				MaxBody:    10 << 20,
+				Locations: []Location{{
+					Match: []string{"=", "/api"},
+					Proxy: Proxy{
+						ProxyPass:        "http://api",
+						ProxyReadTimeout: 1500 * time.Millisecond,
+						Headers:          [][]string{{"Host", "$host"}, {"X-Note", "a b"}},
+					},
+				}},
+				Ignored: "x",
+			}},
+		},
+	}
+	directives, err := Marshal(&v)
+	assert.NilError(t, err)
+	c := &config.Config{Block: &config.Block{Directives: directives}}
+	assert.Equal(t, dumper.DumpConfig(c, dumper.IndentedStyle), `worker_processes auto;
+http {
+    keepalive_timeout 1m30s;
+    server {
+        listen 80;
+        listen 443;
+        server_name example.com www.example.com;
+        gzip on;
+        client_max_body_size 10m;
+        location = /api {
+            proxy_pass http://api;
+            proxy_read_timeout 1s500ms;
+            proxy_set_header Host $host;
+            proxy_set_header X-Note "a b";
+        }
+    }
+}`)
+	http := directives[1].(*config.HTTP)
+	assert.Equal(t, len(http.Servers), 1)
+	location := c.FindDirectives("location")[0].(*config.Location)
+	assert.Equal(t, location.Match, "/api")
+	assert.Equal(t, location.GetParent(), config.IDirective(http.Servers[0]))
+
+	_, err = Marshal(3)
+	assert.ErrorContains(t, err, "a struct is expected")
+	_, err = Marshal(struct{ Limits map[string]string }{map[string]string{}})
+	assert.Error(t, err, "field Limits: unsupported type map[string]string")
+	_, err = Marshal(struct {
+		Timeout time.Duration `nginx:"proxy_read_timeout"`
+	}{500 * time.Microsecond})
+	assert.Error(t, err, "field Timeout: time 500µs is not a whole number of milliseconds")
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(`worker_processes 4;
+http {
+    keepalive_timeout 75;
+    server {
+        listen 80;
+        listen 443 ssl;
+        server_name example.com www.example.com;
+        gzip off;
+        client_max_body_size 1M;
+        location /api {
+            proxy_pass http://api;
+            proxy_read_timeout "1m 30s";
+            proxy_buffer_size 8k;
+            proxy_set_header Host $host;
+            proxy_set_header X-Note "a b";
+            internal_redirect on;
+        }
+    }
+}
+`).Parse()
+	assert.NilError(t, err)
+
+	var v Main
+	assert.NilError(t, Unmarshal(c, &v))
+	assert.Equal(t, v.WorkerProcesses, "4")
+	assert.Equal(t, v.HTTP.KeepaliveTimeout, 75*time.Second)
+	server := v.HTTP.Servers[0]
+	assert.DeepEqual(t, server.Listen, []string{"80", "443 ssl"})
+	assert.DeepEqual(t, server.ServerName, []string{"example.com", "www.example.com"})
+	assert.Equal(t, server.Gzip, false)
+	assert.Equal(t, server.MaxBody, Size(1<<20))
+	location := server.Locations[0]
+	assert.DeepEqual(t, location.Match, []string{"/api"})
+	assert.Equal(t, location.ProxyPass, "http://api")
+	assert.Equal(t, location.ProxyReadTimeout, 90*time.Second)
+	assert.Equal(t, location.BufferSize, Size(8<<10))
+	assert.DeepEqual(t, location.Headers, [][]string{{"Host", "$host"}, {"X-Note", "a b"}})
+	assert.Equal(t, *location.Internal, true)
+
+	// the directives of the marshaled struct are the same
+	directives, err := Marshal(v)
+	assert.NilError(t, err)
+	var again Main
+	assert.NilError(t, Unmarshal(&config.Block{Directives: directives}, &again))
+	assert.DeepEqual(t, again, v)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	t.Parallel()
+	parse := func(conf string) *config.Config {
+		c, err := parser.NewStringParser(conf).Parse()
+		assert.NilError(t, err)
+		return c
+	}
+	var v Main
+	err := Unmarshal(parse("http {\n    keepalive_timeout 10x;\n}\n"), &v)
+	assert.Error(t, err, `line 2: field KeepaliveTimeout: invalid time "10x"`)
+	var e *Error
+	assert.Assert(t, errors.As(err, &e))
+	assert.Equal(t, e.Field, "KeepaliveTimeout")
+
+	err = Unmarshal(parse("http {\n}\nhttp {\n}\n"), &v)
+	assert.Error(t, err, "line 3: field HTTP: http directive is duplicate")
+	err = Unmarshal(parse("http {\n    server {\n        gzip yes;\n    }\n}\n"), &v)
+	assert.Error(t, err, `line 3: field Gzip: invalid value "yes", it must be on or off`)
+	assert.ErrorContains(t, Unmarshal(parse(""), v), "a pointer to a struct is expected")
+}
+
+func TestUnits(t *testing.T) {
+	t.Parallel()
+	for text, want := range map[string]time.Duration{
+		"30":       30 * time.Second,
+		"500ms":    500 * time.Millisecond,
+		"1h30m":    90 * time.Minute,
+		"1d 12h":   36 * time.Hour,
+		"2w":       14 * 24 * time.Hour,
+		"1m1s10ms": time.Minute + time.Second + 10*time.Millisecond,
+	} {
+		d, err := parseDuration(text)
+		assert.NilError(t, err, text)
+		assert.Equal(t, d, want, text)
+	}
+	_, err := parseDuration("1x")
+	assert.ErrorContains(t, err, "invalid time")
+	for _, tt := range []struct {
+		duration time.Duration
+		want     string
+		err      string
+	}{
+		{duration: 36*time.Hour + 5*time.Second, want: "1d12h5s"},
+		{duration: 0, want: "0s"},
+		{duration: 1500 * time.Millisecond, want: "1s500ms"},
+		{duration: 500 * time.Microsecond, err: "time 500µs is not a whole number of milliseconds"},
+		{duration: 1500 * time.Microsecond, err: "time 1.5ms is not a whole number of milliseconds"},
+		{duration: -time.Second, err: "negative time -1s"},
+	} {
+		text, err := formatDuration(tt.duration)
+		if tt.err != "" {
+			assert.Error(t, err, tt.err)
+			continue
+		}
+		assert.NilError(t, err, tt.duration)
+		assert.Equal(t, text, tt.want)
+	}
+
+	var s Size
+	assert.NilError(t, s.UnmarshalText([]byte("512K")))
+	assert.Equal(t, s, Size(512<<10))
+	text, _ := Size(3 << 30).MarshalText()
+	assert.Equal(t, string(text), "3g")
+	text, _ = Size(1000).MarshalText()
+	assert.Equal(t, string(text), "1000")
+	assert.ErrorContains(t, s.UnmarshalText([]byte("1t")), "invalid size")
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+	for name, want := range map[string]string{
+		"ProxyReadTimeout": "proxy_read_timeout",
+		"SSLCertificate":   "ssl_certificate",
+		"HTTP2":            "http2",
+		"Gzip":             "gzip",
+		"HTTP2MaxRequests": "http2_max_requests",
+	} {
+		assert.Equal(t, snakeCase(name), want)
+	}
+}
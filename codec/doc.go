@@ -0,0 +1,23 @@
+// Package codec converts Go structs to and from nginx directives, like encoding/json,
+// with struct tags naming the directives:
+//
+//	type Proxy struct {
+//		Pass        string        `nginx:"proxy_pass"`
+//		ReadTimeout time.Duration `nginx:"proxy_read_timeout,omitempty"`
+//		BufferSize  codec.Size    `nginx:"proxy_buffer_size,omitempty"`
+//		Headers     [][]string    `nginx:"proxy_set_header"`
+//	}
+//
+// A field without tag is the directive named after the field in snake case, like
+// proxy_read_timeout for ProxyReadTimeout, and the tag "-" skips the field. Strings,
+// bools (on and off), numbers, time.Duration (nginx times like 1m30s, whole milliseconds
+// that are not negative), Size (nginx sizes like 10m) and encoding.TextMarshaler values
+// are a directive with one parameter.
+// A slice of them is a repeated directive, or one directive with several parameters
+// with the "params" option, like `nginx:"server_name,params"`. A slice of string
+// slices is a repeated directive with several parameters, like add_header.
+// A struct is a block directive and a slice of structs a repeated block, the parameters
+// of a block go to the string or []string field tagged with the "args" option, like
+// the match of a location. Embedded structs are flattened. The "omitempty" option skips
+// zero values and nil pointers are always skipped.
+package codec
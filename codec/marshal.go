@@ -0,0 +1,218 @@
+package codec
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Marshal returns the directives of the fields of a struct or a pointer to a struct,
+// in the order of the fields. Blocks are typed like the parser does them, with the
+// wrappers of config.BlockWrappers and config.DirectiveWrappers, like *config.Location.
+func Marshal(v any) ([]config.IDirective, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("codec: Marshal of %T, a struct is expected", v)
+	}
+	directives, _, err := marshalStruct(rv)
+	return directives, err
+}
+
+// marshalStruct returns the directives of the fields and the parameters of the args field
+func marshalStruct(rv reflect.Value) ([]config.IDirective, []string, error) {
+	directives := []config.IDirective{}
+	var args []string
+	for _, f := range fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.args {
+			params, err := marshalArgs(fv)
+			if err != nil {
+				return nil, nil, &Error{Field: f.goName, Err: err}
+			}
+			args = params
+			continue
+		}
+		ds, err := marshalField(f, fv)
+		if err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				return nil, nil, err
+			}
+			return nil, nil, &Error{Field: f.goName, Err: err}
+		}
+		directives = append(directives, ds...)
+	}
+	return directives, args, nil
+}
+
+func marshalArgs(fv reflect.Value) ([]string, error) {
+	switch {
+	case fv.Kind() == reflect.String:
+		if fv.String() == "" {
+			return nil, nil
+		}
+		return []string{fv.String()}, nil
+	case isParams(fv.Type()):
+		return marshalParams(fv)
+	}
+	return nil, fmt.Errorf("args must be a string or a slice, not %s", fv.Type())
+}
+
+func marshalField(f field, fv reflect.Value) ([]config.IDirective, error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+	if f.omitEmpty && fv.IsZero() {
+		return nil, nil
+	}
+	t := fv.Type()
+	switch {
+	case isParam(t):
+		param, err := marshalParam(fv)
+		if err != nil {
+			return nil, err
+		}
+		return simple(f.name, []string{param})
+	case f.params && isParams(t):
+		params, err := marshalParams(fv)
+		if err != nil {
+			return nil, err
+		}
+		return simple(f.name, params)
+	case t.Kind() == reflect.Struct:
+		d, err := marshalBlock(f.name, fv)
+		if err != nil {
+			return nil, err
+		}
+		return []config.IDirective{d}, nil
+	case t.Kind() == reflect.Slice:
+		var directives []config.IDirective
+		for i := 0; i < fv.Len(); i++ {
+			// an element that is a slice is the parameters of one directive, like add_header
+			ds, err := marshalField(field{name: f.name, goName: f.goName, params: true}, fv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			directives = append(directives, ds...)
+		}
+		return directives, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// marshalBlock returns the block directive of a struct
+func marshalBlock(name string, rv reflect.Value) (config.IDirective, error) {
+	directives, args, err := marshalStruct(rv)
+	if err != nil {
+		return nil, err
+	}
+	d := directive(name, args)
+	d.Block = &config.Block{Directives: directives}
+	return wrap(d, config.BlockWrappers[name])
+}
+
+// simple returns the directive without block, typed like the parser does
+func simple(name string, params []string) ([]config.IDirective, error) {
+	wrapper, ok := config.IncludeWrappers[name]
+	if !ok {
+		wrapper = config.DirectiveWrappers[name]
+	}
+	d, err := wrap(directive(name, params), wrapper)
+	if err != nil {
+		return nil, err
+	}
+	return []config.IDirective{d}, nil
+}
+
+// directive returns the directive with its parameters
+func directive(name string, params []string) *config.Directive {
+	d := &config.Directive{Name: name, Parameters: []config.Parameter{}}
+	for _, p := range params {
+		d.Parameters = append(d.Parameters, config.Parameter{Value: quote(p)})
+	}
+	return d
+}
+
+// wrap turns the directive into its typed wrapper and sets the parent of its sub directives
+func wrap(d *config.Directive, wrapper func(*config.Directive) (config.IDirective, error)) (config.IDirective, error) {
+	var s config.IDirective = d
+	if wrapper != nil {
+		wrapped, err := wrapper(d)
+		if err != nil {
+			return nil, err
+		}
+		s = wrapped
+	}
+	if s.GetBlock() == nil {
+		s.SetParent(s)
+		return s, nil
+	}
+	if block, ok := s.GetBlock().(*config.Block); ok {
+		block.SetParent(s)
+	}
+	for _, sub := range s.GetBlock().GetDirectives() {
+		sub.SetParent(s)
+	}
+	return s, nil
+}
+
+func marshalParams(fv reflect.Value) ([]string, error) {
+	params := make([]string, 0, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		param, err := marshalParam(fv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+func marshalParam(fv reflect.Value) (string, error) {
+	if fv.Type() == durationType {
+		return formatDuration(time.Duration(fv.Int()))
+	}
+	if fv.CanAddr() && fv.Addr().Type().Implements(textMarshalerType) {
+		fv = fv.Addr()
+	}
+	if m, ok := fv.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		if fv.Bool() {
+			return "on", nil
+		}
+		return "off", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", fv.Type())
+}
+
+// quote quotes a parameter that nginx would otherwise split or read as syntax
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n;{}#\"'") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
@@ -0,0 +1,124 @@
+package codec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Size is a size in bytes, written like nginx sizes: 512, 16k, 10m or 1g
+type Size int64
+
+// size units, from the largest
+var sizeUnits = []struct {
+	suffix string
+	size   Size
+}{
+	{"g", 1 << 30},
+	{"m", 1 << 20},
+	{"k", 1 << 10},
+}
+
+// MarshalText writes the size with the largest unit that divides it
+func (s Size) MarshalText() ([]byte, error) {
+	for _, u := range sizeUnits {
+		if s != 0 && s%u.size == 0 {
+			return []byte(strconv.FormatInt(int64(s/u.size), 10) + u.suffix), nil
+		}
+	}
+	return []byte(strconv.FormatInt(int64(s), 10)), nil
+}
+
+// UnmarshalText reads a size with an optional k, m or g unit, in any case
+func (s *Size) UnmarshalText(text []byte) error {
+	value := strings.ToLower(string(text))
+	unit := Size(1)
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(value, u.suffix) {
+			value, unit = strings.TrimSuffix(value, u.suffix), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size %q", text)
+	}
+	*s = Size(n) * unit
+	return nil
+}
+
+// time units of nginx, from the largest
+var timeUnits = []struct {
+	suffix   string
+	duration time.Duration
+}{
+	{"y", 365 * 24 * time.Hour},
+	{"M", 30 * 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+}
+
+// formatDuration writes a duration like nginx times, 1m30s for 90 seconds. Units above
+// days are not used as they do not have the same length in Go and nginx. nginx times are
+// whole milliseconds and not negative, other durations are an error.
+func formatDuration(d time.Duration) (string, error) {
+	switch {
+	case d < 0:
+		return "", fmt.Errorf("negative time %s", d)
+	case d%time.Millisecond != 0:
+		return "", fmt.Errorf("time %s is not a whole number of milliseconds", d)
+	case d == 0:
+		return "0s", nil
+	}
+	var sb strings.Builder
+	for _, u := range timeUnits[3:] {
+		if n := d / u.duration; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(u.suffix)
+			d -= n * u.duration
+		}
+	}
+	return sb.String(), nil
+}
+
+// parseDuration reads nginx times, like 30, 10s, 1h30m or 1d 12h, a number without unit is seconds
+func parseDuration(text string) (time.Duration, error) {
+	value := strings.ReplaceAll(text, " ", "")
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	if value == "" {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+	var d time.Duration
+	for value != "" {
+		i := 0
+		for i < len(value) && value[i] >= '0' && value[i] <= '9' {
+			i++
+		}
+		n, err := strconv.ParseInt(value[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", text)
+		}
+		value = value[i:]
+		unit := time.Duration(0)
+		for _, u := range timeUnits {
+			// ms before m
+			if strings.HasPrefix(value, u.suffix) && (u.suffix != "m" || !strings.HasPrefix(value, "ms")) {
+				unit = u.duration
+				value = value[len(u.suffix):]
+				break
+			}
+		}
+		if unit == 0 {
+			return 0, fmt.Errorf("invalid time %q", text)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
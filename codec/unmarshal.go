@@ -0,0 +1,171 @@
+package codec
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Unmarshal stores the directives of the block, like a *config.Config or a server, in the
+// fields of the struct v points to. Included files are read as part of the block and
+// fields without directive are left untouched. A directive repeated for a field that
+// is not a slice is an error, like in nginx.
+func Unmarshal(block config.IBlock, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("codec: Unmarshal of %T, a pointer to a struct is expected", v)
+	}
+	return unmarshalStruct(block, nil, rv.Elem())
+}
+
+// unmarshalStruct stores the directives of the block and the parameters of its directive
+func unmarshalStruct(block config.IBlock, params []config.Parameter, rv reflect.Value) error {
+	byName := map[string][]config.IDirective{}
+	for _, d := range config.Flatten(block) {
+		byName[d.GetName()] = append(byName[d.GetName()], d)
+	}
+	for _, f := range fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.args {
+			if err := unmarshalArgs(params, fv); err != nil {
+				return &Error{Field: f.goName, Err: err}
+			}
+			continue
+		}
+		ds := byName[f.name]
+		if len(ds) == 0 {
+			continue
+		}
+		if err := unmarshalField(f, ds, fv); err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				return err
+			}
+			return &Error{Field: f.goName, Line: ds[0].GetLine(), Err: err}
+		}
+	}
+	return nil
+}
+
+func unmarshalArgs(params []config.Parameter, fv reflect.Value) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		fv.SetString(strings.Join(config.UnquotedValues(params), " "))
+		return nil
+	case isParams(fv.Type()):
+		return unmarshalParams(params, fv)
+	}
+	return fmt.Errorf("args must be a string or a slice, not %s", fv.Type())
+}
+
+func unmarshalField(f field, ds []config.IDirective, fv reflect.Value) error {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	t := fv.Type()
+	isSlice := t.Kind() == reflect.Slice && !isParam(t) && !(f.params && isParams(t))
+	if !isSlice && len(ds) > 1 {
+		return &Error{Field: f.goName, Line: ds[1].GetLine(), Err: fmt.Errorf("%s directive is duplicate", f.name)}
+	}
+	switch {
+	case isParam(t):
+		params := ds[0].GetParameters()
+		if len(params) != 1 && t.Kind() != reflect.String {
+			return fmt.Errorf("%s directive has %d parameters, 1 expected", f.name, len(params))
+		}
+		// a string gets all the parameters, like "Host $host" for proxy_set_header
+		return unmarshalParam(strings.Join(config.UnquotedValues(params), " "), fv)
+	case f.params && isParams(t):
+		return unmarshalParams(ds[0].GetParameters(), fv)
+	case t.Kind() == reflect.Struct:
+		return unmarshalBlock(f, ds[0], fv)
+	case isSlice:
+		slice := reflect.MakeSlice(t, len(ds), len(ds))
+		for i, d := range ds {
+			if err := unmarshalField(field{name: f.name, goName: f.goName, params: true}, []config.IDirective{d}, slice.Index(i)); err != nil {
+				var e *Error
+				if errors.As(err, &e) {
+					return err
+				}
+				return &Error{Field: f.goName, Line: d.GetLine(), Err: err}
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+func unmarshalBlock(f field, d config.IDirective, fv reflect.Value) error {
+	if d.GetBlock() == nil {
+		return fmt.Errorf("%s directive has no block", f.name)
+	}
+	return unmarshalStruct(d.GetBlock(), d.GetParameters(), fv)
+}
+
+func unmarshalParams(params []config.Parameter, fv reflect.Value) error {
+	slice := reflect.MakeSlice(fv.Type(), len(params), len(params))
+	for i, p := range params {
+		if err := unmarshalParam(config.Unquote(p.GetValue()), slice.Index(i)); err != nil {
+			return err
+		}
+	}
+	fv.Set(slice)
+	return nil
+}
+
+func unmarshalParam(text string, fv reflect.Value) error {
+	if fv.Type() == durationType {
+		d, err := parseDuration(text)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(text)
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "on":
+			fv.SetBool(true)
+		case "off":
+			fv.SetBool(false)
+		default:
+			return fmt.Errorf("invalid value %q, it must be on or off", text)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+	return nil
+}
@@ -47,3 +47,55 @@ func (b *Block) FindDirectives(directiveName string) []IDirective {
 
 	return directives
 }
+
+// AddDirective adds a directive at the end of the block.
+func (b *Block) AddDirective(d IDirective) {
+	d.SetParent(b.Parent)
+	b.Directives = append(b.Directives, d)
+}
+
+// InsertBefore inserts a directive before ref.
+func (b *Block) InsertBefore(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(b.Directives, ref, d, false)
+	if err != nil {
+		return err
+	}
+	d.SetParent(b.Parent)
+	b.Directives = directives
+	return nil
+}
+
+// InsertAfter inserts a directive after ref.
+func (b *Block) InsertAfter(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(b.Directives, ref, d, true)
+	if err != nil {
+		return err
+	}
+	d.SetParent(b.Parent)
+	b.Directives = directives
+	return nil
+}
+
+// RemoveDirective removes a directive from the block.
+func (b *Block) RemoveDirective(d IDirective) error {
+	directives, err := removeDirective(b.Directives, d)
+	if err != nil {
+		return err
+	}
+	b.Directives = directives
+	return nil
+}
+
+// ReplaceDirective puts d in place of old.
+func (b *Block) ReplaceDirective(old IDirective, d IDirective) error {
+	if err := replaceDirective(b.Directives, old, d); err != nil {
+		return err
+	}
+	d.SetParent(b.Parent)
+	return nil
+}
+
+// MoveTo moves a directive of the block to the end of dst.
+func (b *Block) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(b, d, dst)
+}
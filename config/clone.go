@@ -0,0 +1,382 @@
+package config
+
+// DirectiveCloner is implemented by custom directive types so that Clone deep copies them,
+// the directives of other unknown types are shared by the copy.
+type DirectiveCloner interface {
+	CloneDirective() IDirective
+}
+
+// Clone returns a deep copy of the directive. Typed directives like Upstream or Location
+// keep their type, the parents inside the copy point to the copies and the parent of
+// the directive itself is kept.
+func Clone(d IDirective) IDirective {
+	c := newCloner()
+	cp := c.directive(d)
+	c.link()
+	return cp
+}
+
+// Clone returns a deep copy of the config, included configs are copied too.
+func (c *Config) Clone() *Config {
+	cl := newCloner()
+	cp := cl.config(c)
+	cl.link()
+	return cp
+}
+
+// Clone returns a deep copy of the block.
+func (b *Block) Clone() *Block {
+	c := newCloner()
+	cp := c.block(b).(*Block)
+	c.link()
+	return cp
+}
+
+// Clone returns a deep copy of the directive.
+func (d *Directive) Clone() *Directive {
+	return Clone(d).(*Directive)
+}
+
+// Clone returns a deep copy of the include and its configs.
+func (c *Include) Clone() *Include {
+	return Clone(c).(*Include)
+}
+
+// Clone returns a deep copy of the http block.
+func (h *HTTP) Clone() *HTTP {
+	return Clone(h).(*HTTP)
+}
+
+// Clone returns a deep copy of the server.
+func (s *Server) Clone() *Server {
+	return Clone(s).(*Server)
+}
+
+// Clone returns a deep copy of the location.
+func (l *Location) Clone() *Location {
+	return Clone(l).(*Location)
+}
+
+// Clone returns a deep copy of the upstream.
+func (us *Upstream) Clone() *Upstream {
+	return Clone(us).(*Upstream)
+}
+
+// Clone returns a deep copy of the upstream server.
+func (uss *UpstreamServer) Clone() *UpstreamServer {
+	return Clone(uss).(*UpstreamServer)
+}
+
+// Clone returns a deep copy of the lua block.
+func (lb *LuaBlock) Clone() *LuaBlock {
+	return Clone(lb).(*LuaBlock)
+}
+
+// Clone returns a deep copy of the stream block.
+func (s *Stream) Clone() *Stream {
+	return Clone(s).(*Stream)
+}
+
+// Clone returns a deep copy of the stream server.
+func (ss *StreamServer) Clone() *StreamServer {
+	return Clone(ss).(*StreamServer)
+}
+
+// Clone returns a deep copy of the stream upstream.
+func (su *StreamUpstream) Clone() *StreamUpstream {
+	return Clone(su).(*StreamUpstream)
+}
+
+// Clone returns a deep copy of the map.
+func (m *Map) Clone() *Map {
+	return Clone(m).(*Map)
+}
+
+// Clone returns a deep copy of the geo block.
+func (g *Geo) Clone() *Geo {
+	return Clone(g).(*Geo)
+}
+
+// Clone returns a deep copy of the split_clients block.
+func (sc *SplitClients) Clone() *SplitClients {
+	return Clone(sc).(*SplitClients)
+}
+
+// Clone returns a deep copy of the entry.
+func (e *MapEntry) Clone() *MapEntry {
+	return Clone(e).(*MapEntry)
+}
+
+// cloner copies a tree and remembers the copies to link them to the copied parents
+type cloner struct {
+	copies map[IDirective]IDirective
+	links  []func()
+}
+
+func newCloner() *cloner {
+	return &cloner{copies: map[IDirective]IDirective{}}
+}
+
+// link sets the parents of the copies once the whole tree is copied
+func (c *cloner) link() {
+	for _, l := range c.links {
+		l()
+	}
+}
+
+// parent returns the copy of the directive if it was copied, the directive otherwise
+func (c *cloner) parent(d IDirective) IDirective {
+	if cp, ok := c.copies[d]; ok {
+		return cp
+	}
+	return d
+}
+
+func (c *cloner) directive(d IDirective) IDirective {
+	if d == nil {
+		return nil
+	}
+	if cp, ok := c.copies[d]; ok {
+		return cp
+	}
+	var cp IDirective
+	switch d := d.(type) {
+	case *Directive:
+		cp = c.plain(d)
+	case *Include:
+		cp = c.include(d)
+	case *HTTP:
+		cp = c.http(d)
+	case *Server:
+		cp = c.server(d)
+	case *Location:
+		l := *d
+		l.Directive = c.plain(d.Directive)
+		cp = &l
+	case *Upstream:
+		cp = c.upstream(d)
+	case *UpstreamServer:
+		uss := *d
+		uss.Flags = cloneStrings(d.Flags)
+		if d.Parameters != nil {
+			uss.Parameters = make(map[string]string, len(d.Parameters))
+			for k, v := range d.Parameters {
+				uss.Parameters[k] = v
+			}
+		}
+		c.cloneCommon(&uss.Comment, &uss.DefaultInlineComment, &uss.DefaultTrivia)
+		cp = &uss
+	case *LuaBlock:
+		lb := *d
+		lb.Directives = c.directives(d.Directives)
+		lb.Parameters = cloneParameters(d.Parameters)
+		c.cloneCommon(&lb.Comment, &lb.DefaultInlineComment, &lb.DefaultTrivia)
+		cp = &lb
+	case *Stream:
+		s := *d
+		s.Directives = c.directives(d.Directives)
+		c.cloneCommon(&s.Comment, &s.DefaultInlineComment, &s.DefaultTrivia)
+		cp = &s
+	case *StreamServer:
+		ss := &StreamServer{}
+		c.copies[d] = ss
+		ss.Server = c.server(d.Server)
+		cp = ss
+	case *StreamUpstream:
+		su := &StreamUpstream{}
+		c.copies[d] = su
+		su.Upstream = c.upstream(d.Upstream)
+		cp = su
+	case *Map:
+		m := *d
+		c.copies[d] = &m
+		c.entryBlock(&m.EntryBlock, &d.EntryBlock)
+		cp = &m
+	case *Geo:
+		g := *d
+		c.copies[d] = &g
+		c.entryBlock(&g.EntryBlock, &d.EntryBlock)
+		cp = &g
+	case *SplitClients:
+		sc := *d
+		c.copies[d] = &sc
+		c.entryBlock(&sc.EntryBlock, &d.EntryBlock)
+		cp = &sc
+	case *MapEntry:
+		e := *d
+		c.cloneCommon(&e.Comment, &e.DefaultInlineComment, &e.DefaultTrivia)
+		if d.Include != nil {
+			e.Include = c.directive(d.Include).(*Include)
+		}
+		cp = &e
+	case DirectiveCloner:
+		cp = d.CloneDirective()
+	default:
+		return d
+	}
+	c.copies[d] = cp
+	c.links = append(c.links, func() {
+		cp.SetParent(c.parent(d.GetParent()))
+	})
+	return cp
+}
+
+func (c *cloner) directives(directives []IDirective) []IDirective {
+	if directives == nil {
+		return nil
+	}
+	cp := make([]IDirective, 0, len(directives))
+	for _, d := range directives {
+		cp = append(cp, c.directive(d))
+	}
+	return cp
+}
+
+// block copies a block, the blocks that are directives themselves like HTTP are copied as directives
+func (c *cloner) block(b IBlock) IBlock {
+	switch b := b.(type) {
+	case nil:
+		return nil
+	case *Block:
+		if b == nil {
+			return b
+		}
+		cp := *b
+		cp.Directives = c.directives(b.Directives)
+		c.links = append(c.links, func() {
+			cp.Parent = c.parent(b.Parent)
+		})
+		return &cp
+	case IDirective:
+		if d := c.directive(b); d != nil {
+			return d.GetBlock()
+		}
+	}
+	return b
+}
+
+func (c *cloner) config(conf *Config) *Config {
+	if conf == nil {
+		return nil
+	}
+	cp := *conf
+	if conf.Block != nil {
+		cp.Block = c.block(conf.Block).(*Block)
+	}
+	cp.Trivia = cloneTrivia(conf.Trivia)
+	cp.ClosingComment = cloneStrings(conf.ClosingComment)
+	return &cp
+}
+
+func (c *cloner) plain(d *Directive) *Directive {
+	if d == nil {
+		return nil
+	}
+	cp := *d
+	cp.Parameters = cloneParameters(d.Parameters)
+	cp.Block = c.block(d.Block)
+	c.cloneCommon(&cp.Comment, &cp.DefaultInlineComment, &cp.DefaultTrivia)
+	return &cp
+}
+
+func (c *cloner) include(i *Include) *Include {
+	cp := *i
+	cp.Directive = c.plain(i.Directive)
+	if i.Configs != nil {
+		cp.Configs = make([]*Config, 0, len(i.Configs))
+		for _, conf := range i.Configs {
+			cp.Configs = append(cp.Configs, c.config(conf))
+		}
+	}
+	return &cp
+}
+
+func (c *cloner) http(h *HTTP) *HTTP {
+	cp := *h
+	c.copies[h] = &cp
+	cp.Directives = c.directives(h.Directives)
+	cp.Servers = make([]*Server, 0, len(h.Servers))
+	for _, s := range h.Servers {
+		cp.Servers = append(cp.Servers, c.directive(s).(*Server))
+	}
+	cp.order = c.directives(h.order)
+	c.cloneCommon(&cp.Comment, &cp.DefaultInlineComment, &cp.DefaultTrivia)
+	return &cp
+}
+
+func (c *cloner) server(s *Server) *Server {
+	if s == nil {
+		return nil
+	}
+	cp := *s
+	c.copies[s] = &cp
+	cp.Block = c.block(s.Block)
+	c.cloneCommon(&cp.Comment, &cp.DefaultInlineComment, &cp.DefaultTrivia)
+	return &cp
+}
+
+func (c *cloner) upstream(us *Upstream) *Upstream {
+	if us == nil {
+		return nil
+	}
+	cp := *us
+	c.copies[us] = &cp
+	cp.Directives = c.directives(us.Directives)
+	cp.UpstreamServers = make([]*UpstreamServer, 0, len(us.UpstreamServers))
+	for _, uss := range us.UpstreamServers {
+		cp.UpstreamServers = append(cp.UpstreamServers, c.directive(uss).(*UpstreamServer))
+	}
+	cp.order = c.directives(us.order)
+	c.cloneCommon(&cp.Comment, &cp.DefaultInlineComment, &cp.DefaultTrivia)
+	c.links = append(c.links, func() {
+		if us.owner != nil {
+			cp.owner = c.parent(us.owner)
+		}
+	})
+	return &cp
+}
+
+// entryBlock copies the entries of eb into cp, a copy of eb
+func (c *cloner) entryBlock(cp *EntryBlock, eb *EntryBlock) {
+	if eb.Entries != nil {
+		cp.Entries = make([]*MapEntry, 0, len(eb.Entries))
+		for _, e := range eb.Entries {
+			cp.Entries = append(cp.Entries, c.directive(e).(*MapEntry))
+		}
+	}
+	c.cloneCommon(&cp.Comment, &cp.DefaultInlineComment, &cp.DefaultTrivia)
+	c.links = append(c.links, func() {
+		cp.owner = c.parent(eb.owner)
+	})
+}
+
+// cloneCommon copies the comments and the trivia the copy shares with the original
+func (c *cloner) cloneCommon(comment *[]string, inline *DefaultInlineComment, trivia *DefaultTrivia) {
+	*comment = cloneStrings(*comment)
+	if inline.InlineComment != nil {
+		inline.InlineComment = append([]InlineComment{}, inline.InlineComment...)
+	}
+	trivia.Trivia = cloneTrivia(trivia.Trivia)
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+func cloneParameters(p []Parameter) []Parameter {
+	if p == nil {
+		return nil
+	}
+	return append([]Parameter{}, p...)
+}
+
+func cloneTrivia(t *Trivia) *Trivia {
+	if t == nil {
+		return nil
+	}
+	cp := *t
+	return &cp
+}
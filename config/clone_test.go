@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestConfig_Clone(t *testing.T) {
+	t.Parallel()
+	conf := walkConf(t)
+	conf.Directives[0].(*Directive).SetTrivia(&Trivia{Text: "user www;"})
+	// link the parents like the parser
+	assert.NilError(t, Inspect(conf, func(c *Cursor) error {
+		c.Directive.SetParent(c.Parent())
+		return nil
+	}))
+	cp := conf.Clone()
+	assert.Assert(t, EqualBlock(conf, cp, EqualOptions{}))
+	assert.Equal(t, cp.FilePath, "nginx.conf")
+
+	http := cp.Directives[1].(*HTTP)
+	assert.Assert(t, http != conf.Directives[1])
+	include := http.Directives[0].(*Include)
+	assert.Equal(t, include.GetParent(), IDirective(http))
+	server := include.Configs[0].Directives[0].(*Server)
+	assert.Assert(t, server != conf.Directives[1].(*HTTP).Directives[0].(*Include).Configs[0].Directives[0])
+	location := server.GetBlock().GetDirectives()[1].(*Location)
+	assert.Equal(t, location.GetParent(), IDirective(server))
+	assert.Equal(t, location.GetBlock().GetDirectives()[0].GetParent(), IDirective(location))
+
+	upstream := http.Directives[1].(*Upstream)
+	assert.Equal(t, upstream.GetParent(), IDirective(http))
+	assert.Equal(t, len(upstream.UpstreamServers), 1)
+	assert.Equal(t, upstream.UpstreamServers[0], upstream.GetDirectives()[0])
+	assert.Equal(t, upstream.UpstreamServers[0].GetParent(), IDirective(upstream))
+
+	// the copy is independent
+	cp.Directives[0].(*Directive).Parameters[0].Value = "nobody"
+	cp.Directives[0].(*Directive).GetTrivia().Text = "user nobody;"
+	upstream.UpstreamServers[0].Parameters["weight"] = "2"
+	location.Parameters[0].Value = "/api"
+	assert.Equal(t, conf.Directives[0].GetParameters()[0].GetValue(), "www")
+	assert.Equal(t, conf.Directives[0].(*Directive).GetTrivia().Text, "user www;")
+	assert.Equal(t, len(conf.FindUpstreams()[0].UpstreamServers[0].Parameters), 0)
+	assert.Assert(t, !EqualBlock(conf, cp, EqualOptions{}))
+}
+
+func TestClone_TypedDirectives(t *testing.T) {
+	t.Parallel()
+	stream, err := NewStream(&Directive{
+		Name: "stream",
+		Block: &Block{Directives: []IDirective{
+			&Directive{Name: "upstream", Parameters: []Parameter{{Value: "dns"}}, Block: &Block{Directives: []IDirective{
+				&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:53"}}},
+			}}},
+			&Directive{Name: "server", Block: &Block{Directives: []IDirective{
+				&Directive{Name: "listen", Parameters: []Parameter{{Value: "53"}}},
+			}}},
+		}},
+	})
+	assert.NilError(t, err)
+	cp := stream.Clone()
+	su := cp.Directives[0].(*StreamUpstream)
+	assert.Equal(t, su.GetParent(), IDirective(cp))
+	assert.Equal(t, su.UpstreamServers[0].GetParent(), IDirective(su))
+	assert.Equal(t, su.self(), IDirective(su))
+	ss := cp.Directives[1].(*StreamServer)
+	assert.Equal(t, ss.GetBlock().GetParent(), IDirective(ss))
+	assert.Equal(t, ss.GetBlock().GetDirectives()[0].GetParent(), IDirective(ss))
+	assert.Assert(t, Equal(stream, cp, EqualOptions{}))
+
+	m, err := NewMap(&Directive{
+		Name:       "map",
+		Parameters: []Parameter{{Value: "$host"}, {Value: "$backend"}},
+		Block:      &Block{Directives: []IDirective{&Directive{Name: "default", Parameters: []Parameter{{Value: "a"}}}}},
+	})
+	assert.NilError(t, err)
+	mcp := m.Clone()
+	assert.Equal(t, mcp.Entries[0].GetParent(), IDirective(mcp))
+	assert.Equal(t, mcp.owner, IDirective(mcp))
+	mcp.SetDefault("b")
+	assert.Equal(t, m.GetDefault(), "a")
+
+	lua, err := NewLuaBlock(&Directive{Name: "content_by_lua_block", Block: &Block{IsLuaBlock: true, LiteralCode: "ngx.say(1)"}})
+	assert.NilError(t, err)
+	assert.Equal(t, lua.Clone().GetCodeBlock(), "ngx.say(1)")
+}
@@ -3,10 +3,14 @@ package config
 // Config represents a complete nginx configuration file.
 type Config struct {
 	*Block
-	FilePath string
+	DefaultTrivia
+	FilePath       string
+	ClosingComment []string // the comments after the last directive of the file
 }
 
 // Global wrappers provide extension points for custom directive handling.
+// They are copied by each new parser, use the parser options like
+// parser.WithBlockWrapper to change them for a single parser.
 var (
 	BlockWrappers     = map[string]func(*Directive) (IDirective, error){}
 	DirectiveWrappers = map[string]func(*Directive) (IDirective, error){}
@@ -50,17 +54,41 @@ func (c *Config) FindDirectives(directiveName string) []IDirective {
 	return c.Block.FindDirectives(directiveName)
 }
 
-// FindUpstreams find directives from whole config block
+// FindUpstreams find the http upstreams from whole config block
 func (c *Config) FindUpstreams() []*Upstream {
 	var upstreams []*Upstream
 	directives := c.Block.FindDirectives("upstream")
 	for _, directive := range directives {
-		//	up, _ := NewUpstream(directive)
-		upstreams = append(upstreams, directive.(*Upstream))
+		// an upstream without a block is not wrapped
+		if upstream, ok := directive.(*Upstream); ok {
+			upstreams = append(upstreams, upstream)
+		}
 	}
 	return upstreams
 }
 
+// FindStreamUpstreams find the upstreams of stream blocks from whole config block
+func (c *Config) FindStreamUpstreams() []*StreamUpstream {
+	var upstreams []*StreamUpstream
+	for _, directive := range c.Block.FindDirectives("upstream") {
+		if upstream, ok := directive.(*StreamUpstream); ok {
+			upstreams = append(upstreams, upstream)
+		}
+	}
+	return upstreams
+}
+
+// FindStreams find the stream blocks from whole config block
+func (c *Config) FindStreams() []*Stream {
+	var streams []*Stream
+	for _, directive := range c.Block.FindDirectives("stream") {
+		if stream, ok := directive.(*Stream); ok {
+			streams = append(streams, stream)
+		}
+	}
+	return streams
+}
+
 func init() {
 	BlockWrappers["http"] = func(directive *Directive) (IDirective, error) {
 		return NewHTTP(directive)
@@ -74,6 +102,18 @@ func init() {
 	BlockWrappers["server"] = func(directive *Directive) (IDirective, error) {
 		return NewServer(directive)
 	}
+	BlockWrappers["map"] = func(directive *Directive) (IDirective, error) {
+		return NewMap(directive)
+	}
+	BlockWrappers["geo"] = func(directive *Directive) (IDirective, error) {
+		return NewGeo(directive)
+	}
+	BlockWrappers["split_clients"] = func(directive *Directive) (IDirective, error) {
+		return NewSplitClients(directive)
+	}
+	BlockWrappers["stream"] = func(directive *Directive) (IDirective, error) {
+		return NewStream(directive)
+	}
 	BlockWrappers["upstream"] = func(directive *Directive) (IDirective, error) {
 		return NewUpstream(directive)
 	}
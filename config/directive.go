@@ -7,6 +7,8 @@ type Directive struct {
 	Parameters []Parameter //TODO: Save parameters with their type
 	Comment    []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	Parent IDirective
 	Line   int
 }
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EqualOptions tells Equal which differences to ignore. The source text kept by a
+// lossless parse and the Go types of the directives are never compared, a *Server
+// is equal to a server *Directive with the same content.
+type EqualOptions struct {
+	IgnoreComments bool // comments and inline comments
+	IgnoreLines    bool // line numbers
+	// IgnoreOrder compares the directives of a block in any order, except the ones
+	// nginx reads in order: the rewrite module directives (if, rewrite, return, set,
+	// break), the regex locations, the regex entries of a map and the entries of
+	// split_clients, which keep their relative order.
+	IgnoreOrder bool
+	// ExpandIncludes compares the directives of the included files in place of the
+	// include directives, a config is equal to the same config with includes.
+	ExpandIncludes bool
+}
+
+// Equal reports whether two directives and their sub directives are the same
+func Equal(a, b IDirective, opts EqualOptions) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return canonical(a, opts) == canonical(b, opts)
+}
+
+// EqualBlock reports whether the directives of two blocks, like two *Config, are the same
+func EqualBlock(a, b IBlock, opts EqualOptions) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return canonicalBlock(a, opts) == canonicalBlock(b, opts)
+}
+
+// canonical returns a text that is the same for equal directives
+func canonical(d IDirective, opts EqualOptions) string {
+	var sb strings.Builder
+	if !opts.IgnoreComments {
+		for _, c := range d.GetComment() {
+			sb.WriteString("#")
+			sb.WriteString(c)
+			sb.WriteString("\n")
+		}
+	}
+	if !opts.IgnoreLines {
+		sb.WriteString(strconv.Itoa(d.GetLine()))
+		sb.WriteString(":")
+	}
+	sb.WriteString(d.GetName())
+	for _, p := range d.GetParameters() {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(p.GetValue()))
+	}
+	if commenter, ok := d.(InlineCommenter); ok && !opts.IgnoreComments {
+		for _, c := range commenter.GetInlineComment() {
+			sb.WriteString(" #")
+			sb.WriteString(c.Value)
+		}
+	}
+	if b := d.GetBlock(); b != nil {
+		sb.WriteString(" {")
+		if code := b.GetCodeBlock(); code != "" {
+			sb.WriteString(strconv.Quote(code))
+		}
+		sb.WriteString(canonicalBlock(b, opts))
+		sb.WriteString("}")
+	}
+	return sb.String()
+}
+
+// canonicalBlock returns a text that is the same for blocks with equal directives
+func canonicalBlock(b IBlock, opts EqualOptions) string {
+	var ordered, unordered []string
+	for _, d := range equalDirectives(b, opts) {
+		c := canonical(d, opts)
+		if opts.IgnoreOrder && !keepsOrder(d) {
+			unordered = append(unordered, c)
+			continue
+		}
+		ordered = append(ordered, c)
+	}
+	sort.Strings(unordered)
+	return strings.Join(ordered, "\n") + "\n--\n" + strings.Join(unordered, "\n")
+}
+
+// equalDirectives returns the directives of the block to compare
+func equalDirectives(b IBlock, opts EqualOptions) []IDirective {
+	if !opts.ExpandIncludes {
+		return b.GetDirectives()
+	}
+	directives := make([]IDirective, 0)
+	for _, d := range b.GetDirectives() {
+		include, ok := d.(*Include)
+		if !ok {
+			directives = append(directives, d)
+			continue
+		}
+		for _, c := range include.Configs {
+			if c != nil && c.Block != nil {
+				directives = append(directives, equalDirectives(c.Block, opts)...)
+			}
+		}
+	}
+	return directives
+}
+
+// keepsOrder reports whether nginx reads the directive in order with its siblings
+func keepsOrder(d IDirective) bool {
+	switch d.GetName() {
+	case "if", "rewrite", "return", "set", "break":
+		return true
+	case "location":
+		modifier := ""
+		if l, ok := d.(*Location); ok {
+			modifier, _ = l.ModifierMatch()
+		} else if params := d.GetParameters(); len(params) == 1 {
+			modifier, _ = splitModifier("", params[0].GetValue())
+		} else if len(params) == 2 {
+			modifier = params[0].GetValue()
+		}
+		return modifier == "~" || modifier == "~*"
+	}
+	if e, ok := d.(*MapEntry); ok {
+		if _, ok := e.Parent.(*SplitClients); ok {
+			return true
+		}
+		kind := e.Kind()
+		return kind == RegexEntry || kind == CaseInsensitiveRegexEntry
+	}
+	return false
+}
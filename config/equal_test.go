@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func directive(name string, line int, params ...string) *Directive {
+	d := &Directive{Name: name, Line: line}
+	for _, p := range params {
+		d.Parameters = append(d.Parameters, Parameter{Value: p})
+	}
+	return d
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+	block := func(directives ...IDirective) *Directive {
+		return &Directive{Name: "server", Block: &Block{Directives: directives}}
+	}
+	location := func(params ...string) *Location {
+		l, err := NewLocation(&Directive{Name: "location", Parameters: directive("location", 0, params...).Parameters, Block: &Block{}})
+		assert.NilError(t, err)
+		return l
+	}
+	commented := directive("listen", 2, "80")
+	commented.Comment = []string{"plain http"}
+	tests := []struct {
+		name string
+		a, b IDirective
+		opts EqualOptions
+		want bool
+	}{
+		{
+			name: "same",
+			a:    block(directive("listen", 2, "80"), directive("server_name", 3, "a")),
+			b:    block(directive("listen", 2, "80"), directive("server_name", 3, "a")),
+			want: true,
+		},
+		{
+			name: "parameter",
+			a:    block(directive("listen", 2, "80")),
+			b:    block(directive("listen", 2, "8080")),
+			opts: EqualOptions{IgnoreComments: true, IgnoreLines: true, IgnoreOrder: true},
+		},
+		{
+			name: "lines",
+			a:    block(directive("listen", 2, "80")),
+			b:    block(directive("listen", 4, "80")),
+		},
+		{
+			name: "ignored lines",
+			a:    block(directive("listen", 2, "80")),
+			b:    block(directive("listen", 4, "80")),
+			opts: EqualOptions{IgnoreLines: true},
+			want: true,
+		},
+		{
+			name: "comments",
+			a:    block(commented),
+			b:    block(directive("listen", 2, "80")),
+		},
+		{
+			name: "ignored comments",
+			a:    block(commented),
+			b:    block(directive("listen", 2, "80")),
+			opts: EqualOptions{IgnoreComments: true},
+			want: true,
+		},
+		{
+			name: "order",
+			a:    block(directive("listen", 0, "80"), directive("server_name", 0, "a")),
+			b:    block(directive("server_name", 0, "a"), directive("listen", 0, "80")),
+		},
+		{
+			name: "ignored order",
+			a:    block(directive("listen", 0, "80"), directive("server_name", 0, "a")),
+			b:    block(directive("server_name", 0, "a"), directive("listen", 0, "80")),
+			opts: EqualOptions{IgnoreOrder: true},
+			want: true,
+		},
+		{
+			name: "rewrites keep their order",
+			a:    block(directive("listen", 0, "80"), directive("rewrite", 0, "^/a", "/b"), directive("return", 0, "404")),
+			b:    block(directive("return", 0, "404"), directive("listen", 0, "80"), directive("rewrite", 0, "^/a", "/b")),
+			opts: EqualOptions{IgnoreOrder: true},
+		},
+		{
+			name: "regex locations keep their order",
+			a:    block(directive("location", 0, "~", "a"), directive("location", 0, "/"), directive("location", 0, "~", "b")),
+			b:    block(directive("location", 0, "/"), directive("location", 0, "~", "b"), directive("location", 0, "~", "a")),
+			opts: EqualOptions{IgnoreOrder: true},
+		},
+		{
+			name: "regex locations with an attached modifier keep their order",
+			a:    block(directive("location", 0, "~/a"), directive("location", 0, "/"), directive("location", 0, "~*/b")),
+			b:    block(directive("location", 0, "/"), directive("location", 0, "~*/b"), directive("location", 0, "~/a")),
+			opts: EqualOptions{IgnoreOrder: true},
+		},
+		{
+			name: "typed regex locations keep their order",
+			a:    block(location("~/a"), location("/"), location("~", "/b")),
+			b:    block(location("/"), location("~", "/b"), location("~/a")),
+			opts: EqualOptions{IgnoreOrder: true},
+		},
+		{
+			name: "prefix locations are not ordered",
+			a:    block(location("=/a"), location("^~", "/b")),
+			b:    block(location("^~", "/b"), location("=/a")),
+			opts: EqualOptions{IgnoreOrder: true},
+			want: true,
+		},
+		{
+			name: "typed directives",
+			a:    NewServerOrNill(block(directive("listen", 0, "80"))),
+			b:    block(directive("listen", 0, "80")),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, Equal(tt.a, tt.b, tt.opts), tt.want)
+		})
+	}
+}
+
+func TestEqualBlock_Includes(t *testing.T) {
+	t.Parallel()
+	included := &Include{
+		Directive:   directive("include", 1, "listen.conf"),
+		IncludePath: "listen.conf",
+		Configs:     []*Config{{Block: &Block{Directives: []IDirective{directive("listen", 1, "80")}}, FilePath: "listen.conf"}},
+	}
+	a := &Config{Block: &Block{Directives: []IDirective{included, directive("server_name", 2, "a")}}}
+	b := &Config{Block: &Block{Directives: []IDirective{directive("listen", 1, "80"), directive("server_name", 2, "a")}}}
+	assert.Assert(t, !EqualBlock(a, b, EqualOptions{}))
+	assert.Assert(t, EqualBlock(a, b, EqualOptions{ExpandIncludes: true}))
+}
@@ -10,8 +10,12 @@ type HTTP struct {
 	Directives []IDirective
 	Comment    []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	Parent IDirective
 	Line   int
+
+	order []IDirective // the directives and the servers in their order in the block
 }
 
 // SetLine sets the line number.
@@ -59,6 +63,7 @@ func NewHTTP(directive IDirective) (*HTTP, error) {
 			}
 			http.Directives = append(http.Directives, directive)
 		}
+		http.order = block.GetDirectives()
 		http.Comment = directive.GetComment()
 		http.InlineComment = directive.GetInlineComment()
 
@@ -77,14 +82,14 @@ func (h *HTTP) GetParameters() []Parameter {
 	return []Parameter{}
 }
 
-// GetDirectives returns all directives in the http block.
+// GetDirectives returns all directives in the http block, servers included, in
+// their order. Directives and servers only added to the slices come last.
 func (h *HTTP) GetDirectives() []IDirective {
-	directives := make([]IDirective, 0)
-	directives = append(directives, h.Directives...)
-	for _, directive := range h.Servers {
-		directives = append(directives, directive)
+	servers := make([]IDirective, 0, len(h.Servers))
+	for _, server := range h.Servers {
+		servers = append(servers, server)
 	}
-	return directives
+	return ordered(h.order, h.Directives, servers)
 }
 
 // FindDirectives finds directives in the http block.
@@ -116,3 +121,74 @@ func (h *HTTP) GetBlock() IBlock {
 func (h *HTTP) GetCodeBlock() string {
 	return ""
 }
+
+// sync applies a change to all the directives of the block, then splits them
+// into Directives and Servers again.
+func (h *HTTP) sync(change func(directives []IDirective) ([]IDirective, error)) error {
+	directives, err := change(h.GetDirectives())
+	if err != nil {
+		return err
+	}
+	h.order = directives
+	h.Directives = []IDirective{}
+	h.Servers = []*Server{}
+	for _, directive := range directives {
+		if server, ok := directive.(*Server); ok {
+			h.Servers = append(h.Servers, server)
+			continue
+		}
+		h.Directives = append(h.Directives, directive)
+	}
+	return nil
+}
+
+// AddDirective adds a directive at the end of the http block.
+func (h *HTTP) AddDirective(d IDirective) {
+	_ = h.sync(func(directives []IDirective) ([]IDirective, error) {
+		return append(directives, d), nil
+	})
+	d.SetParent(h)
+}
+
+// InsertBefore inserts a directive before ref.
+func (h *HTTP) InsertBefore(ref IDirective, d IDirective) error {
+	return h.insert(ref, d, false)
+}
+
+// InsertAfter inserts a directive after ref.
+func (h *HTTP) InsertAfter(ref IDirective, d IDirective) error {
+	return h.insert(ref, d, true)
+}
+
+func (h *HTTP) insert(ref IDirective, d IDirective, after bool) error {
+	err := h.sync(func(directives []IDirective) ([]IDirective, error) {
+		return insertDirective(directives, ref, d, after)
+	})
+	if err == nil {
+		d.SetParent(h)
+	}
+	return err
+}
+
+// RemoveDirective removes a directive from the http block.
+func (h *HTTP) RemoveDirective(d IDirective) error {
+	return h.sync(func(directives []IDirective) ([]IDirective, error) {
+		return removeDirective(directives, d)
+	})
+}
+
+// ReplaceDirective puts d in place of old.
+func (h *HTTP) ReplaceDirective(old IDirective, d IDirective) error {
+	err := h.sync(func(directives []IDirective) ([]IDirective, error) {
+		return directives, replaceDirective(directives, old, d)
+	})
+	if err == nil {
+		d.SetParent(h)
+	}
+	return err
+}
+
+// MoveTo moves a directive of the http block to the end of dst.
+func (h *HTTP) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(h, d, dst)
+}
@@ -83,17 +83,41 @@ func NewInclude(dir IDirective) (*Include, error) {
 	if !ok {
 		return nil, errors.New("type error")
 	}
-	include := &Include{
-		Directive:   directive,
-		IncludePath: directive.Parameters[0].GetValue(),
+	if len(directive.Parameters) == 0 {
+		return nil, errors.New("include directive must have a parameter")
 	}
 
 	if len(directive.Parameters) > 1 {
-		panic("include directive can not have multiple parameters")
+		return nil, errors.New("include directive can not have multiple parameters")
 	}
 
 	if directive.Block != nil {
-		panic("include can not have a block, or missing semicolon at the end of include statement")
+		return nil, errors.New("include can not have a block, or missing semicolon at the end of include statement")
+	}
+
+	return &Include{
+		Directive:   directive,
+		IncludePath: directive.Parameters[0].GetValue(),
+	}, nil
+}
+
+// Flatten returns the directives of the block with the directives of the included files in place
+// of their include, an include whose files are not parsed is kept
+func Flatten(b IBlock) []IDirective {
+	if b == nil {
+		return nil
+	}
+	all := make([]IDirective, 0)
+	for _, d := range b.GetDirectives() {
+		if include, ok := d.(*Include); ok && len(include.Configs) > 0 {
+			for _, c := range include.Configs {
+				if c != nil {
+					all = append(all, Flatten(c.Block)...)
+				}
+			}
+			continue
+		}
+		all = append(all, d)
 	}
-	return include, nil
+	return all
 }
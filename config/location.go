@@ -1,6 +1,9 @@
 package config
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Location represents a location block in an nginx configuration.
 type Location struct {
@@ -57,6 +60,24 @@ func NewLocation(directive IDirective) (*Location, error) {
 	return nil, errors.New("too many arguments for location directive")
 }
 
+// ModifierMatch returns the modifier and the match of the location, a modifier
+// written without space like in `location =/` is split from the match.
+func (l *Location) ModifierMatch() (string, string) {
+	return splitModifier(l.Modifier, l.Match)
+}
+
+func splitModifier(modifier, match string) (string, string) {
+	if modifier != "" {
+		return modifier, match
+	}
+	for _, m := range []string{"=", "^~", "~*", "~"} {
+		if strings.HasPrefix(match, m) && match != m {
+			return m, match[len(m):]
+		}
+	}
+	return "", match
+}
+
 // FindDirectives finds directives by name.
 func (l *Location) FindDirectives(directiveName string) []IDirective {
 	block := l.GetBlock()
@@ -10,6 +10,8 @@ type LuaBlock struct {
 	Name       string
 	Comment    []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	LuaCode    string
 	Parent     IDirective
 	Line       int
@@ -112,3 +114,55 @@ func (lb *LuaBlock) GetComment() []string {
 func (lb *LuaBlock) SetComment(comment []string) {
 	lb.Comment = comment
 }
+
+// AddDirective adds a directive at the end of the block
+func (lb *LuaBlock) AddDirective(d IDirective) {
+	d.SetParent(lb)
+	lb.Directives = append(lb.Directives, d)
+}
+
+// InsertBefore inserts a directive before ref
+func (lb *LuaBlock) InsertBefore(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(lb.Directives, ref, d, false)
+	if err != nil {
+		return err
+	}
+	d.SetParent(lb)
+	lb.Directives = directives
+	return nil
+}
+
+// InsertAfter inserts a directive after ref
+func (lb *LuaBlock) InsertAfter(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(lb.Directives, ref, d, true)
+	if err != nil {
+		return err
+	}
+	d.SetParent(lb)
+	lb.Directives = directives
+	return nil
+}
+
+// RemoveDirective removes a directive from the block
+func (lb *LuaBlock) RemoveDirective(d IDirective) error {
+	directives, err := removeDirective(lb.Directives, d)
+	if err != nil {
+		return err
+	}
+	lb.Directives = directives
+	return nil
+}
+
+// ReplaceDirective puts d in place of old
+func (lb *LuaBlock) ReplaceDirective(old IDirective, d IDirective) error {
+	if err := replaceDirective(lb.Directives, old, d); err != nil {
+		return err
+	}
+	d.SetParent(lb)
+	return nil
+}
+
+// MoveTo moves a directive of the block to the end of dst
+func (lb *LuaBlock) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(lb, d, dst)
+}
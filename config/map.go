@@ -0,0 +1,550 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// MapEntryKind is the kind of an entry of a map, geo or split_clients block
+type MapEntryKind int
+
+const (
+	// ExactEntry a value matched as is, a network in geo or a percentage in split_clients
+	ExactEntry MapEntryKind = iota
+	// RegexEntry a case sensitive regular expression, like ~^/api
+	RegexEntry
+	// CaseInsensitiveRegexEntry a case insensitive regular expression, like ~*\.jpg$
+	CaseInsensitiveRegexEntry
+	// DefaultEntry the value used when nothing matches, `default` or `*` in split_clients
+	DefaultEntry
+	// HostnamesEntry the hostnames parameter of map
+	HostnamesEntry
+	// VolatileEntry the volatile parameter of map
+	VolatileEntry
+	// IncludeEntry a file of entries
+	IncludeEntry
+	// RangesEntry the ranges parameter of geo
+	RangesEntry
+	// DeleteEntry a network deleted in geo
+	DeleteEntry
+	// ProxyEntry a trusted proxy address in geo
+	ProxyEntry
+	// ProxyRecursiveEntry the proxy_recursive parameter of geo
+	ProxyRecursiveEntry
+)
+
+// parameters are entries without a value
+var mapParameterKinds = map[string]MapEntryKind{
+	"hostnames":       HostnamesEntry,
+	"volatile":        VolatileEntry,
+	"ranges":          RangesEntry,
+	"proxy_recursive": ProxyRecursiveEntry,
+}
+
+var mapValueKinds = map[string]MapEntryKind{
+	"default": DefaultEntry,
+	"include": IncludeEntry,
+	"delete":  DeleteEntry,
+	"proxy":   ProxyEntry,
+}
+
+// MapEntry represents a line of a map, geo or split_clients block, like `~^/api backend;`
+type MapEntry struct {
+	Key     string // as in the source, quoted if needed
+	Value   string // empty for parameters like hostnames
+	Comment []string
+	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
+	Parent IDirective
+	Line   int
+	// Include is the include directive of an include entry, kept with its
+	// included configs. The block returns it in place of the entry.
+	Include *Include
+}
+
+// directive returns the directive of the entry in its block
+func (e *MapEntry) directive() IDirective {
+	if e.Include != nil {
+		return e.Include
+	}
+	return e
+}
+
+// Kind returns the kind of the entry, guessed from its key
+func (e *MapEntry) Kind() MapEntryKind {
+	if e.Value == "" {
+		if kind, ok := mapParameterKinds[e.Key]; ok {
+			return kind
+		}
+		return ExactEntry
+	}
+	if kind, ok := mapValueKinds[e.Key]; ok {
+		return kind
+	}
+	switch {
+	case e.Key == "*":
+		return DefaultEntry
+	case strings.HasPrefix(e.Key, "~*"):
+		return CaseInsensitiveRegexEntry
+	case strings.HasPrefix(e.Key, "~"):
+		return RegexEntry
+	}
+	return ExactEntry
+}
+
+// SetLine sets the line number.
+func (e *MapEntry) SetLine(line int) {
+	e.Line = line
+}
+
+// GetLine returns the line number.
+func (e *MapEntry) GetLine() int {
+	return e.Line
+}
+
+// SetParent sets the parent directive.
+func (e *MapEntry) SetParent(parent IDirective) {
+	e.Parent = parent
+	if e.Include != nil {
+		e.Include.SetParent(parent)
+	}
+}
+
+// GetParent returns the parent directive.
+func (e *MapEntry) GetParent() IDirective {
+	return e.Parent
+}
+
+// SetComment sets the entry comment.
+func (e *MapEntry) SetComment(comment []string) {
+	e.Comment = comment
+}
+
+// GetComment returns the entry comment.
+func (e *MapEntry) GetComment() []string {
+	return e.Comment
+}
+
+// GetName returns the key of the entry.
+func (e *MapEntry) GetName() string {
+	return e.Key
+}
+
+// GetParameters returns the value of the entry, if any.
+func (e *MapEntry) GetParameters() []Parameter {
+	if e.Value == "" {
+		return []Parameter{}
+	}
+	return []Parameter{{Value: e.Value}}
+}
+
+// GetBlock returns nil because entries do not have blocks.
+func (e *MapEntry) GetBlock() IBlock {
+	return nil
+}
+
+// NewMapEntry creates an entry from a directive of a map, geo or split_clients block.
+func NewMapEntry(directive IDirective) (*MapEntry, error) {
+	if include, ok := directive.(*Include); ok {
+		e := &MapEntry{Key: include.GetName(), Value: include.IncludePath, Line: include.GetLine(), Include: include}
+		keepColumn(include, e)
+		return e, nil
+	}
+	if directive.GetBlock() != nil {
+		return nil, errors.New("map entry can not have a block")
+	}
+	parameters := directive.GetParameters()
+	if len(parameters) > 1 {
+		return nil, errors.New("invalid number of parameters in '" + directive.GetName() + "' entry")
+	}
+	e := &MapEntry{
+		Key:     directive.GetName(),
+		Comment: directive.GetComment(),
+	}
+	if len(parameters) == 1 {
+		e.Value = parameters[0].GetValue()
+	}
+	e.InlineComment = directive.GetInlineComment()
+	keepSource(directive, e)
+	return e, nil
+}
+
+// EntryBlock is the common part of map, geo and split_clients blocks, a list
+// of entries setting a variable
+type EntryBlock struct {
+	Entries []*MapEntry
+	Comment []string
+	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
+	Parent IDirective
+	Line   int
+
+	owner IDirective // the map, geo or split_clients the entries belong to
+}
+
+// newEntryBlock reads the entries of the block of a directive
+func newEntryBlock(directive IDirective) (EntryBlock, error) {
+	eb := EntryBlock{
+		Entries: []*MapEntry{},
+		Comment: directive.GetComment(),
+	}
+	eb.InlineComment = directive.GetInlineComment()
+	block := directive.GetBlock()
+	if block == nil {
+		return eb, errors.New(directive.GetName() + " directive must have a block")
+	}
+	for _, d := range block.GetDirectives() {
+		e, err := NewMapEntry(d)
+		if err != nil {
+			return eb, err
+		}
+		eb.Entries = append(eb.Entries, e)
+	}
+	return eb, nil
+}
+
+// SetLine sets the line number.
+func (eb *EntryBlock) SetLine(line int) {
+	eb.Line = line
+}
+
+// GetLine returns the line number.
+func (eb *EntryBlock) GetLine() int {
+	return eb.Line
+}
+
+// SetParent sets the parent directive.
+func (eb *EntryBlock) SetParent(parent IDirective) {
+	eb.Parent = parent
+}
+
+// GetParent returns the parent directive.
+func (eb *EntryBlock) GetParent() IDirective {
+	return eb.Parent
+}
+
+// SetComment sets the directive comment.
+func (eb *EntryBlock) SetComment(comment []string) {
+	eb.Comment = comment
+}
+
+// GetComment returns the directive comment.
+func (eb *EntryBlock) GetComment() []string {
+	return eb.Comment
+}
+
+// GetDirectives returns the entries, the include directive of include entries.
+func (eb *EntryBlock) GetDirectives() []IDirective {
+	directives := make([]IDirective, 0, len(eb.Entries))
+	for _, e := range eb.Entries {
+		directives = append(directives, e.directive())
+	}
+	return directives
+}
+
+// FindDirectives finds the entries with the given key.
+func (eb *EntryBlock) FindDirectives(directiveName string) []IDirective {
+	directives := make([]IDirective, 0)
+	for _, e := range eb.Entries {
+		if e.Key == directiveName {
+			directives = append(directives, e.directive())
+		}
+	}
+	return directives
+}
+
+// GetCodeBlock returns the literal code block.
+func (eb *EntryBlock) GetCodeBlock() string {
+	return ""
+}
+
+// FindEntry finds the entry with the given key, nil if there is none.
+func (eb *EntryBlock) FindEntry(key string) *MapEntry {
+	for _, e := range eb.Entries {
+		if e.Key == key {
+			return e
+		}
+	}
+	return nil
+}
+
+// AddEntry adds an entry at the end of the block.
+func (eb *EntryBlock) AddEntry(key, value string) *MapEntry {
+	e := &MapEntry{Key: key, Value: value, Parent: eb.owner}
+	eb.Entries = append(eb.Entries, e)
+	return e
+}
+
+// SetEntry sets the value of the entry with the given key, the entry is added if there is none.
+func (eb *EntryBlock) SetEntry(key, value string) *MapEntry {
+	if e := eb.FindEntry(key); e != nil {
+		e.Value = value
+		return e
+	}
+	return eb.AddEntry(key, value)
+}
+
+// RemoveEntry removes the entries with the given key, it reports whether any was found.
+func (eb *EntryBlock) RemoveEntry(key string) bool {
+	entries := eb.Entries[:0]
+	for _, e := range eb.Entries {
+		if e.Key != key {
+			entries = append(entries, e)
+		}
+	}
+	removed := len(entries) != len(eb.Entries)
+	eb.Entries = entries
+	return removed
+}
+
+// GetDefault returns the default value, empty if there is none.
+func (eb *EntryBlock) GetDefault() string {
+	for _, e := range eb.Entries {
+		if e.Kind() == DefaultEntry {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// toMapEntry returns the directive as an entry, the parameters of other directives make the value
+func toMapEntry(d IDirective) *MapEntry {
+	if e, ok := d.(*MapEntry); ok {
+		return e
+	}
+	if include, ok := d.(*Include); ok {
+		e, _ := NewMapEntry(include)
+		return e
+	}
+	values := make([]string, 0, len(d.GetParameters()))
+	for _, p := range d.GetParameters() {
+		values = append(values, p.GetValue())
+	}
+	e := &MapEntry{Key: d.GetName(), Value: strings.Join(values, " "), Comment: d.GetComment()}
+	e.InlineComment = d.GetInlineComment()
+	return e
+}
+
+// entryIndex returns the position of the entry, -1 if it is not in the block
+func (eb *EntryBlock) entryIndex(d IDirective) int {
+	for i, e := range eb.Entries {
+		if IDirective(e) == d || e.directive() == d {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddDirective adds an entry at the end of the block, other directives are turned into entries
+// and an include is kept as the Include of its entry.
+func (eb *EntryBlock) AddDirective(d IDirective) {
+	e := toMapEntry(d)
+	e.SetParent(eb.owner)
+	eb.Entries = append(eb.Entries, e)
+}
+
+// InsertBefore inserts an entry before ref.
+func (eb *EntryBlock) InsertBefore(ref IDirective, d IDirective) error {
+	return eb.insert(ref, d, false)
+}
+
+// InsertAfter inserts an entry after ref.
+func (eb *EntryBlock) InsertAfter(ref IDirective, d IDirective) error {
+	return eb.insert(ref, d, true)
+}
+
+func (eb *EntryBlock) insert(ref IDirective, d IDirective, after bool) error {
+	i := eb.entryIndex(ref)
+	if i < 0 {
+		return ErrDirectiveNotFound
+	}
+	if after {
+		i++
+	}
+	e := toMapEntry(d)
+	e.SetParent(eb.owner)
+	eb.Entries = append(eb.Entries, nil)
+	copy(eb.Entries[i+1:], eb.Entries[i:])
+	eb.Entries[i] = e
+	return nil
+}
+
+// RemoveDirective removes an entry from the block.
+func (eb *EntryBlock) RemoveDirective(d IDirective) error {
+	i := eb.entryIndex(d)
+	if i < 0 {
+		return ErrDirectiveNotFound
+	}
+	eb.Entries = append(eb.Entries[:i], eb.Entries[i+1:]...)
+	return nil
+}
+
+// ReplaceDirective puts d in place of the old entry.
+func (eb *EntryBlock) ReplaceDirective(old IDirective, d IDirective) error {
+	i := eb.entryIndex(old)
+	if i < 0 {
+		return ErrDirectiveNotFound
+	}
+	e := toMapEntry(d)
+	e.SetParent(eb.owner)
+	eb.Entries[i] = e
+	return nil
+}
+
+// MoveTo moves an entry of the block to the end of dst.
+func (eb *EntryBlock) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(eb, d, dst)
+}
+
+// setOwner makes the directive the parent of the entries
+func (eb *EntryBlock) setOwner(owner IDirective) {
+	eb.owner = owner
+	for _, e := range eb.Entries {
+		e.SetParent(owner)
+	}
+}
+
+// Map represents a map block, which sets a variable depending on the value of a source.
+type Map struct {
+	EntryBlock
+	Source   string // like $http_host
+	Variable string // like $backend
+}
+
+// NewMap creates a map from a directive with a block.
+func NewMap(directive IDirective) (*Map, error) {
+	parameters := directive.GetParameters()
+	if len(parameters) != 2 {
+		return nil, errors.New("map directive must have a source and a variable")
+	}
+	eb, err := newEntryBlock(directive)
+	if err != nil {
+		return nil, err
+	}
+	m := &Map{
+		EntryBlock: eb,
+		Source:     parameters[0].GetValue(),
+		Variable:   parameters[1].GetValue(),
+	}
+	m.setOwner(m)
+	return m, nil
+}
+
+// GetName implements the Statement interface.
+func (m *Map) GetName() string {
+	return "map"
+}
+
+// GetParameters returns the source and the variable.
+func (m *Map) GetParameters() []Parameter {
+	return []Parameter{{Value: m.Source}, {Value: m.Variable}}
+}
+
+// GetBlock returns the map itself, which implements IBlock.
+func (m *Map) GetBlock() IBlock {
+	return m
+}
+
+// SetDefault sets the default value of the map.
+func (m *Map) SetDefault(value string) *MapEntry {
+	return m.SetEntry("default", value)
+}
+
+// Geo represents a geo block, which sets a variable depending on the client address.
+type Geo struct {
+	EntryBlock
+	Address  string // the source address, empty for $remote_addr
+	Variable string
+}
+
+// NewGeo creates a geo from a directive with a block.
+func NewGeo(directive IDirective) (*Geo, error) {
+	parameters := directive.GetParameters()
+	if len(parameters) == 0 || len(parameters) > 2 {
+		return nil, errors.New("geo directive must have a variable and an optional address")
+	}
+	eb, err := newEntryBlock(directive)
+	if err != nil {
+		return nil, err
+	}
+	g := &Geo{
+		EntryBlock: eb,
+		Variable:   parameters[len(parameters)-1].GetValue(),
+	}
+	if len(parameters) == 2 {
+		g.Address = parameters[0].GetValue()
+	}
+	g.setOwner(g)
+	return g, nil
+}
+
+// GetName implements the Statement interface.
+func (g *Geo) GetName() string {
+	return "geo"
+}
+
+// GetParameters returns the address, if any, and the variable.
+func (g *Geo) GetParameters() []Parameter {
+	if g.Address == "" {
+		return []Parameter{{Value: g.Variable}}
+	}
+	return []Parameter{{Value: g.Address}, {Value: g.Variable}}
+}
+
+// GetBlock returns the geo itself, which implements IBlock.
+func (g *Geo) GetBlock() IBlock {
+	return g
+}
+
+// SetDefault sets the default value of the geo.
+func (g *Geo) SetDefault(value string) *MapEntry {
+	return g.SetEntry("default", value)
+}
+
+// SplitClients represents a split_clients block, which sets a variable for A/B testing.
+type SplitClients struct {
+	EntryBlock
+	Source   string // the hashed string, like "${remote_addr}AAA"
+	Variable string
+}
+
+// NewSplitClients creates a split_clients from a directive with a block.
+func NewSplitClients(directive IDirective) (*SplitClients, error) {
+	parameters := directive.GetParameters()
+	if len(parameters) != 2 {
+		return nil, errors.New("split_clients directive must have a source and a variable")
+	}
+	eb, err := newEntryBlock(directive)
+	if err != nil {
+		return nil, err
+	}
+	sc := &SplitClients{
+		EntryBlock: eb,
+		Source:     parameters[0].GetValue(),
+		Variable:   parameters[1].GetValue(),
+	}
+	sc.setOwner(sc)
+	return sc, nil
+}
+
+// GetName implements the Statement interface.
+func (sc *SplitClients) GetName() string {
+	return "split_clients"
+}
+
+// GetParameters returns the source and the variable.
+func (sc *SplitClients) GetParameters() []Parameter {
+	return []Parameter{{Value: sc.Source}, {Value: sc.Variable}}
+}
+
+// GetBlock returns the split_clients itself, which implements IBlock.
+func (sc *SplitClients) GetBlock() IBlock {
+	return sc
+}
+
+// SetDefault sets the value for the remaining clients, the `*` entry.
+func (sc *SplitClients) SetDefault(value string) *MapEntry {
+	return sc.SetEntry("*", value)
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMapEntry_Kind(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		key, value string
+		kind       MapEntryKind
+	}{
+		{"example.com", "a", ExactEntry},
+		{"''", "a", ExactEntry},
+		{"~^/api", "a", RegexEntry},
+		{"~*\\.jpg$", "a", CaseInsensitiveRegexEntry},
+		{"default", "a", DefaultEntry},
+		{"*", "a", DefaultEntry},
+		{"hostnames", "", HostnamesEntry},
+		{"volatile", "", VolatileEntry},
+		{"include", "flags.conf", IncludeEntry},
+		{"ranges", "", RangesEntry},
+		{"delete", "127.0.0.0/16", DeleteEntry},
+		{"proxy", "192.168.0.1", ProxyEntry},
+		{"proxy_recursive", "", ProxyRecursiveEntry},
+	}
+	for _, tt := range tests {
+		e := &MapEntry{Key: tt.key, Value: tt.value}
+		assert.Equal(t, e.Kind(), tt.kind, tt.key)
+	}
+}
+
+func TestMap_Include(t *testing.T) {
+	t.Parallel()
+	include := &Include{Directive: &Directive{Name: "include", Parameters: []Parameter{{Value: "hosts.map"}}}, IncludePath: "hosts.map"}
+	m, err := NewMap(&Directive{
+		Name:       "map",
+		Parameters: []Parameter{{Value: "$http_host"}, {Value: "$backend"}},
+		Block:      &Block{Directives: []IDirective{&Directive{Name: "default", Parameters: []Parameter{{Value: "web"}}}, include}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, m.Entries[1].Kind(), IncludeEntry)
+	assert.Equal(t, m.Entries[1].Value, "hosts.map")
+	assert.Equal(t, m.Entries[1].Include, include)
+	assert.Equal(t, m.GetDirectives()[1], IDirective(include))
+	assert.Equal(t, include.GetParent(), IDirective(m))
+
+	// an added include is kept too, and found by the mutation methods
+	other := &Include{Directive: &Directive{Name: "include", Parameters: []Parameter{{Value: "more.map"}}}, IncludePath: "more.map"}
+	assert.NilError(t, m.InsertBefore(include, other))
+	assert.Equal(t, m.GetDirectives()[1], IDirective(other))
+	assert.Equal(t, other.GetParent(), IDirective(m))
+	assert.NilError(t, m.RemoveDirective(include))
+	assert.Equal(t, len(m.Entries), 2)
+}
+
+func TestMap_Entries(t *testing.T) {
+	t.Parallel()
+	m, err := NewMap(&Directive{
+		Name:       "map",
+		Parameters: []Parameter{{Value: "$http_host"}, {Value: "$backend"}},
+		Block: &Block{
+			Directives: []IDirective{
+				&Directive{Name: "hostnames"},
+				&Directive{Name: "default", Parameters: []Parameter{{Value: "web"}}},
+				&Directive{Name: "api.example.com", Parameters: []Parameter{{Value: "api"}}, Comment: []string{"# api"}},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, m.Source, "$http_host")
+	assert.Equal(t, m.Variable, "$backend")
+	assert.Equal(t, len(m.Entries), 3)
+	assert.Equal(t, m.GetDefault(), "web")
+	assert.DeepEqual(t, m.FindEntry("api.example.com").Comment, []string{"# api"})
+	assert.Equal(t, m.Entries[0].GetParent(), IDirective(m))
+
+	e := m.AddEntry("~^static\\.", "cdn")
+	assert.Equal(t, e.GetParent(), IDirective(m))
+	assert.Equal(t, m.SetEntry("api.example.com", "api2"), m.FindEntry("api.example.com"))
+	assert.Equal(t, m.FindEntry("api.example.com").Value, "api2")
+	m.SetDefault("none")
+	assert.Equal(t, m.GetDefault(), "none")
+	assert.Assert(t, m.RemoveEntry("hostnames"))
+	assert.Assert(t, !m.RemoveEntry("hostnames"))
+	assert.Equal(t, len(m.GetDirectives()), 3)
+
+	_, err = NewMap(&Directive{Name: "map", Parameters: []Parameter{{Value: "$a"}}, Block: &Block{}})
+	assert.Error(t, err, "map directive must have a source and a variable")
+	_, err = NewMap(&Directive{Name: "map", Parameters: []Parameter{{Value: "$a"}, {Value: "$b"}}})
+	assert.Error(t, err, "map directive must have a block")
+	_, err = NewMap(&Directive{Name: "map", Parameters: []Parameter{{Value: "$a"}, {Value: "$b"}}, Block: &Block{
+		Directives: []IDirective{&Directive{Name: "a", Parameters: []Parameter{{Value: "b"}, {Value: "c"}}}},
+	}})
+	assert.Error(t, err, "invalid number of parameters in 'a' entry")
+}
+
+func TestGeo_Parameters(t *testing.T) {
+	t.Parallel()
+	g, err := NewGeo(&Directive{Name: "geo", Parameters: []Parameter{{Value: "$geo"}}, Block: &Block{}})
+	assert.NilError(t, err)
+	assert.Equal(t, g.Address, "")
+	assert.DeepEqual(t, g.GetParameters(), []Parameter{{Value: "$geo"}})
+	g.Address = "$arg_ip"
+	assert.DeepEqual(t, g.GetParameters(), []Parameter{{Value: "$arg_ip"}, {Value: "$geo"}})
+	g.SetDefault("0")
+	assert.Equal(t, g.GetDefault(), "0")
+
+	sc := &SplitClients{Source: `"${remote_addr}AAA"`, Variable: "$variant"}
+	sc.AddEntry("50%", ".one")
+	sc.SetDefault(`""`)
+	assert.Equal(t, sc.GetDefault(), `""`)
+	assert.Equal(t, sc.Entries[1].Key, "*")
+}
@@ -0,0 +1,97 @@
+package config
+
+import "errors"
+
+// ErrDirectiveNotFound is returned when the directive to edit is not in the block
+var ErrDirectiveNotFound = errors.New("directive not found in block")
+
+// ErrImmutableBlock is returned when a block to edit is not a MutableBlock
+var ErrImmutableBlock = errors.New("block can not be edited")
+
+// indexOf returns the position of the directive in the list, -1 if it is not there
+func indexOf(directives []IDirective, d IDirective) int {
+	for i, directive := range directives {
+		if directive == d {
+			return i
+		}
+	}
+	return -1
+}
+
+// ordered returns the directives of the lists, the ones in order first and in
+// that order, then the others in the order of the lists
+func ordered(order []IDirective, lists ...[]IDirective) []IDirective {
+	present := map[IDirective]bool{}
+	for _, list := range lists {
+		for _, d := range list {
+			present[d] = true
+		}
+	}
+	directives := make([]IDirective, 0, len(present))
+	add := func(d IDirective) {
+		if present[d] {
+			directives = append(directives, d)
+			delete(present, d)
+		}
+	}
+	for _, d := range order {
+		add(d)
+	}
+	for _, list := range lists {
+		for _, d := range list {
+			add(d)
+		}
+	}
+	return directives
+}
+
+// insertDirective inserts d before or after ref
+func insertDirective(directives []IDirective, ref IDirective, d IDirective, after bool) ([]IDirective, error) {
+	i := indexOf(directives, ref)
+	if i < 0 {
+		return directives, ErrDirectiveNotFound
+	}
+	if after {
+		i++
+	}
+	directives = append(directives, nil)
+	copy(directives[i+1:], directives[i:])
+	directives[i] = d
+	return directives, nil
+}
+
+// removeDirective removes d from the list
+func removeDirective(directives []IDirective, d IDirective) ([]IDirective, error) {
+	i := indexOf(directives, d)
+	if i < 0 {
+		return directives, ErrDirectiveNotFound
+	}
+	return append(directives[:i], directives[i+1:]...), nil
+}
+
+// replaceDirective puts d in place of old
+func replaceDirective(directives []IDirective, old IDirective, d IDirective) error {
+	i := indexOf(directives, old)
+	if i < 0 {
+		return ErrDirectiveNotFound
+	}
+	directives[i] = d
+	return nil
+}
+
+// moveDirective removes d from src and adds it at the end of dst. The original
+// source text of d is dropped, a lossless dump renders it at its new place.
+func moveDirective(src MutableBlock, d IDirective, dst IBlock) error {
+	target, ok := dst.(MutableBlock)
+	if !ok {
+		return ErrImmutableBlock
+	}
+	if err := src.RemoveDirective(d); err != nil {
+		return err
+	}
+	if holder, ok := d.(TriviaHolder); ok {
+		holder.SetTrivia(nil)
+	}
+	target.AddDirective(d)
+	return nil
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func names(directives []IDirective) []string {
+	n := make([]string, 0, len(directives))
+	for _, d := range directives {
+		n = append(n, d.GetName())
+	}
+	return n
+}
+
+func TestBlock_Mutations(t *testing.T) {
+	t.Parallel()
+	listen := &Directive{Name: "listen", Parameters: []Parameter{{Value: "80"}}}
+	root := &Directive{Name: "root", Parameters: []Parameter{{Value: "/var/www"}}}
+	server, err := NewServer(&Directive{Name: "server", Block: &Block{Directives: []IDirective{listen, root}}})
+	assert.NilError(t, err)
+	block := server.GetBlock().(MutableBlock)
+	block.SetParent(server)
+
+	serverName := &Directive{Name: "server_name", Parameters: []Parameter{{Value: "example.com"}}}
+	assert.NilError(t, block.InsertAfter(listen, serverName))
+	assert.DeepEqual(t, names(block.GetDirectives()), []string{"listen", "server_name", "root"})
+	assert.Equal(t, serverName.GetParent(), IDirective(server))
+
+	index := &Directive{Name: "index", Parameters: []Parameter{{Value: "index.html"}}}
+	block.AddDirective(index)
+	assert.NilError(t, block.InsertBefore(listen, &Directive{Name: "access_log", Parameters: []Parameter{{Value: "off"}}}))
+	assert.DeepEqual(t, names(block.GetDirectives()), []string{"access_log", "listen", "server_name", "root", "index"})
+
+	alias := &Directive{Name: "alias", Parameters: []Parameter{{Value: "/srv"}}}
+	assert.NilError(t, block.ReplaceDirective(root, alias))
+	assert.NilError(t, block.RemoveDirective(index))
+	assert.DeepEqual(t, names(block.GetDirectives()), []string{"access_log", "listen", "server_name", "alias"})
+	assert.Equal(t, alias.GetParent(), IDirective(server))
+
+	assert.ErrorIs(t, block.RemoveDirective(index), ErrDirectiveNotFound)
+	assert.ErrorIs(t, block.InsertBefore(index, root), ErrDirectiveNotFound)
+	assert.ErrorIs(t, block.ReplaceDirective(index, root), ErrDirectiveNotFound)
+}
+
+func TestHTTP_Mutations(t *testing.T) {
+	t.Parallel()
+	http, err := NewHTTP(&Directive{
+		Name: "http",
+		Block: &Block{
+			Directives: []IDirective{
+				&Directive{Name: "sendfile", Parameters: []Parameter{{Value: "on"}}},
+				NewServerOrNill(&Directive{Name: "server", Block: &Block{}}),
+				&Directive{Name: "gzip", Parameters: []Parameter{{Value: "on"}}},
+				NewServerOrNill(&Directive{Name: "server", Block: &Block{}}),
+			},
+		},
+	})
+	assert.NilError(t, err)
+	// servers keep their place between the other directives
+	assert.DeepEqual(t, names(http.GetDirectives()), []string{"sendfile", "server", "gzip", "server"})
+	assert.DeepEqual(t, names(http.Directives), []string{"sendfile", "gzip"})
+	assert.Equal(t, len(http.Servers), 2)
+
+	first := http.Servers[0]
+	added := NewServerOrNill(&Directive{Name: "server", Block: &Block{}})
+	assert.NilError(t, http.InsertBefore(first, added))
+	assert.DeepEqual(t, names(http.GetDirectives()), []string{"sendfile", "server", "server", "gzip", "server"})
+	assert.Equal(t, len(http.Servers), 3)
+	assert.Equal(t, http.Servers[0], added)
+	assert.Equal(t, added.GetParent(), IDirective(http))
+
+	assert.NilError(t, http.RemoveDirective(first))
+	assert.Equal(t, len(http.Servers), 2)
+	assert.Equal(t, indexOf(http.GetDirectives(), first), -1)
+	assert.DeepEqual(t, names(http.Directives), []string{"sendfile", "gzip"})
+
+	// servers removed from the Servers slice directly are not dumped
+	removed := http.Servers[0]
+	http.Servers = http.Servers[1:]
+	assert.Equal(t, indexOf(http.GetDirectives(), removed), -1)
+	http.Servers = append([]*Server{removed}, http.Servers...)
+
+	// servers appended to the Servers slice directly are kept too
+	http.Servers = append(http.Servers, NewServerOrNill(&Directive{Name: "server", Block: &Block{}}))
+	http.AddDirective(&Directive{Name: "charset", Parameters: []Parameter{{Value: "utf-8"}}})
+	assert.DeepEqual(t, names(http.GetDirectives()), []string{"sendfile", "server", "gzip", "server", "server", "charset"})
+	assert.Equal(t, len(http.Servers), 3)
+}
+
+func TestUpstream_Mutations(t *testing.T) {
+	t.Parallel()
+	upstream, err := NewUpstream(&Directive{
+		Name:       "upstream",
+		Parameters: []Parameter{{Value: "backend"}},
+		Block: &Block{
+			Directives: []IDirective{
+				&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:8080"}}},
+				&Directive{Name: "keepalive", Parameters: []Parameter{{Value: "16"}}},
+				&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:8081"}}},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names(upstream.GetDirectives()), []string{"server", "keepalive", "server"})
+	assert.DeepEqual(t, names(upstream.Directives), []string{"keepalive"})
+
+	keepalive := upstream.GetDirectives()[1]
+	server, err := NewUpstreamServer(&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:8082"}}})
+	assert.NilError(t, err)
+	assert.NilError(t, upstream.InsertBefore(keepalive, server))
+	assert.Equal(t, len(upstream.UpstreamServers), 3)
+	assert.Equal(t, upstream.UpstreamServers[1], server)
+	assert.Equal(t, server.GetParent(), IDirective(upstream))
+
+	upstream.AddServer(&UpstreamServer{Address: "127.0.0.1:8083"})
+	assert.Equal(t, len(upstream.UpstreamServers), 4)
+	assert.DeepEqual(t, names(upstream.GetDirectives()), []string{"server", "server", "keepalive", "server", "server"})
+
+	assert.NilError(t, upstream.RemoveDirective(upstream.UpstreamServers[0]))
+	assert.Equal(t, len(upstream.UpstreamServers), 3)
+	assert.Equal(t, upstream.UpstreamServers[0], server)
+}
+
+func TestMoveTo(t *testing.T) {
+	t.Parallel()
+	header := &Directive{Name: "add_header", Parameters: []Parameter{{Value: "X-Frame-Options"}, {Value: "DENY"}}}
+	header.SetTrivia(&Trivia{Offset: 10, Leading: "\n\t"})
+	src, err := NewLocation(&Directive{Name: "location", Parameters: []Parameter{{Value: "/"}}, Block: &Block{Directives: []IDirective{header}}})
+	assert.NilError(t, err)
+	dst, err := NewServer(&Directive{Name: "server", Block: &Block{}})
+	assert.NilError(t, err)
+	src.GetBlock().SetParent(src)
+	dst.GetBlock().SetParent(dst)
+
+	assert.NilError(t, src.GetBlock().(MutableBlock).MoveTo(header, dst.GetBlock()))
+	assert.Equal(t, len(src.GetBlock().GetDirectives()), 0)
+	assert.Equal(t, len(dst.GetBlock().GetDirectives()), 1)
+	assert.Equal(t, dst.GetBlock().GetDirectives()[0], IDirective(header))
+	assert.Equal(t, header.GetParent(), IDirective(dst))
+	assert.Assert(t, header.GetTrivia() == nil)
+
+	assert.ErrorIs(t, src.GetBlock().(MutableBlock).MoveTo(header, dst.GetBlock()), ErrDirectiveNotFound)
+	assert.ErrorIs(t, dst.GetBlock().(MutableBlock).MoveTo(header, immutableBlock{}), ErrImmutableBlock)
+}
+
+// immutableBlock is an IBlock implemented outside of the package
+type immutableBlock struct{}
+
+func (immutableBlock) GetDirectives() []IDirective        { return nil }
+func (immutableBlock) FindDirectives(string) []IDirective { return nil }
+func (immutableBlock) GetCodeBlock() string               { return "" }
+func (immutableBlock) SetParent(IDirective)               {}
+func (immutableBlock) GetParent() IDirective              { return nil }
+
+func TestMutableBlock(t *testing.T) {
+	t.Parallel()
+	for _, b := range []IBlock{&Block{}, &HTTP{}, &Upstream{}, &Stream{}, &LuaBlock{}, &EntryBlock{}} {
+		_, ok := b.(MutableBlock)
+		assert.Assert(t, ok, "%T", b)
+	}
+
+	d := &Directive{Name: "root"}
+	c := &Cursor{Directive: d, Block: immutableBlock{}}
+	assert.ErrorIs(t, c.Remove(), ErrImmutableBlock)
+	assert.ErrorIs(t, c.Replace(&Directive{Name: "alias"}), ErrImmutableBlock)
+}
+
+func TestEntryBlock_Mutations(t *testing.T) {
+	t.Parallel()
+	m, err := NewMap(&Directive{
+		Name:       "map",
+		Parameters: []Parameter{{Value: "$http_upgrade"}, {Value: "$connection_upgrade"}},
+		Block: &Block{
+			Directives: []IDirective{
+				&Directive{Name: "default", Parameters: []Parameter{{Value: "upgrade"}}},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	def := m.Entries[0]
+	assert.NilError(t, m.InsertAfter(def, &Directive{Name: "''", Parameters: []Parameter{{Value: "close"}}}))
+	assert.Equal(t, len(m.Entries), 2)
+	assert.Equal(t, m.Entries[1].Key, "''")
+	assert.Equal(t, m.Entries[1].Value, "close")
+	assert.Equal(t, m.Entries[1].GetParent(), IDirective(m))
+
+	assert.NilError(t, m.RemoveDirective(def))
+	assert.Equal(t, m.GetDefault(), "")
+	assert.ErrorIs(t, m.RemoveDirective(def), ErrDirectiveNotFound)
+}
@@ -9,6 +9,8 @@ type Server struct {
 	Block   IBlock
 	Comment []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	Parent IDirective
 	Line   int
 }
@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 // IBlock represents any directive block
 type IBlock interface {
 	GetDirectives() []IDirective
@@ -9,6 +11,17 @@ type IBlock interface {
 	GetParent() IDirective
 }
 
+// MutableBlock is a block whose directives can be edited in place, all the blocks of this package are
+type MutableBlock interface {
+	IBlock
+	AddDirective(d IDirective)
+	InsertBefore(ref IDirective, d IDirective) error
+	InsertAfter(ref IDirective, d IDirective) error
+	RemoveDirective(d IDirective) error
+	ReplaceDirective(old IDirective, d IDirective) error
+	MoveTo(d IDirective, dst IBlock) error
+}
+
 // IDirective represents any directive
 type IDirective interface {
 	GetName() string //the directive name.
@@ -44,6 +57,36 @@ func (d *DefaultInlineComment) SetInlineComment(comment InlineComment) {
 	d.InlineComment = append(d.InlineComment, comment)
 }
 
+// ColumnHolder is implemented by directives that know the column their name starts at.
+type ColumnHolder interface {
+	GetColumn() int
+	SetColumn(column int)
+}
+
+// DefaultColumn represents the default column holder
+type DefaultColumn struct {
+	Column int // the column the name starts at, 0 when it is not known
+}
+
+// GetColumn returns the column
+func (d *DefaultColumn) GetColumn() int {
+	return d.Column
+}
+
+// SetColumn sets the column
+func (d *DefaultColumn) SetColumn(column int) {
+	d.Column = column
+}
+
+// keepColumn copies the column of a directive to its wrapper
+func keepColumn(from, to IDirective) {
+	if f, ok := from.(ColumnHolder); ok {
+		if t, ok := to.(ColumnHolder); ok {
+			t.SetColumn(f.GetColumn())
+		}
+	}
+}
+
 // FileDirective a statement that saves its own file
 type FileDirective interface {
 	isFileDirective()
@@ -85,5 +128,33 @@ func (p *Parameter) GetRelativeLineIndex() int {
 	return p.RelativeLineIndex
 }
 
+// Values returns the values of the parameters as written
+func Values(params []Parameter) []string {
+	values := make([]string, 0, len(params))
+	for _, p := range params {
+		values = append(values, p.GetValue())
+	}
+	return values
+}
+
+// UnquotedValues returns the values of the parameters without their quotes
+func UnquotedValues(params []Parameter) []string {
+	values := make([]string, 0, len(params))
+	for _, p := range params {
+		values = append(values, Unquote(p.GetValue()))
+	}
+	return values
+}
+
+// Unquote removes the quotes around a parameter value and unescapes the quotes in it, like nginx
+func Unquote(value string) string {
+	if len(value) < 2 || (value[0] != '"' && value[0] != '\'') || value[len(value)-1] != value[0] {
+		return value
+	}
+	return quoteEscapes.Replace(value[1 : len(value)-1])
+}
+
+var quoteEscapes = strings.NewReplacer(`\"`, `"`, `\'`, `'`)
+
 // InlineComment represents an inline comment
 type InlineComment Parameter
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUnquote(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		`plain`:         `plain`,
+		`"a b"`:         `a b`,
+		`'a b'`:         `a b`,
+		`"say \"hi\""`:  `say "hi"`,
+		`'it\'s'`:       `it's`,
+		`"it\'s"`:       `it's`,
+		`"unterminated`: `"unterminated`,
+		`"`:             `"`,
+		`"mixed'`:       `"mixed'`,
+	}
+	for value, want := range tests {
+		assert.Equal(t, Unquote(value), want, value)
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	params := []Parameter{{Value: "X-Server"}, {Value: `"a \"b\""`}}
+	assert.DeepEqual(t, Values(params), []string{"X-Server", `"a \"b\""`})
+	assert.DeepEqual(t, UnquotedValues(params), []string{"X-Server", `a "b"`})
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+	listen := &Directive{Name: "listen", Parameters: []Parameter{{Value: "80"}}}
+	root := &Directive{Name: "root", Parameters: []Parameter{{Value: "/var/www"}}}
+	unparsed := &Include{Directive: &Directive{Name: "include", Parameters: []Parameter{{Value: "missing.conf"}}}, IncludePath: "missing.conf"}
+	block := &Block{Directives: []IDirective{
+		listen,
+		&Include{
+			Directive:   &Directive{Name: "include", Parameters: []Parameter{{Value: "site.conf"}}},
+			IncludePath: "site.conf",
+			Configs:     []*Config{{FilePath: "site.conf", Block: &Block{Directives: []IDirective{root}}}, nil},
+		},
+		unparsed,
+	}}
+	assert.DeepEqual(t, Flatten(block), []IDirective{listen, root, unparsed})
+	assert.Assert(t, Flatten(nil) == nil)
+}
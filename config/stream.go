@@ -0,0 +1,239 @@
+package config
+
+import (
+	"errors"
+)
+
+// Stream represents a stream block, which proxies TCP and UDP traffic.
+type Stream struct {
+	Directives []IDirective
+	Comment    []string
+	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
+	Parent IDirective
+	Line   int
+}
+
+// SetLine sets the line number.
+func (s *Stream) SetLine(line int) {
+	s.Line = line
+}
+
+// GetLine returns the line number.
+func (s *Stream) GetLine() int {
+	return s.Line
+}
+
+// SetParent sets the parent directive.
+func (s *Stream) SetParent(parent IDirective) {
+	s.Parent = parent
+}
+
+// GetParent returns the parent directive.
+func (s *Stream) GetParent() IDirective {
+	return s.Parent
+}
+
+// GetComment returns the comment of the stream directive.
+func (s *Stream) GetComment() []string {
+	return s.Comment
+}
+
+// SetComment sets the comment of the stream directive.
+func (s *Stream) SetComment(comment []string) {
+	s.Comment = comment
+}
+
+// NewStream creates a stream block from a directive that has a block,
+// its server and upstream blocks become StreamServer and StreamUpstream.
+func NewStream(directive IDirective) (*Stream, error) {
+	block := directive.GetBlock()
+	if block == nil {
+		return nil, errors.New("stream directive must have a block")
+	}
+	stream := &Stream{
+		Directives: []IDirective{},
+		Comment:    directive.GetComment(),
+	}
+	stream.InlineComment = directive.GetInlineComment()
+	for _, d := range block.GetDirectives() {
+		d, err := newStreamDirective(d)
+		if err != nil {
+			return nil, err
+		}
+		d.SetParent(stream)
+		stream.Directives = append(stream.Directives, d)
+	}
+	return stream, nil
+}
+
+// newStreamDirective turns the server and upstream blocks of a stream into
+// their stream types, including the ones of included files
+func newStreamDirective(d IDirective) (IDirective, error) {
+	if include, ok := d.(*Include); ok {
+		for _, c := range include.Configs {
+			if c == nil || c.Block == nil {
+				continue
+			}
+			for i, sub := range c.Block.Directives {
+				sub, err := newStreamDirective(sub)
+				if err != nil {
+					return nil, err
+				}
+				c.Block.Directives[i] = sub
+			}
+		}
+		return include, nil
+	}
+	if d.GetBlock() == nil {
+		return d, nil
+	}
+	switch d.GetName() {
+	case "server":
+		return NewStreamServer(d)
+	case "upstream":
+		return NewStreamUpstream(d)
+	}
+	return d, nil
+}
+
+// keepSource copies the position and the original source text of a directive to its wrapper
+func keepSource(from, to IDirective) {
+	to.SetLine(from.GetLine())
+	keepColumn(from, to)
+	if f, ok := from.(TriviaHolder); ok {
+		if t, ok := to.(TriviaHolder); ok {
+			t.SetTrivia(f.GetTrivia())
+		}
+	}
+}
+
+// GetName returns the directive name to construct the statement string.
+func (s *Stream) GetName() string { //the directive name.
+	return "stream"
+}
+
+// GetParameters returns directive parameters, if any.
+func (s *Stream) GetParameters() []Parameter {
+	return []Parameter{}
+}
+
+// GetDirectives returns all directives in the stream block.
+func (s *Stream) GetDirectives() []IDirective {
+	return s.Directives
+}
+
+// GetServers returns the server blocks of the stream.
+func (s *Stream) GetServers() []*StreamServer {
+	servers := make([]*StreamServer, 0)
+	for _, directive := range s.Directives {
+		if server, ok := directive.(*StreamServer); ok {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// GetUpstreams returns the upstream blocks of the stream.
+func (s *Stream) GetUpstreams() []*StreamUpstream {
+	upstreams := make([]*StreamUpstream, 0)
+	for _, directive := range s.Directives {
+		if upstream, ok := directive.(*StreamUpstream); ok {
+			upstreams = append(upstreams, upstream)
+		}
+	}
+	return upstreams
+}
+
+// FindUpstream finds the upstream with the given name, nil if there is none.
+func (s *Stream) FindUpstream(name string) *StreamUpstream {
+	for _, directive := range s.FindDirectives("upstream") {
+		if upstream, ok := directive.(*StreamUpstream); ok && upstream.UpstreamName == name {
+			return upstream
+		}
+	}
+	return nil
+}
+
+// FindDirectives finds directives in the stream block.
+func (s *Stream) FindDirectives(directiveName string) []IDirective {
+	directives := make([]IDirective, 0)
+	for _, directive := range s.GetDirectives() {
+		if directive.GetName() == directiveName {
+			directives = append(directives, directive)
+		}
+		if include, ok := directive.(*Include); ok {
+			for _, c := range include.Configs {
+				directives = append(directives, c.FindDirectives(directiveName)...)
+			}
+		}
+		if directive.GetBlock() != nil {
+			directives = append(directives, directive.GetBlock().FindDirectives(directiveName)...)
+		}
+	}
+
+	return directives
+}
+
+// GetBlock returns the block itself.
+func (s *Stream) GetBlock() IBlock {
+	return s
+}
+
+// GetCodeBlock returns the literal code block.
+func (s *Stream) GetCodeBlock() string {
+	return ""
+}
+
+// AddDirective adds a directive at the end of the stream block.
+func (s *Stream) AddDirective(d IDirective) {
+	d.SetParent(s)
+	s.Directives = append(s.Directives, d)
+}
+
+// InsertBefore inserts a directive before ref.
+func (s *Stream) InsertBefore(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(s.Directives, ref, d, false)
+	if err != nil {
+		return err
+	}
+	d.SetParent(s)
+	s.Directives = directives
+	return nil
+}
+
+// InsertAfter inserts a directive after ref.
+func (s *Stream) InsertAfter(ref IDirective, d IDirective) error {
+	directives, err := insertDirective(s.Directives, ref, d, true)
+	if err != nil {
+		return err
+	}
+	d.SetParent(s)
+	s.Directives = directives
+	return nil
+}
+
+// RemoveDirective removes a directive from the stream block.
+func (s *Stream) RemoveDirective(d IDirective) error {
+	directives, err := removeDirective(s.Directives, d)
+	if err != nil {
+		return err
+	}
+	s.Directives = directives
+	return nil
+}
+
+// ReplaceDirective puts d in place of old.
+func (s *Stream) ReplaceDirective(old IDirective, d IDirective) error {
+	if err := replaceDirective(s.Directives, old, d); err != nil {
+		return err
+	}
+	d.SetParent(s)
+	return nil
+}
+
+// MoveTo moves a directive of the stream block to the end of dst.
+func (s *Stream) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(s, d, dst)
+}
@@ -0,0 +1,49 @@
+package config
+
+// StreamServer represents a server block in a stream block.
+type StreamServer struct {
+	*Server
+}
+
+// NewStreamServer creates a stream server from a server directive with a block.
+func NewStreamServer(directive IDirective) (*StreamServer, error) {
+	server, ok := directive.(*Server)
+	if !ok {
+		var err error
+		server, err = NewServer(directive)
+		if err != nil {
+			return nil, err
+		}
+		keepSource(directive, server)
+	}
+	ss := &StreamServer{Server: server}
+	if block, ok := server.Block.(*Block); ok {
+		block.Parent = ss
+	}
+	for _, d := range ss.GetDirectives() {
+		d.SetParent(ss)
+	}
+	return ss, nil
+}
+
+// GetListens returns the listen directives of the server.
+func (ss *StreamServer) GetListens() []IDirective {
+	listens := make([]IDirective, 0)
+	for _, directive := range ss.GetDirectives() {
+		if directive.GetName() == "listen" {
+			listens = append(listens, directive)
+		}
+	}
+	return listens
+}
+
+// GetProxyPass returns the address or the upstream name the server proxies to,
+// empty if it has no proxy_pass.
+func (ss *StreamServer) GetProxyPass() string {
+	for _, directive := range ss.GetDirectives() {
+		if directive.GetName() == "proxy_pass" && len(directive.GetParameters()) > 0 {
+			return directive.GetParameters()[0].GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewStream(t *testing.T) {
+	t.Parallel()
+	stream, err := NewStream(&Directive{
+		Name: "stream",
+		Block: &Block{
+			Directives: []IDirective{
+				&Directive{
+					Name:       "upstream",
+					Parameters: []Parameter{{Value: "dns"}},
+					Block: &Block{
+						Directives: []IDirective{
+							&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:53"}, {Value: "weight=2"}}},
+							&Directive{Name: "least_conn"},
+						},
+					},
+				},
+				&Directive{
+					Name: "server",
+					Block: &Block{
+						Directives: []IDirective{
+							&Directive{Name: "listen", Parameters: []Parameter{{Value: "53"}, {Value: "udp"}}},
+							&Directive{Name: "proxy_pass", Parameters: []Parameter{{Value: "dns"}}},
+						},
+					},
+				},
+				&Directive{Name: "tcp_nodelay", Parameters: []Parameter{{Value: "on"}}},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(stream.GetDirectives()), 3)
+
+	servers := stream.GetServers()
+	assert.Equal(t, len(servers), 1)
+	assert.Equal(t, servers[0].GetProxyPass(), "dns")
+	assert.Equal(t, len(servers[0].GetListens()), 1)
+	assert.Equal(t, servers[0].GetParent(), IDirective(stream))
+	assert.Equal(t, servers[0].GetDirectives()[0].GetParent(), IDirective(servers[0]))
+
+	upstreams := stream.GetUpstreams()
+	assert.Equal(t, len(upstreams), 1)
+	assert.Equal(t, upstreams[0].UpstreamName, "dns")
+	assert.Equal(t, upstreams[0].UpstreamServers[0].Parameters["weight"], "2")
+	assert.Equal(t, upstreams[0].UpstreamServers[0].GetParent(), IDirective(upstreams[0]))
+	assert.Equal(t, stream.FindUpstream(servers[0].GetProxyPass()), upstreams[0])
+	assert.Assert(t, stream.FindUpstream("none") == nil)
+
+	_, err = NewStream(&Directive{Name: "stream"})
+	assert.Error(t, err, "stream directive must have a block")
+	_, err = NewStreamUpstream(&Directive{Name: "upstream", Block: &Block{}})
+	assert.Error(t, err, "upstream directive must have a name")
+}
@@ -0,0 +1,30 @@
+package config
+
+// StreamUpstream represents an `upstream{}` block in a stream block.
+type StreamUpstream struct {
+	*Upstream
+}
+
+// NewStreamUpstream creates a stream upstream from an upstream directive with a block.
+func NewStreamUpstream(directive IDirective) (*StreamUpstream, error) {
+	upstream, ok := directive.(*Upstream)
+	if !ok {
+		var err error
+		upstream, err = NewUpstream(directive)
+		if err != nil {
+			return nil, err
+		}
+		keepSource(directive, upstream)
+	}
+	su := &StreamUpstream{Upstream: upstream}
+	upstream.owner = su
+	for _, d := range su.GetDirectives() {
+		d.SetParent(su)
+	}
+	return su, nil
+}
+
+// GetBlock returns the upstream itself, which implements IBlock.
+func (su *StreamUpstream) GetBlock() IBlock {
+	return su
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Trivia keeps the original source text of a directive parsed in lossless mode,
+// so that a dumper can reproduce untouched directives byte-for-byte.
+type Trivia struct {
+	// Offset is the byte offset where the directive (or its leading comments) starts.
+	Offset int
+	// Leading is the whitespace, blank lines and anything else between the
+	// previous directive and this one.
+	Leading string
+	// Text is the original directive text. For blocks it ends at the opening
+	// brace, otherwise it ends at the semicolon or the inline comment after it.
+	Text string
+	// Closing is the text after the last sub directive of a block, including
+	// the closing brace. For a config, it is the text after the last directive.
+	Closing string
+	// CommentAt is the position in Text of the inline comment after the
+	// semicolon, 0 if there is none.
+	CommentAt int
+
+	signature string
+	code      string
+}
+
+// Snapshot records the current state of the directive, Modified reports
+// any change made after the snapshot.
+func (t *Trivia) Snapshot(d IDirective) {
+	t.signature = signature(d, true)
+	t.code = signature(d, false)
+}
+
+// Modified reports whether the directive head (name, parameters and comments)
+// changed since the last snapshot. Sub directives are not taken into account.
+func (t *Trivia) Modified(d IDirective) bool {
+	return t.signature != signature(d, true)
+}
+
+// CodeModified is Modified without the inline comment after the end of the
+// directive, it is false when only that comment changed.
+func (t *Trivia) CodeModified(d IDirective) bool {
+	return t.code != signature(d, false)
+}
+
+// TrailingComment returns the inline comment written after the end of the
+// directive, on the line of its last parameter.
+func TrailingComment(d IDirective) (InlineComment, bool) {
+	line := 0
+	if params := d.GetParameters(); len(params) > 0 {
+		line = params[len(params)-1].RelativeLineIndex
+	}
+	var comment InlineComment
+	found := false
+	for _, c := range d.GetInlineComment() {
+		if c.RelativeLineIndex == line {
+			comment, found = c, true
+		}
+	}
+	return comment, found
+}
+
+// TriviaHolder is implemented by directives that keep their original source text.
+type TriviaHolder interface {
+	GetTrivia() *Trivia
+	SetTrivia(trivia *Trivia)
+}
+
+// DefaultTrivia represents the default trivia holder
+type DefaultTrivia struct {
+	Trivia *Trivia
+}
+
+// GetTrivia returns the original source text, nil if the directive was not
+// parsed in lossless mode or was created later.
+func (d *DefaultTrivia) GetTrivia() *Trivia {
+	return d.Trivia
+}
+
+// SetTrivia sets the original source text
+func (d *DefaultTrivia) SetTrivia(trivia *Trivia) {
+	d.Trivia = trivia
+}
+
+// signature is the state of the directive head, trailing tells whether the
+// inline comment after the end of the directive is part of it
+func signature(d IDirective, trailing bool) string {
+	var sb strings.Builder
+	sb.WriteString(d.GetName())
+	for _, p := range d.GetParameters() {
+		sb.WriteString("\x00p")
+		sb.WriteString(strconv.Itoa(p.RelativeLineIndex))
+		sb.WriteString(p.Value)
+	}
+	for _, c := range d.GetComment() {
+		sb.WriteString("\x00c")
+		sb.WriteString(c)
+	}
+	last, hasLast := TrailingComment(d)
+	for _, c := range d.GetInlineComment() {
+		if !trailing && hasLast && c == last {
+			continue
+		}
+		sb.WriteString("\x00i")
+		sb.WriteString(strconv.Itoa(c.RelativeLineIndex))
+		sb.WriteString(c.Value)
+	}
+	if b := d.GetBlock(); b != nil {
+		sb.WriteString("\x00b")
+		sb.WriteString(b.GetCodeBlock())
+	}
+	return sb.String()
+}
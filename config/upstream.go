@@ -12,8 +12,13 @@ type Upstream struct {
 	Directives []IDirective
 	Comment    []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	Parent IDirective
 	Line   int
+
+	owner IDirective   // the directive wrapping the upstream, like a StreamUpstream
+	order []IDirective // the directives and the servers in their order in the block
 }
 
 // SetLine sets the line number.
@@ -61,20 +66,22 @@ func (us *Upstream) GetComment() []string {
 	return us.Comment
 }
 
-// GetDirectives returns sub directives of the upstream.
+// GetDirectives returns sub directives of the upstream, servers included, in their
+// order. Directives and servers only added to the slices come last.
 func (us *Upstream) GetDirectives() []IDirective {
-	directives := make([]IDirective, 0)
-	directives = append(directives, us.Directives...)
+	servers := make([]IDirective, 0, len(us.UpstreamServers))
 	for _, uss := range us.UpstreamServers {
-		directives = append(directives, uss)
+		servers = append(servers, uss)
 	}
-
-	return directives
+	return ordered(us.order, us.Directives, servers)
 }
 
 // NewUpstream creates a new Upstream from a directive.
 func NewUpstream(directive IDirective) (*Upstream, error) {
 	parameters := directive.GetParameters()
+	if len(parameters) == 0 {
+		return nil, errors.New("upstream directive must have a name")
+	}
 	us := &Upstream{
 		UpstreamName: parameters[0].GetValue(), //first parameter of the directive is the upstream name
 	}
@@ -86,15 +93,22 @@ func NewUpstream(directive IDirective) (*Upstream, error) {
 	if len(directive.GetBlock().GetDirectives()) > 0 {
 		for _, d := range directive.GetBlock().GetDirectives() {
 			if d.GetName() == "server" {
-				uss, err := NewUpstreamServer(d)
-				if err != nil {
-					return nil, err
+				uss, ok := d.(*UpstreamServer)
+				if !ok {
+					var err error
+					uss, err = NewUpstreamServer(d)
+					if err != nil {
+						return nil, err
+					}
 				}
 				uss.SetParent(us)
 				uss.SetLine(d.GetLine())
+				keepColumn(d, uss)
 				us.UpstreamServers = append(us.UpstreamServers, uss)
+				us.order = append(us.order, uss)
 			} else {
 				us.Directives = append(us.Directives, d)
+				us.order = append(us.order, d)
 			}
 		}
 	}
@@ -107,7 +121,7 @@ func NewUpstream(directive IDirective) (*Upstream, error) {
 
 // AddServer adds a server to the upstream.
 func (us *Upstream) AddServer(server *UpstreamServer) {
-	us.UpstreamServers = append(us.UpstreamServers, server)
+	us.AddDirective(server)
 }
 
 // GetCodeBlock returns the literal code block.
@@ -115,7 +129,7 @@ func (us *Upstream) GetCodeBlock() string {
 	return ""
 }
 
-// FindDirectives finds directives in the block recursively.
+// FindDirectives finds directives in the block recursively, servers are not included.
 func (us *Upstream) FindDirectives(directiveName string) []IDirective {
 	directives := make([]IDirective, 0)
 	for _, directive := range us.Directives {
@@ -129,3 +143,82 @@ func (us *Upstream) FindDirectives(directiveName string) []IDirective {
 
 	return directives
 }
+
+// self returns the directive the sub directives belong to
+func (us *Upstream) self() IDirective {
+	if us.owner != nil {
+		return us.owner
+	}
+	return us
+}
+
+// sync applies a change to all the directives of the upstream, then splits
+// them into Directives and UpstreamServers again.
+func (us *Upstream) sync(change func(directives []IDirective) ([]IDirective, error)) error {
+	directives, err := change(us.GetDirectives())
+	if err != nil {
+		return err
+	}
+	us.order = directives
+	us.Directives = []IDirective{}
+	us.UpstreamServers = []*UpstreamServer{}
+	for _, directive := range directives {
+		if uss, ok := directive.(*UpstreamServer); ok {
+			us.UpstreamServers = append(us.UpstreamServers, uss)
+			continue
+		}
+		us.Directives = append(us.Directives, directive)
+	}
+	return nil
+}
+
+// AddDirective adds a directive at the end of the upstream.
+func (us *Upstream) AddDirective(d IDirective) {
+	_ = us.sync(func(directives []IDirective) ([]IDirective, error) {
+		return append(directives, d), nil
+	})
+	d.SetParent(us.self())
+}
+
+// InsertBefore inserts a directive before ref.
+func (us *Upstream) InsertBefore(ref IDirective, d IDirective) error {
+	return us.insert(ref, d, false)
+}
+
+// InsertAfter inserts a directive after ref.
+func (us *Upstream) InsertAfter(ref IDirective, d IDirective) error {
+	return us.insert(ref, d, true)
+}
+
+func (us *Upstream) insert(ref IDirective, d IDirective, after bool) error {
+	err := us.sync(func(directives []IDirective) ([]IDirective, error) {
+		return insertDirective(directives, ref, d, after)
+	})
+	if err == nil {
+		d.SetParent(us.self())
+	}
+	return err
+}
+
+// RemoveDirective removes a directive from the upstream.
+func (us *Upstream) RemoveDirective(d IDirective) error {
+	return us.sync(func(directives []IDirective) ([]IDirective, error) {
+		return removeDirective(directives, d)
+	})
+}
+
+// ReplaceDirective puts d in place of old.
+func (us *Upstream) ReplaceDirective(old IDirective, d IDirective) error {
+	err := us.sync(func(directives []IDirective) ([]IDirective, error) {
+		return directives, replaceDirective(directives, old, d)
+	})
+	if err == nil {
+		d.SetParent(us.self())
+	}
+	return err
+}
+
+// MoveTo moves a directive of the upstream to the end of dst.
+func (us *Upstream) MoveTo(d IDirective, dst IBlock) error {
+	return moveDirective(us, d, dst)
+}
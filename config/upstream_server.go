@@ -13,6 +13,8 @@ type UpstreamServer struct {
 	Parameters map[string]string
 	Comment    []string
 	DefaultInlineComment
+	DefaultTrivia
+	DefaultColumn
 	Parent IDirective
 	Line   int
 }
@@ -0,0 +1,72 @@
+package config
+
+import "regexp"
+
+// Variable is a reference to a variable in the value of a parameter, like $host or ${host}
+type Variable struct {
+	Name   string // without the $ and the braces, like host, or 1 for a regex capture
+	Offset int    // the byte offset of the $ in the value
+}
+
+// Variables returns the variables the value of the parameter refers to
+func (p *Parameter) Variables() []Variable {
+	return ParseVariables(p.Value)
+}
+
+// ParseVariables returns the variables s refers to, like nginx does. A $ that is not
+// followed by a name, like the end of a regular expression, is not a reference, and
+// $12 is the capture $1 followed by 2.
+func ParseVariables(s string) []Variable {
+	var vars []Variable
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			continue
+		}
+		start, end := i+1, i+1
+		switch {
+		case s[start] == '{':
+			end = start + 1
+			for end < len(s) && isVariableChar(s[end]) {
+				end++
+			}
+			if end == start+1 || end >= len(s) || s[end] != '}' {
+				continue
+			}
+			vars = append(vars, Variable{Name: s[start+1 : end], Offset: i})
+			i = end
+			continue
+		case s[start] >= '1' && s[start] <= '9':
+			end = start + 1
+		default:
+			for end < len(s) && isVariableChar(s[end]) {
+				end++
+			}
+		}
+		if end == start {
+			continue
+		}
+		vars = append(vars, Variable{Name: s[start:end], Offset: i})
+		i = end - 1
+	}
+	return vars
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+var namedCapture = regexp.MustCompile(`\(\?(?:P?<([A-Za-z_][A-Za-z0-9_]*)>|'([A-Za-z_][A-Za-z0-9_]*)')`)
+
+// NamedCaptures returns the names of the named captures of a regular expression, like
+// name for ^/(?<name>\w+)$, they are variables once the expression matches
+func NamedCaptures(regex string) []string {
+	var names []string
+	for _, m := range namedCapture.FindAllStringSubmatch(regex, -1) {
+		if m[1] != "" {
+			names = append(names, m[1])
+		} else {
+			names = append(names, m[2])
+		}
+	}
+	return names
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseVariables(t *testing.T) {
+	t.Parallel()
+	for value, want := range map[string][]Variable{
+		"$host":                    {{Name: "host"}},
+		`"${scheme}://$host$uri"`:  {{Name: "scheme", Offset: 1}, {Name: "host", Offset: 13}, {Name: "uri", Offset: 18}},
+		"^/api/(.*)$":              nil,
+		"/img/$12.png":             {{Name: "1", Offset: 5}},
+		"${remote_addr}AAA":        {{Name: "remote_addr"}},
+		"${ host}":                 nil,
+		"price: 5$":                nil,
+		"($http_user_agent":        {{Name: "http_user_agent", Offset: 1}},
+		"$arg_id$cookie_session-x": {{Name: "arg_id"}, {Name: "cookie_session", Offset: 7}},
+	} {
+		p := Parameter{Value: value}
+		assert.DeepEqual(t, p.Variables(), want)
+	}
+}
+
+func TestNamedCaptures(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, NamedCaptures(`^/(?<user>\w+)/(?P<id>\d+)/(?'page'.*)$`), []string{"user", "id", "page"})
+	assert.Assert(t, NamedCaptures(`^/(\w+)$`) == nil)
+}
@@ -0,0 +1,131 @@
+package config
+
+import "errors"
+
+// SkipChildren is returned by Visitor.Enter to not walk the sub directives of the directive, like fs.SkipDir
+var SkipChildren = errors.New("skip children")
+
+// SkipAll is returned by a Visitor to stop the walk, Walk returns nil
+var SkipAll = errors.New("skip all")
+
+// Visitor is called by Walk when it enters a directive and when it leaves it
+type Visitor interface {
+	// Enter is called before the sub directives are walked
+	Enter(c *Cursor) error
+	// Leave is called after the sub directives are walked, even if Enter returned SkipChildren
+	Leave(c *Cursor) error
+}
+
+// Cursor is the directive being walked and where it is
+type Cursor struct {
+	Directive IDirective
+	Path      []IDirective // the enclosing directives from the top level one, the directive not included
+	Block     IBlock       // the block the directive is in
+	File      string       // the file the directive is in, empty when parsed from a string
+
+	removed bool
+}
+
+// Parent returns the enclosing directive, nil at the top level
+func (c *Cursor) Parent() IDirective {
+	if len(c.Path) == 0 {
+		return nil
+	}
+	return c.Path[len(c.Path)-1]
+}
+
+// Replace puts d in place of the directive, the sub directives of d are walked next
+func (c *Cursor) Replace(d IDirective) error {
+	block, ok := c.Block.(MutableBlock)
+	if !ok {
+		return ErrImmutableBlock
+	}
+	if err := block.ReplaceDirective(c.Directive, d); err != nil {
+		return err
+	}
+	c.Directive = d
+	return nil
+}
+
+// Remove removes the directive from its block, its sub directives are not walked and Leave is not called
+func (c *Cursor) Remove() error {
+	block, ok := c.Block.(MutableBlock)
+	if !ok {
+		return ErrImmutableBlock
+	}
+	if err := block.RemoveDirective(c.Directive); err != nil {
+		return err
+	}
+	c.removed = true
+	return nil
+}
+
+// Walk walks the directives of the block depth-first in order, the directives of included
+// files take the place of their include directive, after it. Pass a *Config to know the files.
+// It stops at the first error returned by the visitor.
+func Walk(node IBlock, v Visitor) error {
+	file := ""
+	if c, ok := node.(*Config); ok {
+		file = c.FilePath
+	}
+	if err := walk(node, file, nil, v); err != nil && err != SkipAll {
+		return err
+	}
+	return nil
+}
+
+// Inspect walks the block like Walk and calls fn when it enters a directive
+func Inspect(node IBlock, fn func(c *Cursor) error) error {
+	return Walk(node, inspector(fn))
+}
+
+type inspector func(c *Cursor) error
+
+func (f inspector) Enter(c *Cursor) error {
+	return f(c)
+}
+
+func (f inspector) Leave(*Cursor) error {
+	return nil
+}
+
+func walk(b IBlock, file string, path []IDirective, v Visitor) error {
+	if b == nil {
+		return nil
+	}
+	// the visitor can change the block, walk the directives it had
+	directives := append([]IDirective(nil), b.GetDirectives()...)
+	for _, d := range directives {
+		c := &Cursor{Directive: d, Path: path[:len(path):len(path)], Block: b, File: file}
+		err := v.Enter(c)
+		switch {
+		case err == SkipChildren:
+		case err != nil:
+			return err
+		case c.removed:
+			continue
+		default:
+			if err := walkChildren(c, v); err != nil {
+				return err
+			}
+		}
+		if err := v.Leave(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkChildren(c *Cursor, v Visitor) error {
+	if include, ok := c.Directive.(*Include); ok {
+		for _, conf := range include.Configs {
+			if conf != nil {
+				if err := walk(conf.Block, conf.FilePath, c.Path, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return walk(c.Directive.GetBlock(), c.File, append(c.Path, c.Directive), v)
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// walkConf is
+//
+//	user www;
+//	http {
+//		include sites.conf; # server { listen 80; location / { root /srv; } }
+//		upstream backend { server 127.0.0.1:8080; keepalive 16; }
+//	}
+func walkConf(t *testing.T) *Config {
+	t.Helper()
+	location, err := NewLocation(&Directive{
+		Name:       "location",
+		Parameters: []Parameter{{Value: "/"}},
+		Block:      &Block{Directives: []IDirective{&Directive{Name: "root", Parameters: []Parameter{{Value: "/srv"}}}}},
+	})
+	assert.NilError(t, err)
+	server := NewServerOrNill(&Directive{
+		Name:  "server",
+		Block: &Block{Directives: []IDirective{&Directive{Name: "listen", Parameters: []Parameter{{Value: "80"}}}, location}},
+	})
+	upstream, err := NewUpstream(&Directive{
+		Name:       "upstream",
+		Parameters: []Parameter{{Value: "backend"}},
+		Block: &Block{Directives: []IDirective{
+			&Directive{Name: "server", Parameters: []Parameter{{Value: "127.0.0.1:8080"}}},
+			&Directive{Name: "keepalive", Parameters: []Parameter{{Value: "16"}}},
+		}},
+	})
+	assert.NilError(t, err)
+	include := &Include{
+		Directive:   &Directive{Name: "include", Parameters: []Parameter{{Value: "sites.conf"}}},
+		IncludePath: "sites.conf",
+		Configs:     []*Config{{Block: &Block{Directives: []IDirective{server}}, FilePath: "sites.conf"}},
+	}
+	http, err := NewHTTP(&Directive{Name: "http", Block: &Block{Directives: []IDirective{include, upstream}}})
+	assert.NilError(t, err)
+	return &Config{
+		Block:    &Block{Directives: []IDirective{&Directive{Name: "user", Parameters: []Parameter{{Value: "www"}}}, http}},
+		FilePath: "nginx.conf",
+	}
+}
+
+type recorder struct {
+	events []string
+	enter  func(c *Cursor) error
+}
+
+func (r *recorder) Enter(c *Cursor) error {
+	names := make([]string, 0, len(c.Path))
+	for _, d := range c.Path {
+		names = append(names, d.GetName())
+	}
+	r.events = append(r.events, "enter "+strings.Join(append(names, c.Directive.GetName()), "/")+" "+c.File)
+	if r.enter != nil {
+		return r.enter(c)
+	}
+	return nil
+}
+
+func (r *recorder) Leave(c *Cursor) error {
+	r.events = append(r.events, "leave "+c.Directive.GetName())
+	return nil
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	assert.NilError(t, Walk(walkConf(t), r))
+	assert.DeepEqual(t, r.events, []string{
+		"enter user nginx.conf",
+		"leave user",
+		"enter http nginx.conf",
+		"enter http/include nginx.conf",
+		// included directives are in the block of the include
+		"enter http/server sites.conf",
+		"enter http/server/listen sites.conf",
+		"leave listen",
+		"enter http/server/location sites.conf",
+		"enter http/server/location/root sites.conf",
+		"leave root",
+		"leave location",
+		"leave server",
+		"leave include",
+		// upstream servers are walked like any other directive
+		"enter http/upstream nginx.conf",
+		"enter http/upstream/server nginx.conf",
+		"leave server",
+		"enter http/upstream/keepalive nginx.conf",
+		"leave keepalive",
+		"leave upstream",
+		"leave http",
+	})
+}
+
+func TestWalk_Skip(t *testing.T) {
+	t.Parallel()
+	r := &recorder{enter: func(c *Cursor) error {
+		switch c.Directive.GetName() {
+		case "include":
+			return SkipChildren
+		case "keepalive":
+			return SkipAll
+		}
+		return nil
+	}}
+	assert.NilError(t, Walk(walkConf(t), r))
+	assert.DeepEqual(t, r.events, []string{
+		"enter user nginx.conf",
+		"leave user",
+		"enter http nginx.conf",
+		"enter http/include nginx.conf",
+		"leave include",
+		"enter http/upstream nginx.conf",
+		"enter http/upstream/server nginx.conf",
+		"leave server",
+		"enter http/upstream/keepalive nginx.conf",
+	})
+
+	errStop := errors.New("stop")
+	err := Inspect(walkConf(t), func(c *Cursor) error {
+		if c.Directive.GetName() == "listen" {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+}
+
+func TestWalk_Replace(t *testing.T) {
+	t.Parallel()
+	conf := walkConf(t)
+	var parents []string
+	err := Inspect(conf, func(c *Cursor) error {
+		switch c.Directive.GetName() {
+		case "location":
+			parents = append(parents, c.Parent().GetName())
+			block := &Block{Directives: []IDirective{&Directive{Name: "return", Parameters: []Parameter{{Value: "404"}}}}}
+			return c.Replace(&Directive{Name: "location", Parameters: []Parameter{{Value: "/old"}}, Block: block})
+		case "return":
+			// the sub directives of the new directive are walked
+			parents = append(parents, c.Parent().GetParameters()[0].GetValue())
+		case "keepalive":
+			return c.Remove()
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parents, []string{"server", "/old"})
+
+	server := conf.FindDirectives("http")[0].GetBlock().GetDirectives()[0].(*Include).Configs[0].Directives[0]
+	assert.Equal(t, server.GetBlock().GetDirectives()[1].GetParameters()[0].GetValue(), "/old")
+	upstream := conf.FindDirectives("upstream")[0].(*Upstream)
+	assert.Equal(t, len(upstream.GetDirectives()), 1)
+	assert.Equal(t, len(upstream.UpstreamServers), 1)
+}
@@ -0,0 +1,71 @@
+package crossplane
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// CommentDirective is the directive name of comments in a payload
+const CommentDirective = "#"
+
+// Payload is the output of crossplane parse, the first config is the main file
+type Payload struct {
+	Status string  `json:"status"`
+	Errors []Error `json:"errors"`
+	Config []File  `json:"config"`
+}
+
+// File is a parsed file of a payload
+type File struct {
+	File   string       `json:"file"`
+	Status string       `json:"status"`
+	Errors []Error      `json:"errors"`
+	Parsed []*Directive `json:"parsed"`
+}
+
+// Error is a parsing error reported by crossplane
+type Error struct {
+	File  string `json:"file,omitempty"`
+	Line  *int   `json:"line"`
+	Error string `json:"error"`
+}
+
+// Directive is a directive of a payload. Comments are directives named "#" with
+// the text after the "#" in Comment, the code of a *_by_lua_block is its last argument.
+type Directive struct {
+	Directive string       `json:"directive"`
+	Line      int          `json:"line"`
+	Args      []string     `json:"args"`
+	Includes  []int        `json:"includes,omitempty"` // the indexes of the included files in the payload
+	Block     []*Directive `json:"block,omitempty"`    // nil when the directive has no block
+	Comment   *string      `json:"comment,omitempty"`
+}
+
+// MarshalJSON writes an empty block as [] and no block at all when it is nil
+func (d *Directive) MarshalJSON() ([]byte, error) {
+	type directive Directive
+	out := struct {
+		*directive
+		Block *[]*Directive `json:"block,omitempty"`
+	}{directive: (*directive)(d)}
+	if d.Block != nil {
+		out.Block = &d.Block
+	}
+	return json.Marshal(out)
+}
+
+// Marshal encodes the config and its included files as a crossplane payload
+func Marshal(cfg *config.Config) ([]byte, error) {
+	return json.Marshal(Encode(cfg))
+}
+
+// Unmarshal decodes a crossplane payload into the config of its first file
+func Unmarshal(data []byte) (*config.Config, error) {
+	p := &Payload{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	return Decode(p)
+}
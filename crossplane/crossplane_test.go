@@ -0,0 +1,194 @@
+package crossplane
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/include-glob/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+
+	data, err := Marshal(c)
+	assert.NilError(t, err)
+	decoded, err := Unmarshal(data)
+	assert.NilError(t, err)
+
+	assert.Equal(t, decoded.FilePath, c.FilePath)
+	assert.Equal(t, dumper.DumpConfig(decoded, dumper.IndentedStyle), dumper.DumpConfig(c, dumper.IndentedStyle))
+	// only the quotes that are not needed are lost, like "text/plain"
+	again, err := Marshal(decoded)
+	assert.NilError(t, err)
+	assert.Equal(t, string(again), string(data))
+
+	include := decoded.FindDirectives("include")[1].(*config.Include)
+	assert.Equal(t, include.IncludePath, "http.conf")
+	assert.Equal(t, include.Configs[0].FilePath, "../testdata/include-glob/http.conf")
+	servers := decoded.FindDirectives("server")
+	assert.Equal(t, len(servers), 2)
+	for _, s := range servers {
+		_, ok := s.(*config.Server)
+		assert.Assert(t, ok)
+	}
+	locations := decoded.FindDirectives("location")
+	assert.Assert(t, len(locations) > 0)
+	for _, l := range locations {
+		_, ok := l.(*config.Location)
+		assert.Assert(t, ok)
+	}
+}
+
+const payload = `{
+  "status": "ok",
+  "errors": [],
+  "config": [
+    {
+      "file": "/etc/nginx/nginx.conf",
+      "status": "ok",
+      "errors": [],
+      "parsed": [
+        {"directive": "#", "line": 1, "args": [], "comment": " main"},
+        {"directive": "events", "line": 2, "args": [], "block": []},
+        {"directive": "http", "line": 3, "args": [], "block": [
+          {"directive": "upstream", "line": 4, "args": ["api"], "block": [
+            {"directive": "server", "line": 5, "args": ["10.0.0.3:8080", "weight=2"]}
+          ]},
+          {"directive": "include", "line": 7, "args": ["conf.d/*.conf"], "includes": [1]},
+          {"directive": "log_format", "line": 8, "args": ["main", "$remote_addr - [$time_local]"]},
+          {"directive": "#", "line": 8, "args": [], "comment": " inline"}
+        ]}
+      ]
+    },
+    {
+      "file": "/etc/nginx/conf.d/default.conf",
+      "status": "ok",
+      "errors": [],
+      "parsed": [
+        {"directive": "server", "line": 1, "args": [], "block": [
+          {"directive": "location", "line": 2, "args": ["~", "^/(api|v2)"], "block": [
+            {"directive": "content_by_lua_block", "line": 3, "args": ["\n ngx.say(\"hi\")\n "]}
+          ]}
+        ]}
+      ]
+    }
+  ]
+}`
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+	c, err := Unmarshal([]byte(payload))
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.IndentedStyle), `# main
+events {
+
+}
+http {
+    upstream api {
+        server 10.0.0.3:8080 weight=2;
+    }
+    include conf.d/*.conf;
+    log_format main "$remote_addr - [$time_local]";# inline
+}`)
+
+	upstream := c.FindUpstreams()[0]
+	assert.Equal(t, upstream.UpstreamServers[0].Parameters["weight"], "2")
+	assert.Equal(t, upstream.GetLine(), 4)
+
+	include := c.FindDirectives("include")[0].(*config.Include)
+	assert.Equal(t, include.Configs[0].FilePath, "/etc/nginx/conf.d/default.conf")
+	location := c.FindDirectives("location")[0].(*config.Location)
+	assert.Equal(t, location.Modifier, "~")
+	assert.Equal(t, location.Match, "^/(api|v2)")
+	lua := c.FindDirectives("content_by_lua_block")[0].(*config.LuaBlock)
+	assert.Equal(t, lua.LuaCode, `ngx.say("hi")`)
+	assert.Equal(t, lua.GetParent(), config.IDirective(location))
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser("# comment\nevents {}\nadd_header X-Note \"a b\"; # why\n").Parse()
+	assert.NilError(t, err)
+	data, err := Marshal(c)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"status":"ok","errors":[],"config":[{"file":"","status":"ok","errors":[],"parsed":[`+
+		`{"directive":"#","line":1,"args":[],"comment":" comment"},`+
+		`{"directive":"events","line":2,"args":[],"block":[]},`+
+		`{"directive":"add_header","line":3,"args":["X-Note","a b"]},`+
+		`{"directive":"#","line":3,"args":[],"comment":" why"}]}]}`)
+}
+
+func TestMarshal_EscapedQuotes(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(`return 200 "say \"hi\"";` + "\n" + `add_header X-Note 'it\'s';` + "\n").Parse()
+	assert.NilError(t, err)
+	data, err := Marshal(c)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"status":"ok","errors":[],"config":[{"file":"","status":"ok","errors":[],"parsed":[`+
+		`{"directive":"return","line":1,"args":["200","say \"hi\""]},`+
+		`{"directive":"add_header","line":2,"args":["X-Note","it's"]}]}]}`)
+
+	// the quotes are escaped again by Unmarshal
+	decoded, err := Unmarshal(data)
+	assert.NilError(t, err)
+	assert.Equal(t, decoded.FindDirectives("return")[0].GetParameters()[1].GetValue(), `"say \"hi\""`)
+	again, err := Marshal(decoded)
+	assert.NilError(t, err)
+	assert.Equal(t, string(again), string(data))
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := Unmarshal([]byte(`{"config": []}`))
+	assert.ErrorContains(t, err, "payload has no config")
+	_, err = Unmarshal([]byte(`{"config": [{"file": "a.conf", "parsed": [{"directive": "include", "line": 2, "args": ["b.conf"], "includes": [3]}]}]}`))
+	assert.ErrorContains(t, err, "a.conf:2: no config 3 in the payload")
+	_, err = Unmarshal([]byte(`{"config": [{"file": "a.conf", "parsed": [{"directive": "location", "line": 1, "args": [], "block": []}]}]}`))
+	assert.ErrorContains(t, err, "a.conf:1: ")
+	_, err = Unmarshal([]byte(`[`))
+	assert.ErrorContains(t, err, "invalid payload")
+}
+
+func TestMarshal_RoundTripWrappers(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(`# upstreams
+stream {
+    upstream dns {
+        server 10.0.0.1:53;
+    }
+    server {
+        listen 53 udp;
+        proxy_pass dns;
+    }
+}
+http {
+    map $http_host $name {
+        default 0;
+        ~^www\. 1; # www
+    }
+    server {
+        location = /lua {
+            content_by_lua_block {
+                ngx.say("hello")
+            }
+        }
+    }
+}
+`).Parse()
+	assert.NilError(t, err)
+	data, err := Marshal(c)
+	assert.NilError(t, err)
+	decoded, err := Unmarshal(data)
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(decoded, dumper.IndentedStyle), dumper.DumpConfig(c, dumper.IndentedStyle))
+	assert.Assert(t, config.EqualBlock(decoded, c, config.EqualOptions{}))
+	assert.Equal(t, len(decoded.FindStreamUpstreams()), 1)
+	_, ok := decoded.FindDirectives("map")[0].(*config.Map)
+	assert.Assert(t, ok)
+}
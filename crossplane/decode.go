@@ -0,0 +1,192 @@
+package crossplane
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Decode converts a payload to the config of its first file. Directives are wrapped like
+// the parser does, with the wrappers registered in config.BlockWrappers, config.DirectiveWrappers
+// and config.IncludeWrappers, and includes get the configs of the files they refer to.
+// Comments at the end of a block are dropped, as by the parser.
+func Decode(p *Payload) (*config.Config, error) {
+	if len(p.Config) == 0 {
+		return nil, errors.New("payload has no config")
+	}
+	d := &decoder{payload: p, files: map[int]*config.Config{}}
+	return d.file(0)
+}
+
+type decoder struct {
+	payload *Payload
+	files   map[int]*config.Config
+}
+
+func (d *decoder) file(i int) (*config.Config, error) {
+	if cfg, ok := d.files[i]; ok {
+		return cfg, nil
+	}
+	if i < 0 || i >= len(d.payload.Config) {
+		return nil, fmt.Errorf("no config %d in the payload", i)
+	}
+	f := d.payload.Config[i]
+	cfg := &config.Config{FilePath: f.File, Block: &config.Block{}}
+	// known before its directives, a file including itself does not loop
+	d.files[i] = cfg
+	directives, err := d.directives(f.File, f.Parsed)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Block.Directives = directives
+	return cfg, nil
+}
+
+func (d *decoder) directives(file string, parsed []*Directive) ([]config.IDirective, error) {
+	directives := make([]config.IDirective, 0, len(parsed))
+	var comments []string
+	for i := 0; i < len(parsed); i++ {
+		pd := parsed[i]
+		if pd.Directive == CommentDirective {
+			comments = append(comments, commentText(pd))
+			continue
+		}
+		dir, err := d.directive(file, pd)
+		if err != nil {
+			return nil, err
+		}
+		dir.Comment = comments
+		comments = nil
+		// a comment on the line of a directive without block is an inline comment
+		for dir.Block == nil && i+1 < len(parsed) && parsed[i+1].Directive == CommentDirective && parsed[i+1].Line == pd.Line && pd.Line > 0 {
+			i++
+			dir.SetInlineComment(config.InlineComment{Value: commentText(parsed[i])})
+		}
+
+		s, err := d.wrap(file, dir, pd)
+		if err != nil {
+			return nil, err
+		}
+		link(s)
+		s.SetLine(pd.Line)
+		directives = append(directives, s)
+	}
+	return directives, nil
+}
+
+// directive builds the generic directive and its block
+func (d *decoder) directive(file string, pd *Directive) (*config.Directive, error) {
+	dir := &config.Directive{Name: quote(pd.Directive), Parameters: make([]config.Parameter, 0, len(pd.Args))}
+	for _, arg := range pd.Args {
+		dir.Parameters = append(dir.Parameters, config.Parameter{Value: quote(arg)})
+	}
+	switch {
+	case isLuaBlock(pd.Directive) && pd.Block == nil && len(pd.Args) > 0:
+		// the code is the last argument
+		dir.Parameters = dir.Parameters[:len(pd.Args)-1]
+		dir.Block = &config.Block{
+			IsLuaBlock:  true,
+			Directives:  []config.IDirective{},
+			LiteralCode: strings.TrimSpace(pd.Args[len(pd.Args)-1]),
+		}
+	case pd.Block != nil:
+		directives, err := d.directives(file, pd.Block)
+		if err != nil {
+			return nil, err
+		}
+		dir.Block = &config.Block{Directives: directives}
+	}
+	return dir, nil
+}
+
+// wrap turns the directive into its typed wrapper like the parser does
+func (d *decoder) wrap(file string, dir *config.Directive, pd *Directive) (config.IDirective, error) {
+	var wrapper func(*config.Directive) (config.IDirective, error)
+	switch {
+	case dir.Block == nil:
+		if iw, ok := config.IncludeWrappers[dir.Name]; ok {
+			return d.include(file, iw, dir, pd)
+		}
+		wrapper = config.DirectiveWrappers[dir.Name]
+	case isLuaBlock(dir.Name):
+		wrapper = config.BlockWrappers["_by_lua_block"]
+	default:
+		wrapper = config.BlockWrappers[dir.Name]
+	}
+	if wrapper == nil {
+		return dir, nil
+	}
+	s, err := wrapper(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%d: %w", file, pd.Line, err)
+	}
+	return s, nil
+}
+
+func (d *decoder) include(file string, wrapper func(*config.Directive) (config.IDirective, error), dir *config.Directive, pd *Directive) (config.IDirective, error) {
+	s, err := wrapper(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%d: %w", file, pd.Line, err)
+	}
+	include, ok := s.(*config.Include)
+	if !ok {
+		return nil, fmt.Errorf("%s:%d: include wrapper of '%s' must return an *config.Include", file, pd.Line, dir.Name)
+	}
+	for _, i := range pd.Includes {
+		cfg, err := d.file(i)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, pd.Line, err)
+		}
+		include.Configs = append(include.Configs, cfg)
+	}
+	return include, nil
+}
+
+// link sets the parent of the sub directives like the parser does
+func link(s config.IDirective) {
+	b := s.GetBlock()
+	if b == nil {
+		s.SetParent(s)
+		return
+	}
+	if block, ok := b.(*config.Block); ok {
+		block.SetParent(s)
+	}
+	for _, dir := range b.GetDirectives() {
+		dir.SetParent(s)
+	}
+}
+
+func commentText(pd *Directive) string {
+	if pd.Comment == nil {
+		return "#"
+	}
+	return "#" + *pd.Comment
+}
+
+func isLuaBlock(name string) bool {
+	return strings.HasSuffix(name, "_by_lua_block")
+}
+
+var variables = regexp.MustCompile(`\$\{\w+\}`)
+
+// quote quotes an argument that nginx would otherwise split or read as syntax
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(variables.ReplaceAllString(value, ""), " \t\r\n;{}#\"'") {
+		return value
+	}
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		// keep the quotes escaped in the payload escaped once
+		if value[i] == '"' && (i == 0 || value[i-1] != '\\') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(value[i])
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,4 @@
+// Package crossplane converts configs to and from the JSON payload of nginx-crossplane,
+// so that they can be exchanged with its Python tooling, stored or built in other languages
+// and dumped with the dumper package.
+package crossplane
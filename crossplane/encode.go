@@ -0,0 +1,73 @@
+package crossplane
+
+import (
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Encode converts the config to a payload, the files it includes follow it in the payload
+func Encode(cfg *config.Config) *Payload {
+	e := &encoder{files: map[*config.Config]int{}}
+	e.file(cfg)
+	return &Payload{Status: "ok", Errors: []Error{}, Config: e.config}
+}
+
+type encoder struct {
+	config []File
+	files  map[*config.Config]int // the index of each file in the payload
+}
+
+func (e *encoder) file(cfg *config.Config) int {
+	if i, ok := e.files[cfg]; ok {
+		return i
+	}
+	i := len(e.config)
+	e.files[cfg] = i
+	e.config = append(e.config, File{File: cfg.FilePath, Status: "ok", Errors: []Error{}})
+	parsed := []*Directive{}
+	if cfg.Block != nil {
+		parsed = e.directives(cfg.GetDirectives())
+	}
+	e.config[i].Parsed = parsed
+	return i
+}
+
+func (e *encoder) directives(directives []config.IDirective) []*Directive {
+	parsed := make([]*Directive, 0, len(directives))
+	for _, d := range directives {
+		// the comments are on the lines before the directive
+		line := d.GetLine() - len(d.GetComment())
+		for _, c := range d.GetComment() {
+			parsed = append(parsed, comment(c, max(line, 0)))
+			line++
+		}
+
+		pd := &Directive{Directive: config.Unquote(d.GetName()), Line: d.GetLine(), Args: make([]string, 0, len(d.GetParameters()))}
+		for _, p := range d.GetParameters() {
+			pd.Args = append(pd.Args, config.Unquote(p.GetValue()))
+		}
+		if include, ok := d.(*config.Include); ok {
+			for _, c := range include.Configs {
+				pd.Includes = append(pd.Includes, e.file(c))
+			}
+		} else if block := d.GetBlock(); block != nil {
+			if code := block.GetCodeBlock(); code != "" {
+				pd.Args = append(pd.Args, code)
+			} else {
+				pd.Block = e.directives(block.GetDirectives())
+			}
+		}
+		parsed = append(parsed, pd)
+
+		for _, c := range d.GetInlineComment() {
+			parsed = append(parsed, comment(c.Value, d.GetLine()+c.RelativeLineIndex))
+		}
+	}
+	return parsed
+}
+
+func comment(text string, line int) *Directive {
+	text = strings.TrimPrefix(text, "#")
+	return &Directive{Directive: CommentDirective, Line: line, Args: []string{}, Comment: &text}
+}
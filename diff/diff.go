@@ -0,0 +1,240 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Kind is the kind of a change
+type Kind string
+
+const (
+	// Added the directive is only in the new config
+	Added Kind = "added"
+	// Removed the directive is only in the old config
+	Removed Kind = "removed"
+	// Changed the directive is in both configs with other parameters
+	Changed Kind = "changed"
+)
+
+// Change is a difference between the configs
+type Change struct {
+	Kind      Kind     `json:"kind"`
+	Path      []string `json:"path"`          // the enclosing blocks, like ["http", "upstream api"]
+	Directive string   `json:"directive"`     // what the directive is, like "server 10.0.0.5:8080" or "worker_connections"
+	Old       string   `json:"old,omitempty"` // the old directive, without its block
+	New       string   `json:"new,omitempty"` // the new directive, without its block
+	OldLine   int      `json:"old_line,omitempty"`
+	NewLine   int      `json:"new_line,omitempty"`
+}
+
+// Diff is the list of changes from a config to another, in the order of the configs
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the configs are the same
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Configs returns the changes from the old config to the new one. The directives of
+// included files are compared in place of their include directive, comments and
+// the order of the directives are not taken into account.
+func Configs(old, next *config.Config) *Diff {
+	d := &Diff{Changes: []Change{}}
+	d.blocks(nil, old.Block, next.Block)
+	return d
+}
+
+// Directives returns the changes from the old directive to the new one and in their blocks
+func Directives(old, next config.IDirective) *Diff {
+	d := &Diff{Changes: []Change{}}
+	d.compare(nil, entry{directive: old, key: identity(old)}, entry{directive: next, key: identity(next)})
+	return d
+}
+
+// entry is a directive and its identity among the directives of its block
+type entry struct {
+	directive config.IDirective
+	key       string
+}
+
+// describe returns the directive without its block, or its identity for blocks without parameters like a server
+func (e entry) describe() string {
+	if h := head(e.directive); h != e.directive.GetName() || e.directive.GetBlock() == nil {
+		return h
+	}
+	return identity(e.directive)
+}
+
+// blocks compares the directives of two blocks, matched by identity
+func (d *Diff) blocks(path []string, old, next config.IBlock) {
+	oldEntries, newEntries := entries(config.Flatten(old), config.Flatten(next))
+	byKey := make(map[string]entry, len(newEntries))
+	for _, e := range newEntries {
+		byKey[e.key] = e
+	}
+	matched := make(map[string]bool, len(oldEntries))
+	for _, o := range oldEntries {
+		n, ok := byKey[o.key]
+		if !ok {
+			d.add(Change{Kind: Removed, Path: path, Directive: o.key, Old: o.describe(), OldLine: o.directive.GetLine()})
+			continue
+		}
+		matched[o.key] = true
+		d.compare(path, o, n)
+	}
+	for _, n := range newEntries {
+		if !matched[n.key] {
+			d.add(Change{Kind: Added, Path: path, Directive: n.key, New: n.describe(), NewLine: n.directive.GetLine()})
+		}
+	}
+}
+
+// compare compares two directives with the same identity
+func (d *Diff) compare(path []string, o, n entry) {
+	if head(o.directive) != head(n.directive) || code(o.directive) != code(n.directive) {
+		d.add(Change{
+			Kind:      Changed,
+			Path:      path,
+			Directive: o.key,
+			Old:       head(o.directive),
+			New:       head(n.directive),
+			OldLine:   o.directive.GetLine(),
+			NewLine:   n.directive.GetLine(),
+		})
+	}
+	oldBlock, newBlock := o.directive.GetBlock(), n.directive.GetBlock()
+	if oldBlock != nil || newBlock != nil {
+		d.blocks(append(path[:len(path):len(path)], o.key), oldBlock, newBlock)
+	}
+}
+
+func (d *Diff) add(c Change) {
+	if c.Path == nil {
+		c.Path = []string{}
+	}
+	d.Changes = append(d.Changes, c)
+}
+
+// entries returns the old and the new directives of a block with their identity. Repeated
+// directives like add_header are told apart by their first parameter, then by all of them
+// and last by their position, the same way in both blocks.
+func entries(old, next []config.IDirective) ([]entry, []entry) {
+	o, n := make([]entry, 0, len(old)), make([]entry, 0, len(next))
+	for _, d := range old {
+		o = append(o, entry{directive: d, key: identity(d)})
+	}
+	for _, d := range next {
+		n = append(n, entry{directive: d, key: identity(d)})
+	}
+	for _, refine := range []func(config.IDirective) string{firstParameter, head} {
+		dup := duplicated(o, n)
+		for _, list := range [][]entry{o, n} {
+			for i, e := range list {
+				// only plain directives, identified by their name, are refined
+				if dup[e.key] && identity(e.directive) == e.directive.GetName() {
+					list[i].key = refine(e.directive)
+				}
+			}
+		}
+	}
+	dup := duplicated(o, n)
+	for _, list := range [][]entry{o, n} {
+		seen := map[string]int{}
+		for i, e := range list {
+			if dup[e.key] {
+				seen[e.key]++
+				list[i].key = fmt.Sprintf("%s (#%d)", e.key, seen[e.key])
+			}
+		}
+	}
+	return o, n
+}
+
+// duplicated returns the keys found more than once in any of the lists
+func duplicated(lists ...[]entry) map[string]bool {
+	dup := map[string]bool{}
+	for _, list := range lists {
+		count := map[string]int{}
+		for _, e := range list {
+			count[e.key]++
+			if count[e.key] > 1 {
+				dup[e.key] = true
+			}
+		}
+	}
+	return dup
+}
+
+// identity returns what tells the directive apart from the other ones of its block
+func identity(d config.IDirective) string {
+	params := config.Values(d.GetParameters())
+	switch {
+	case d.GetName() == "server" && d.GetBlock() != nil:
+		return serverIdentity(d)
+	case d.GetName() == "server":
+		// an upstream server, by address
+		if len(params) > 0 {
+			return "server " + params[0]
+		}
+	case d.GetName() == "location", d.GetName() == "upstream", d.GetName() == "map", d.GetName() == "geo",
+		d.GetName() == "split_clients", d.GetName() == "if", d.GetName() == "limit_except", d.GetName() == "types":
+		return head(d)
+	}
+	if _, ok := d.(*config.MapEntry); ok {
+		return d.GetName()
+	}
+	if d.GetBlock() != nil && len(params) > 0 {
+		return head(d)
+	}
+	return d.GetName()
+}
+
+// serverIdentity names a server by its server names and its listen addresses
+func serverIdentity(d config.IDirective) string {
+	var names, listens []string
+	for _, sub := range config.Flatten(d.GetBlock()) {
+		switch sub.GetName() {
+		case "server_name":
+			names = append(names, config.Values(sub.GetParameters())...)
+		case "listen":
+			listens = append(listens, strings.Join(config.Values(sub.GetParameters()), " "))
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(listens)
+	id := "server"
+	if len(names) > 0 {
+		id += " " + strings.Join(names, " ")
+	}
+	if len(listens) > 0 {
+		id += " [" + strings.Join(listens, ", ") + "]"
+	}
+	return id
+}
+
+func firstParameter(d config.IDirective) string {
+	params := config.Values(d.GetParameters())
+	if len(params) == 0 {
+		return d.GetName()
+	}
+	return d.GetName() + " " + params[0]
+}
+
+// head returns the directive without its block, like "server 10.0.0.5:8080 weight=3"
+func head(d config.IDirective) string {
+	return strings.Join(append([]string{d.GetName()}, config.Values(d.GetParameters())...), " ")
+}
+
+// code returns the literal code of a lua block
+func code(d config.IDirective) string {
+	if b := d.GetBlock(); b != nil {
+		return b.GetCodeBlock()
+	}
+	return ""
+}
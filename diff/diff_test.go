@@ -0,0 +1,147 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func parse(t *testing.T, conf string) *config.Config {
+	t.Helper()
+	c, err := parser.NewStringParser(conf).Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+const oldConf = `worker_processes 2;
+events {
+    worker_connections 1024;
+}
+http {
+    upstream api {
+        server 10.0.0.3:8080;
+        server 10.0.0.4:8080 weight=2;
+    }
+    server {
+        listen 80;
+        server_name example.com;
+        add_header X-Frame-Options DENY;
+        add_header X-Version 1;
+        location /api {
+            proxy_pass http://api;
+        }
+        location = /old {
+            return 410;
+        }
+    }
+    server {
+        listen 80;
+        server_name static.example.com;
+        root /srv;
+    }
+}
+`
+
+const newConf = `# comments and order do not matter
+worker_processes 2;
+events {
+    worker_connections 4096;
+}
+http {
+    server {
+        # the same server
+        server_name example.com;
+        listen 80;
+        add_header X-Version 2;
+        add_header X-Frame-Options DENY;
+        location /api {
+            proxy_pass http://api;
+            proxy_read_timeout 30s;
+        }
+    }
+    upstream api {
+        server 10.0.0.4:8080 weight=1;
+        server 10.0.0.3:8080;
+        server 10.0.0.5:8080 weight=3;
+    }
+    server {
+        listen 80;
+        server_name cdn.example.com;
+        root /srv;
+    }
+}
+`
+
+func TestConfigs(t *testing.T) {
+	t.Parallel()
+	d := Configs(parse(t, oldConf), parse(t, newConf))
+	assert.Equal(t, d.Text(), `events: changed worker_connections 1024 to worker_connections 4096
+http > upstream api: changed server 10.0.0.4:8080 weight=2 to server 10.0.0.4:8080 weight=1
+http > upstream api: added server 10.0.0.5:8080 weight=3
+http > server example.com [80]: changed add_header X-Version 1 to add_header X-Version 2
+http > server example.com [80] > location /api: added proxy_read_timeout 30s
+http > server example.com [80]: removed location = /old
+http: removed server static.example.com [80]
+http: added server cdn.example.com [80]
+`)
+
+	added := d.Changes[2]
+	assert.Equal(t, added.Kind, Added)
+	assert.DeepEqual(t, added.Path, []string{"http", "upstream api"})
+	assert.Equal(t, added.Directive, "server 10.0.0.5:8080")
+	assert.Equal(t, added.NewLine, 21)
+	assert.Equal(t, added.OldLine, 0)
+
+	assert.Assert(t, Configs(parse(t, oldConf), parse(t, oldConf)).Empty())
+}
+
+func TestConfigs_RepeatedDirectives(t *testing.T) {
+	t.Parallel()
+	d := Configs(
+		parse(t, "add_header X-A 1;\nadd_header X-A 2;\ninclude a.conf;\n"),
+		parse(t, "add_header X-A 1;\nadd_header X-A 3;\nadd_header X-B 1;\n"),
+	)
+	assert.Equal(t, d.Text(), `removed add_header X-A 2
+removed include a.conf
+added add_header X-A 3
+added add_header X-B 1
+`)
+}
+
+func TestDiff_JSON(t *testing.T) {
+	t.Parallel()
+	d := Configs(parse(t, "events {\n\tworker_connections 1024;\n}\n"), parse(t, "events {\n\tworker_connections 2048;\n}\nuser www;\n"))
+	data, err := d.JSON()
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{
+  "changes": [
+    {
+      "kind": "changed",
+      "path": [
+        "events"
+      ],
+      "directive": "worker_connections",
+      "old": "worker_connections 1024",
+      "new": "worker_connections 2048",
+      "old_line": 2,
+      "new_line": 2
+    },
+    {
+      "kind": "added",
+      "path": [],
+      "directive": "user",
+      "new": "user www",
+      "new_line": 4
+    }
+  ]
+}`)
+}
+
+func TestDirectives(t *testing.T) {
+	t.Parallel()
+	old := parse(t, "location / {\n\troot /a;\n}\n").FindDirectives("location")[0]
+	next := parse(t, "location / {\n\troot /b;\n}\n").FindDirectives("location")[0]
+	assert.Equal(t, Directives(old, next).Text(), "location /: changed root /a to root /b\n")
+}
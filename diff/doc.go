@@ -0,0 +1,4 @@
+// Package diff compares two nginx configurations by the meaning of their directives
+// rather than by their text: a server is identified by its listen and server_name,
+// a location by its modifier and match and an upstream server by its address.
+package diff
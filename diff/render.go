@@ -0,0 +1,40 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// String returns the change as a line of text, like
+// "http > upstream api: added server 10.0.0.5:8080 weight=3"
+func (c Change) String() string {
+	var change string
+	switch c.Kind {
+	case Added:
+		change = "added " + c.New
+	case Removed:
+		change = "removed " + c.Old
+	default:
+		change = fmt.Sprintf("changed %s to %s", c.Old, c.New)
+	}
+	if len(c.Path) == 0 {
+		return change
+	}
+	return strings.Join(c.Path, " > ") + ": " + change
+}
+
+// Text returns the changes, one per line
+func (d *Diff) Text() string {
+	var sb strings.Builder
+	for _, c := range d.Changes {
+		sb.WriteString(c.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// JSON returns the changes as an indented JSON document
+func (d *Diff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
@@ -9,8 +9,9 @@ import (
 func TestConfig_ToString(t *testing.T) {
 	t.Parallel()
 	type fields struct {
-		Block    *config.Block
-		FilePath string
+		Block          *config.Block
+		FilePath       string
+		ClosingComment []string
 	}
 	tests := []struct {
 		name   string
@@ -45,12 +46,25 @@ func TestConfig_ToString(t *testing.T) {
 			},
 			want: "user nginx nginx;\nworker_processes 5;\ninclude /etc/nginx/conf/*.conf;",
 		},
+		{
+			name: "closing comment",
+			fields: fields{
+				Block: &config.Block{
+					Directives: []config.IDirective{
+						&config.Directive{Name: "user", Parameters: []config.Parameter{{Value: "nginx"}}},
+					},
+				},
+				ClosingComment: []string{"# end of the file", "# gonginx:disable"},
+			},
+			want: "user nginx;\n# end of the file\n# gonginx:disable",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &config.Config{
-				Block:    tt.fields.Block,
-				FilePath: tt.fields.FilePath,
+				Block:          tt.fields.Block,
+				FilePath:       tt.fields.FilePath,
+				ClosingComment: tt.fields.ClosingComment,
 			}
 			//TODO(tufan): create another dumper for a config and include statement (file thingis)
 			if got := DumpConfig(c, NoIndentStyle); got != tt.want {
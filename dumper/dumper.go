@@ -44,6 +44,14 @@ var (
 		Indent:            0,
 		Debug:             false,
 	}
+
+	//LosslessStyle keeps the original text of directives parsed with parser.WithLossless
+	//and renders the modified ones indented
+	LosslessStyle = &Style{
+		StartIndent: 0,
+		Indent:      4,
+		Lossless:    true,
+	}
 )
 
 // Style dumping style
@@ -53,6 +61,7 @@ type Style struct {
 	StartIndent       int
 	Indent            int
 	Debug             bool
+	Lossless          bool
 }
 
 // NewStyle create new style
@@ -73,6 +82,7 @@ func (s *Style) Iterate() *Style {
 		SpaceBeforeBlocks: s.SpaceBeforeBlocks,
 		StartIndent:       s.StartIndent + s.Indent,
 		Indent:            s.Indent,
+		Lossless:          s.Lossless,
 	}
 	return newStyle
 }
@@ -88,6 +98,27 @@ func DumpDirective(d config.IDirective, style *Style) string {
 	if style.SpaceBeforeBlocks && d.GetBlock() != nil {
 		buf.WriteString("\n")
 	}
+	lastComment, hasLastComment := dumpDirectiveHead(&buf, d, style)
+
+	if d.GetBlock() == nil {
+		if d.GetName() != "" {
+			buf.WriteRune(';')
+		}
+		// the last inline comment
+		if hasLastComment {
+			buf.WriteString(lastComment.Value)
+		}
+	} else {
+		buf.WriteString(" {\n")
+		buf.WriteString(DumpBlock(d.GetBlock(), style.Iterate()))
+		buf.WriteString(fmt.Sprintf("\n%s}", strings.Repeat(" ", style.StartIndent)))
+	}
+	return buf.String()
+}
+
+// dumpDirectiveHead writes comments, name and parameters of a directive and
+// returns the inline comment that belongs after the end of the directive, if any.
+func dumpDirectiveHead(buf *bytes.Buffer, d config.IDirective, style *Style) (config.InlineComment, bool) {
 	// outline comment
 	if len(d.GetComment()) > 0 {
 		for _, comment := range d.GetComment() {
@@ -119,20 +150,8 @@ func DumpDirective(d config.IDirective, style *Style) string {
 		}
 	}
 
-	if d.GetBlock() == nil {
-		if d.GetName() != "" {
-			buf.WriteRune(';')
-		}
-		// the last inline comment
-		if comment, ok := inlineComments[relativeLineIndex]; ok {
-			buf.WriteString(comment.Value)
-		}
-	} else {
-		buf.WriteString(" {\n")
-		buf.WriteString(DumpBlock(d.GetBlock(), style.Iterate()))
-		buf.WriteString(fmt.Sprintf("\n%s}", strings.Repeat(" ", style.StartIndent)))
-	}
-	return buf.String()
+	comment, ok := inlineComments[relativeLineIndex]
+	return comment, ok
 }
 
 // DumpBlock convert a directive to a string
@@ -142,6 +161,10 @@ func DumpBlock(b config.IBlock, style *Style) string {
 	}
 
 	var buf bytes.Buffer
+	if style.Lossless {
+		dumpLosslessBlock(&buf, b, style, strings.Repeat(" ", style.StartIndent))
+		return buf.String()
+	}
 	directives := b.GetDirectives()
 	if style.SortDirectives {
 		sort.SliceStable(directives, func(i, j int) bool {
@@ -167,7 +190,23 @@ func DumpBlock(b config.IBlock, style *Style) string {
 
 // DumpConfig dump whole config
 func DumpConfig(c *config.Config, style *Style) string {
-	return DumpBlock(c.Block, style)
+	if style.Lossless {
+		var buf bytes.Buffer
+		dumpLosslessBlock(&buf, c.Block, style, strings.Repeat(" ", style.StartIndent))
+		if t := c.GetTrivia(); t != nil {
+			buf.WriteString(t.Closing)
+		}
+		return buf.String()
+	}
+	var buf bytes.Buffer
+	buf.WriteString(DumpBlock(c.Block, style))
+	for _, comment := range c.ClosingComment {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(fmt.Sprintf("%s%s", strings.Repeat(" ", style.StartIndent), comment))
+	}
+	return buf.String()
 }
 
 // DumpInclude dump(stringify) the included AST
@@ -0,0 +1,116 @@
+package dumper
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// dumpLosslessBlock writes directives parsed in lossless mode with their original
+// text, only the directives changed (or created) after parsing are rendered
+// with the given style. indent is used for new directives when it can not be
+// guessed from their siblings.
+func dumpLosslessBlock(buf *bytes.Buffer, b config.IBlock, style *Style, indent string) {
+	directives := b.GetDirectives()
+	for _, d := range directives {
+		if t := getTrivia(d); t != nil && strings.ContainsAny(t.Leading, "\r\n") {
+			indent = lastLineIndent(t.Leading)
+			break
+		}
+	}
+
+	for _, d := range directives {
+		t := getTrivia(d)
+		if t == nil {
+			buf.WriteString("\n")
+			buf.WriteString(indent)
+			buf.WriteString(renderDirective(d, style, indent))
+			continue
+		}
+
+		buf.WriteString(t.Leading)
+		if d.GetBlock() == nil {
+			buf.WriteString(renderLosslessDirective(d, t, style, indent))
+			continue
+		}
+		if d.GetBlock().GetCodeBlock() != "" {
+			if t.Modified(d) {
+				buf.WriteString(renderDirective(d, style, indent))
+			} else {
+				buf.WriteString(t.Text)
+			}
+			continue
+		}
+
+		if t.Modified(d) {
+			head := renderDirectiveHead(d, style, indent)
+			buf.WriteString(head)
+			buf.WriteString(" {")
+		} else {
+			buf.WriteString(t.Text)
+		}
+		dumpLosslessBlock(buf, d.GetBlock(), style, indent+strings.Repeat(" ", style.Indent))
+		if t.Closing != "" {
+			buf.WriteString(t.Closing)
+		} else {
+			buf.WriteString("\n" + indent + "}")
+		}
+	}
+}
+
+// renderDirective renders a directive with the style, the first line is not indented
+// since its indentation is already written.
+func renderDirective(d config.IDirective, style *Style, indent string) string {
+	s := *style
+	s.Lossless = false
+	s.SpaceBeforeBlocks = false
+	s.StartIndent = len(indent)
+	return strings.TrimPrefix(DumpDirective(d, &s), strings.Repeat(" ", s.StartIndent))
+}
+
+// renderLosslessDirective renders a directive without block from its original text,
+// only the parts that changed are rendered again. An inline comment after the
+// semicolon keeps its original spacing.
+func renderLosslessDirective(d config.IDirective, t *config.Trivia, style *Style, indent string) string {
+	if !t.Modified(d) {
+		return t.Text
+	}
+	code, spacing := t.Text, " "
+	if t.CommentAt > 0 {
+		code = strings.TrimRight(t.Text[:t.CommentAt], " \t")
+		spacing = t.Text[len(code):t.CommentAt]
+	}
+	comment, ok := config.TrailingComment(d)
+	if t.CodeModified(d) {
+		code = renderDirective(d, style, indent)
+		if ok {
+			code = strings.TrimSuffix(code, comment.Value)
+		}
+	}
+	if !ok {
+		return code
+	}
+	return code + spacing + comment.Value
+}
+
+// renderDirectiveHead renders a block directive without its block.
+func renderDirectiveHead(d config.IDirective, style *Style, indent string) string {
+	s := *style
+	s.StartIndent = len(indent)
+	var buf bytes.Buffer
+	dumpDirectiveHead(&buf, d, &s)
+	return strings.TrimPrefix(buf.String(), strings.Repeat(" ", s.StartIndent))
+}
+
+func getTrivia(d config.IDirective) *config.Trivia {
+	if holder, ok := d.(config.TriviaHolder); ok {
+		return holder.GetTrivia()
+	}
+	return nil
+}
+
+func lastLineIndent(s string) string {
+	s = s[strings.LastIndexAny(s, "\r\n")+1:]
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
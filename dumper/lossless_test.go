@@ -0,0 +1,365 @@
+package dumper_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+const losslessConf = `# main config
+user  www   www ;
+
+events {
+	worker_connections 1024;   # inline
+}
+
+http {
+    log_format  main  '$remote_addr - $remote_user'
+                      "$status";
+
+    server {
+        listen       80;
+        server_name  "example.com"   www.example.com;
+
+        location ~* \.(gif|jpg)$ {
+            expires 30d;
+        }
+        content_by_lua_block {
+            ngx.say("hi")
+        }
+    }
+    upstream backend {
+        server 127.0.0.1:8080   weight=5;
+
+        server 127.0.0.2:8080;
+    }
+}
+# trailing comment
+`
+
+func TestLossless_RoundTrip(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(losslessConf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), losslessConf)
+}
+
+func TestLossless_RoundTripFile(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/full_conf/nginx.conf", parser.WithLossless())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	data, err := os.ReadFile("../testdata/full_conf/nginx.conf")
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), string(data))
+}
+
+func TestLossless_ModifiedDirective(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(losslessConf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+
+	listen := c.FindDirectives("listen")[0].(*config.Directive)
+	listen.Parameters[0].SetValue("8080")
+
+	upstream := c.FindUpstreams()[0]
+	upstream.UpstreamServers[1].Parameters["weight"] = "2"
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `# main config
+user  www   www ;
+
+events {
+	worker_connections 1024;   # inline
+}
+
+http {
+    log_format  main  '$remote_addr - $remote_user'
+                      "$status";
+
+    server {
+        listen 8080;
+        server_name  "example.com"   www.example.com;
+
+        location ~* \.(gif|jpg)$ {
+            expires 30d;
+        }
+        content_by_lua_block {
+            ngx.say("hi")
+        }
+    }
+    upstream backend {
+        server 127.0.0.1:8080   weight=5;
+
+        server 127.0.0.2:8080 weight=2;
+    }
+}
+# trailing comment
+`)
+}
+
+func TestLossless_ModifiedInlineComment(t *testing.T) {
+	t.Parallel()
+	conf := `server {
+    listen  80;    # public
+    root  /var/www;   # files
+    index index.html;
+}
+`
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+
+	// only the comment changed, the directive text is kept
+	listen := c.FindDirectives("listen")[0].(*config.Directive)
+	listen.InlineComment[0].Value = "# private"
+	// the directive changed, the comment keeps its spacing
+	root := c.FindDirectives("root")[0].(*config.Directive)
+	root.Parameters[0].SetValue("/srv")
+	// a new comment is written one space after the semicolon
+	c.FindDirectives("index")[0].SetInlineComment(config.InlineComment{Value: "# default"})
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `server {
+    listen  80;    # private
+    root /srv;   # files
+    index index.html; # default
+}
+`)
+}
+
+func TestLossless_ModifiedBlockHead(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(losslessConf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+
+	location := c.FindDirectives("location")[0].(*config.Location)
+	location.Parameters[1].SetValue(`\.(gif|jpg|png)$`)
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `# main config
+user  www   www ;
+
+events {
+	worker_connections 1024;   # inline
+}
+
+http {
+    log_format  main  '$remote_addr - $remote_user'
+                      "$status";
+
+    server {
+        listen       80;
+        server_name  "example.com"   www.example.com;
+
+        location ~* \.(gif|jpg|png)$ {
+            expires 30d;
+        }
+        content_by_lua_block {
+            ngx.say("hi")
+        }
+    }
+    upstream backend {
+        server 127.0.0.1:8080   weight=5;
+
+        server 127.0.0.2:8080;
+    }
+}
+# trailing comment
+`)
+}
+
+func TestLossless_NewAndRemovedDirectives(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(losslessConf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+
+	events := c.FindDirectives("events")[0].GetBlock().(*config.Block)
+	events.Directives = append(events.Directives, &config.Directive{
+		Name:       "use",
+		Parameters: []config.Parameter{{Value: "epoll"}},
+	})
+
+	location := c.FindDirectives("location")[0].GetBlock().(*config.Block)
+	location.Directives = nil
+
+	upstream := c.FindUpstreams()[0]
+	upstream.AddServer(&config.UpstreamServer{Address: "127.0.0.3:8080"})
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `# main config
+user  www   www ;
+
+events {
+	worker_connections 1024;   # inline
+	use epoll;
+}
+
+http {
+    log_format  main  '$remote_addr - $remote_user'
+                      "$status";
+
+    server {
+        listen       80;
+        server_name  "example.com"   www.example.com;
+
+        location ~* \.(gif|jpg)$ {
+        }
+        content_by_lua_block {
+            ngx.say("hi")
+        }
+    }
+    upstream backend {
+        server 127.0.0.1:8080   weight=5;
+
+        server 127.0.0.2:8080;
+        server 127.0.0.3:8080;
+    }
+}
+# trailing comment
+`)
+}
+
+func TestLossless_Stream(t *testing.T) {
+	t.Parallel()
+	conf := `stream {
+    # dns
+    upstream  dns {
+        server 127.0.0.1:53   weight=5;
+    }
+
+    server {
+        listen 53   udp;
+        proxy_pass  dns;
+    }
+}
+`
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), conf)
+
+	c.FindStreams()[0].GetServers()[0].GetListens()[0].(*config.Directive).Parameters[0].Value = "5353"
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), strings.Replace(conf, "listen 53   udp", "listen 5353 udp", 1))
+}
+
+func TestLossless_Mutations(t *testing.T) {
+	t.Parallel()
+	conf := `http {
+    sendfile on;
+    server {
+        listen 80;
+    }
+    gzip  on;   # compress
+    upstream backend {
+        server 127.0.0.1:8080;
+        keepalive 16;
+    }
+}
+`
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+
+	http := c.FindDirectives("http")[0].(*config.HTTP)
+	gzip := c.FindDirectives("gzip")[0]
+	server, err := config.NewServer(&config.Directive{
+		Name:  "server",
+		Block: &config.Block{Directives: []config.IDirective{&config.Directive{Name: "listen", Parameters: []config.Parameter{{Value: "8080"}}}}},
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, http.InsertBefore(gzip, server))
+	assert.Equal(t, len(http.Servers), 2)
+
+	upstream := c.FindUpstreams()[0]
+	keepalive := upstream.FindDirectives("keepalive")[0]
+	assert.NilError(t, upstream.InsertBefore(keepalive, &config.UpstreamServer{Address: "127.0.0.2:8080"}))
+
+	listen := c.FindDirectives("listen")[0]
+	assert.NilError(t, listen.GetParent().GetBlock().(config.MutableBlock).RemoveDirective(listen))
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `http {
+    sendfile on;
+    server {
+    }
+    server {
+        listen 8080;
+    }
+    gzip  on;   # compress
+    upstream backend {
+        server 127.0.0.1:8080;
+        server 127.0.0.2:8080;
+        keepalive 16;
+    }
+}
+`)
+	// the order is kept by the other styles too
+	assert.Equal(t, dumper.DumpConfig(c, dumper.IndentedStyle), `http {
+    sendfile on;
+    server {
+
+    }
+    server {
+        listen 8080;
+    }
+    gzip on;# compress
+    upstream backend {
+        server 127.0.0.1:8080;
+        server 127.0.0.2:8080;
+        keepalive 16;
+    }
+}`)
+}
+
+func TestLossless_Reorder(t *testing.T) {
+	t.Parallel()
+	conf := `server {
+    # the port
+    listen 80;
+    root /var/www;
+    index  index.html;   # default
+    access_log off;
+}
+`
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+	block := c.FindDirectives("server")[0].GetBlock().(config.MutableBlock)
+	listen := block.FindDirectives("listen")[0]
+	root := block.FindDirectives("root")[0]
+	index := block.FindDirectives("index")[0]
+	accessLog := block.FindDirectives("access_log")[0]
+
+	// a directive removed and inserted again is dumped at its new place with its text
+	assert.NilError(t, block.RemoveDirective(index))
+	assert.NilError(t, block.InsertBefore(listen, index))
+	// a directive moved in its own block goes to the end
+	assert.NilError(t, block.MoveTo(root, block))
+	assert.NilError(t, block.ReplaceDirective(accessLog, &config.Directive{Name: "access_log", Parameters: []config.Parameter{{Value: "/var/log/access.log"}}}))
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `server {
+    index  index.html;   # default
+    # the port
+    listen 80;
+    access_log /var/log/access.log;
+    root /var/www;
+}
+`)
+}
+
+func TestLossless_Clone(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/full_conf/nginx.conf", parser.WithLossless())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	original := dumper.DumpConfig(c, dumper.LosslessStyle)
+
+	cp := c.Clone()
+	assert.Equal(t, dumper.DumpConfig(cp, dumper.LosslessStyle), original)
+	assert.Assert(t, config.EqualBlock(c, cp, config.EqualOptions{}))
+
+	// a what-if change of the copy leaves the original untouched
+	http := cp.FindDirectives("http")[0].(*config.HTTP)
+	assert.NilError(t, http.RemoveDirective(http.Servers[0]))
+	assert.Assert(t, !config.EqualBlock(c, cp, config.EqualOptions{}))
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), original)
+}
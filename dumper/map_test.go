@@ -0,0 +1,105 @@
+package dumper_test
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func TestMap_ToString(t *testing.T) {
+	t.Parallel()
+	m := &config.Map{Source: "$http_x_flag", Variable: "$feature_enabled"}
+	m.SetDefault("0")
+	m.AddEntry("~*^beta", "1")
+	m.AddEntry("''", "0")
+	assert.Equal(t, dumper.DumpDirective(m, dumper.IndentedStyle), `map $http_x_flag $feature_enabled {
+    default 0;
+    ~*^beta 1;
+    '' 0;
+}`)
+}
+
+func TestMap_Parse(t *testing.T) {
+	t.Parallel()
+	conf := `http {
+    map $http_upgrade $connection_upgrade {
+        default   upgrade;
+        ''        close;
+    }
+    geo $remote_addr $geo {
+        ranges;
+        default 0;
+        127.0.0.1-127.0.0.1 1; # local
+    }
+    split_clients "${remote_addr}AAA" $variant {
+        0.5% .one;
+        *    "";
+    }
+}
+`
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), conf)
+
+	m, ok := c.FindDirectives("map")[0].(*config.Map)
+	assert.Assert(t, ok)
+	assert.Equal(t, m.FindEntry("''").Value, "close")
+	g, ok := c.FindDirectives("geo")[0].(*config.Geo)
+	assert.Assert(t, ok)
+	assert.Equal(t, g.Address, "$remote_addr")
+	assert.Equal(t, g.Entries[0].Kind(), config.RangesEntry)
+	assert.Equal(t, g.Entries[2].GetInlineComment()[0].Value, "# local")
+	sc, ok := c.FindDirectives("split_clients")[0].(*config.SplitClients)
+	assert.Assert(t, ok)
+	assert.Equal(t, sc.GetDefault(), `""`)
+
+	m.SetEntry("''", "keep-alive")
+	m.AddEntry("websocket", "upgrade")
+	g.RemoveEntry("default")
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), `http {
+    map $http_upgrade $connection_upgrade {
+        default   upgrade;
+        '' keep-alive;
+        websocket upgrade;
+    }
+    geo $remote_addr $geo {
+        ranges;
+        127.0.0.1-127.0.0.1 1; # local
+    }
+    split_clients "${remote_addr}AAA" $variant {
+        0.5% .one;
+        *    "";
+    }
+}
+`)
+}
+
+func TestMap_Include(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/map/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+
+	m := c.FindDirectives("map")[0].(*config.Map)
+	assert.Equal(t, m.Entries[1].Kind(), config.IncludeEntry)
+	include := m.Entries[1].Include
+	assert.Assert(t, include != nil)
+	assert.Equal(t, len(include.Configs), 1)
+	// the include is kept in the block with the entries of its file
+	assert.Equal(t, m.GetDirectives()[1], config.IDirective(include))
+	assert.Equal(t, include.GetParent(), config.IDirective(m))
+	assert.Equal(t, len(include.GetDirectives()), 2)
+
+	g := c.FindDirectives("geo")[0].(*config.Geo)
+	assert.Equal(t, len(g.Entries[1].Include.Configs), 1)
+
+	cp := c.Clone()
+	mcp := cp.FindDirectives("map")[0].(*config.Map)
+	assert.Assert(t, mcp.Entries[1].Include != include)
+	assert.Equal(t, len(mcp.Entries[1].Include.Configs), 1)
+	assert.Equal(t, dumper.DumpConfig(cp, dumper.IndentedStyle), dumper.DumpConfig(c, dumper.IndentedStyle))
+}
@@ -0,0 +1,3 @@
+// Package effective computes the effective configuration of a block, the directives
+// set in the block and the ones it inherits from its enclosing blocks.
+package effective
@@ -0,0 +1,171 @@
+package effective
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+)
+
+// Directive is a directive of the effective configuration along with its origin
+type Directive struct {
+	Name       string
+	Parameters []string
+	Source     config.IDirective // the directive in the config, nil for a default value
+	File       string            // the file it is set in, empty when parsed from a string
+	Line       int
+	Block      config.IDirective // the block it is set in, nil for the main context
+	Inherited  bool              // set in an enclosing block
+	Default    bool              // neither set nor inherited, the default value of the directive registry
+}
+
+// Config is the effective configuration of a block
+type Config struct {
+	Context    parser.Context
+	Directives map[string][]*Directive // set in the block or inherited, by name
+}
+
+// Get returns the effective directives with the given name, the default value
+// of the directive if it is neither set nor inherited, nil if it has none or its
+// default depends on the platform or the build, like client_body_buffer_size 8k|16k
+func (c *Config) Get(name string) []*Directive {
+	if directives, ok := c.Directives[name]; ok {
+		return directives
+	}
+	spec, ok := parser.LookupDirective(name, c.Context)
+	if !ok {
+		return nil
+	}
+	params := spec.DefaultParameters()
+	if params == nil {
+		return nil
+	}
+	return []*Directive{{
+		Name:       name,
+		Parameters: params,
+		Default:    true,
+	}}
+}
+
+// Value returns the parameters of the first effective directive with the given name,
+// joined by spaces, empty if there is none
+func (c *Config) Value(name string) string {
+	directives := c.Get(name)
+	if len(directives) == 0 {
+		return ""
+	}
+	return strings.Join(directives[0].Parameters, " ")
+}
+
+// Names returns the sorted names of the directives set in the block or inherited
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Directives))
+	for name := range c.Directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// located is a directive and the file it is in
+type located struct {
+	directive config.IDirective
+	file      string
+}
+
+// level is a block on the way from the main context to the resolved block
+type level struct {
+	block      config.IDirective // nil for the main context
+	directives []located
+}
+
+// Resolve computes the effective configuration of a block of the config, like a
+// *config.Server or a *config.Location. A directive set in a block replaces the
+// whole value inherited from the enclosing blocks, even for directives that can
+// be repeated like add_header. Only the directives that are inheritable according
+// to the directive registry are inherited, unknown directives are not.
+func Resolve(cfg *config.Config, block config.IDirective) (*Config, error) {
+	if block == nil || block.GetBlock() == nil {
+		return nil, errors.New("the directive to resolve must have a block")
+	}
+	path, ok := findPath(nil, cfg.Block, cfg.FilePath, block)
+	if !ok {
+		return nil, errors.New("the block is not in the config")
+	}
+
+	ctx := parser.MainContext
+	values := map[string][]*Directive{}
+	for i, lvl := range path {
+		if i > 0 {
+			ctx = parser.ChildContext(ctx, lvl.block.GetName())
+			inherited := map[string][]*Directive{}
+			for name, directives := range values {
+				if spec, ok := parser.LookupDirective(name, ctx); ok && spec.Inheritable {
+					inherited[name] = directives
+				}
+			}
+			values = inherited
+		}
+
+		set := map[string][]*Directive{}
+		for _, l := range lvl.directives {
+			d := l.directive
+			if d.GetBlock() != nil && d.GetBlock().GetCodeBlock() == "" {
+				continue
+			}
+			set[d.GetName()] = append(set[d.GetName()], &Directive{
+				Name:       d.GetName(),
+				Parameters: config.Values(d.GetParameters()),
+				Source:     d,
+				File:       l.file,
+				Line:       d.GetLine(),
+				Block:      lvl.block,
+				Inherited:  lvl.block != block,
+			})
+		}
+		for name, directives := range set {
+			values[name] = directives
+		}
+	}
+	return &Config{Context: ctx, Directives: values}, nil
+}
+
+// expand returns the directives of a block, the ones of included files in place of their include
+func expand(b config.IBlock, file string) []located {
+	all := make([]located, 0)
+	if b == nil {
+		return all
+	}
+	for _, d := range b.GetDirectives() {
+		if include, ok := d.(*config.Include); ok {
+			for _, c := range include.Configs {
+				if c != nil {
+					all = append(all, expand(c.Block, c.FilePath)...)
+				}
+			}
+			continue
+		}
+		all = append(all, located{directive: d, file: file})
+	}
+	return all
+}
+
+// findPath returns the blocks from the given one down to the target
+func findPath(parent config.IDirective, b config.IBlock, file string, target config.IDirective) ([]level, bool) {
+	lvl := level{block: parent, directives: expand(b, file)}
+	for _, l := range lvl.directives {
+		d := l.directive
+		if d.GetBlock() == nil {
+			continue
+		}
+		if d == target {
+			return []level{lvl, {block: d, directives: expand(d.GetBlock(), l.file)}}, true
+		}
+		if path, ok := findPath(d, d.GetBlock(), l.file, target); ok {
+			return append([]level{lvl}, path...), true
+		}
+	}
+	return nil, false
+}
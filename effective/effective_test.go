@@ -0,0 +1,146 @@
+package effective
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func parseConf(t *testing.T) *config.Config {
+	t.Helper()
+	p, err := parser.NewParser("../testdata/effective/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+func findLocation(c *config.Config, match string) *config.Location {
+	for _, d := range c.FindDirectives("location") {
+		if l := d.(*config.Location); l.Match == match {
+			return l
+		}
+	}
+	return nil
+}
+
+func values(directives []*Directive) []string {
+	v := make([]string, 0)
+	for _, d := range directives {
+		v = append(v, d.Parameters...)
+	}
+	return v
+}
+
+func TestResolve_Server(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	server := c.FindDirectives("server")[0]
+	e, err := Resolve(c, server)
+	assert.NilError(t, err)
+	assert.Equal(t, e.Context, parser.ServerContext)
+
+	// set in the server, replaces the headers of http
+	headers := e.Get("add_header")
+	assert.DeepEqual(t, values(headers), []string{"X-Server", "example"})
+	assert.Assert(t, !headers[0].Inherited)
+	assert.Equal(t, headers[0].Line, 13)
+	assert.Equal(t, headers[0].Block, server)
+
+	// inherited from http
+	size := e.Get("client_max_body_size")
+	assert.Equal(t, e.Value("client_max_body_size"), "10m")
+	assert.Assert(t, size[0].Inherited)
+	assert.Equal(t, size[0].File, "../testdata/effective/nginx.conf")
+	assert.Equal(t, size[0].Line, 7)
+	assert.Equal(t, size[0].Block.GetName(), "http")
+
+	// inherited from main
+	assert.Equal(t, e.Value("error_log"), "logs/error.log warn")
+	assert.Assert(t, e.Get("error_log")[0].Block == nil)
+
+	// not inheritable
+	assert.Assert(t, e.Get("worker_processes") == nil)
+	assert.DeepEqual(t, e.Names(), []string{"add_header", "client_max_body_size", "error_log", "listen", "proxy_set_header", "server_name"})
+}
+
+func TestResolve_Location(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+
+	e, err := Resolve(c, findLocation(c, "/"))
+	assert.NilError(t, err)
+	assert.Equal(t, e.Context, parser.LocationContext)
+	assert.DeepEqual(t, values(e.Get("add_header")), []string{"X-Server", "example"})
+	assert.DeepEqual(t, values(e.Get("proxy_set_header")), []string{"Host", "$host"})
+	assert.Assert(t, e.Get("server_name") == nil)
+	assert.Assert(t, e.Get("listen") == nil)
+	assert.Assert(t, e.Get("proxy_pass")[0].Block == config.IDirective(findLocation(c, "/")))
+
+	// array directives are replaced wholesale, the Host header is not sent anymore
+	e, err = Resolve(c, findLocation(c, "/api/"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values(e.Get("add_header")), []string{"X-Api", "1", "X-Api-Version", "2"})
+	assert.DeepEqual(t, values(e.Get("proxy_set_header")), []string{"X-Real-IP", "$remote_addr"})
+	assert.Equal(t, e.Value("client_max_body_size"), "50m")
+
+	e, err = Resolve(c, findLocation(c, "/api/upload/"))
+	assert.NilError(t, err)
+	assert.Equal(t, e.Value("client_max_body_size"), "1g")
+	assert.DeepEqual(t, values(e.Get("add_header")), []string{"X-Api", "1", "X-Api-Version", "2"})
+	assert.Equal(t, e.Get("add_header")[0].Line, 20)
+}
+
+func TestResolve_Include(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	e, err := Resolve(c, c.FindDirectives("http")[0])
+	assert.NilError(t, err)
+	headers := e.Get("add_header")
+	assert.DeepEqual(t, values(headers), []string{"X-Included", "yes", "X-Frame-Options", "DENY"})
+	assert.Equal(t, headers[0].File, "../testdata/effective/headers.conf")
+	assert.Equal(t, headers[0].Line, 1)
+	assert.Equal(t, headers[1].File, "../testdata/effective/nginx.conf")
+}
+
+func TestResolve_Defaults(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	e, err := Resolve(c, findLocation(c, "/"))
+	assert.NilError(t, err)
+	sendfile := e.Get("sendfile")
+	assert.Equal(t, len(sendfile), 1)
+	assert.Assert(t, sendfile[0].Default)
+	assert.Assert(t, sendfile[0].Source == nil)
+	assert.Equal(t, e.Value("sendfile"), "off")
+	assert.Equal(t, e.Value("proxy_pass"), "http://backend")
+	assert.Equal(t, e.Value("no_such_directive"), "")
+	// not allowed in a location
+	assert.Assert(t, e.Get("worker_connections") == nil)
+
+	// a default of several parameters
+	upstream := e.Get("proxy_next_upstream")
+	assert.Equal(t, len(upstream), 1)
+	assert.DeepEqual(t, upstream[0].Parameters, []string{"error", "timeout"})
+	// the defaults depending on the platform are not values
+	assert.Assert(t, e.Get("client_body_buffer_size") == nil)
+	assert.Equal(t, e.Value("fastcgi_buffers"), "")
+
+	c, err = parser.NewStringParser("http {\n    server {\n    }\n}\n").Parse()
+	assert.NilError(t, err)
+	server, err := Resolve(c, c.FindDirectives("server")[0])
+	assert.NilError(t, err)
+	assert.Assert(t, server.Get("listen") == nil)
+	assert.Assert(t, server.Get("server_name") == nil)
+}
+
+func TestResolve_Errors(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	_, err := Resolve(c, &config.Directive{Name: "server", Block: &config.Block{}})
+	assert.Error(t, err, "the block is not in the config")
+	_, err = Resolve(c, &config.Directive{Name: "listen"})
+	assert.Error(t, err, "the directive to resolve must have a block")
+}
@@ -26,8 +26,7 @@ func addCustomDirective(fullConf string, blockName string, directiveName string,
 		Name:       directiveName,
 		Parameters: []config.Parameter{{Value: directiveValue}},
 	}
-	realBlock := block.(*config.Block)
-	realBlock.Directives = append(realBlock.Directives, newDirective)
+	block.(config.MutableBlock).AddDirective(newDirective)
 
 	return dumper.DumpConfig(conf, dumper.IndentedStyle), nil
 }
@@ -4,6 +4,7 @@ go 1.22
 
 require (
 	github.com/imega/luaformatter v0.0.0-20211025140405-86b0a68d6bef
+	gopkg.in/yaml.v3 v3.0.1
 	gotest.tools/v3 v3.5.1
 )
 
@@ -0,0 +1,8 @@
+// Package lint checks configs against rules of nginx best practices and reports findings
+// with their rule, severity, position and a suggested fix. Rules are pluggable with Register
+// and can be disabled for a file with a comment anywhere in it, like
+//
+//	# gonginx:disable if-in-location root-in-location
+//
+// or for all rules with "# gonginx:disable".
+package lint
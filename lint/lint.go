@@ -0,0 +1,277 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Severity is how serious a finding is
+type Severity int
+
+const (
+	// Info is a suggestion
+	Info Severity = iota + 1
+	// Warning is a likely mistake
+	Warning
+	// Error is a config nginx rejects or that is broken
+	Error
+)
+
+var severityName = map[Severity]string{
+	Info:    "info",
+	Warning: "warning",
+	Error:   "error",
+}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	return severityName[s]
+}
+
+// MarshalText writes the name of the severity
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Finding is a problem found by a rule
+type Finding struct {
+	Rule      string            `json:"rule"`
+	Severity  Severity          `json:"severity"`
+	Message   string            `json:"message"`
+	Fix       string            `json:"fix,omitempty"` // how to fix it
+	File      string            `json:"file,omitempty"`
+	Line      int               `json:"line"`
+	Column    int               `json:"column,omitempty"` // 0 when the rule does not know it
+	Directive config.IDirective `json:"-"`
+}
+
+// String returns the position, the severity, the message and the rule of the finding
+func (f Finding) String() string {
+	if f.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", f.File, f.Line, f.Column, f.Severity, f.Message, f.Rule)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s (%s)", f.File, f.Line, f.Severity, f.Message, f.Rule)
+}
+
+// Rule checks a config
+type Rule interface {
+	// ID is the name of the rule in reports and disable comments, like if-in-location
+	ID() string
+	Description() string
+	// Severity is the severity of the findings that do not set one
+	Severity() Severity
+	Check(p *Pass)
+}
+
+// NewRule returns a rule that calls check
+func NewRule(id, description string, severity Severity, check func(p *Pass)) Rule {
+	return &funcRule{id: id, description: description, severity: severity, check: check}
+}
+
+type funcRule struct {
+	id, description string
+	severity        Severity
+	check           func(p *Pass)
+}
+
+func (r *funcRule) ID() string          { return r.id }
+func (r *funcRule) Description() string { return r.description }
+func (r *funcRule) Severity() Severity  { return r.severity }
+func (r *funcRule) Check(p *Pass)       { r.check(p) }
+
+// Pass is the run of a rule on a config
+type Pass struct {
+	Config   *config.Config
+	rule     Rule
+	findings []Finding
+	shared   *shared
+}
+
+// shared is what the passes of a run compute once for all the rules
+type shared struct {
+	variables *VariableTable
+	servers   []server
+	resolved  bool // servers is computed
+}
+
+// Variables returns the variable table of the config, it is computed once for all the rules of a run
+func (p *Pass) Variables() *VariableTable {
+	if p.shared == nil {
+		p.shared = &shared{}
+	}
+	if p.shared.variables == nil {
+		p.shared.variables = Variables(p.Config)
+	}
+	return p.shared.variables
+}
+
+// servers returns the http servers of the config with their effective configuration,
+// they are resolved once for all the rules of a run
+func (p *Pass) servers() []server {
+	if p.shared == nil {
+		p.shared = &shared{}
+	}
+	if !p.shared.resolved {
+		p.shared.servers, p.shared.resolved = servers(p.Config), true
+	}
+	return p.shared.servers
+}
+
+// Report adds a finding, the rule, its severity and the line of the directive are set when missing
+func (p *Pass) Report(f Finding) {
+	f.Rule = p.rule.ID()
+	if f.Severity == 0 {
+		f.Severity = p.rule.Severity()
+	}
+	if f.Line == 0 && f.Directive != nil {
+		f.Line = f.Directive.GetLine()
+	}
+	// the column of the directive, when the finding is on its line
+	if holder, ok := f.Directive.(config.ColumnHolder); ok && f.Column == 0 && f.Line == f.Directive.GetLine() {
+		f.Column = holder.GetColumn()
+	}
+	p.findings = append(p.findings, f)
+}
+
+// ReportAt adds a finding at the directive of the cursor
+func (p *Pass) ReportAt(c *config.Cursor, message, fix string) {
+	p.Report(Finding{Message: message, Fix: fix, File: c.File, Directive: c.Directive})
+}
+
+var (
+	mu       sync.RWMutex
+	registry []Rule
+)
+
+// Register adds a rule to the rules run by default, it replaces a rule with the same ID
+func Register(rule Rule) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i, r := range registry {
+		if r.ID() == rule.ID() {
+			registry[i] = rule
+			return
+		}
+	}
+	registry = append(registry, rule)
+}
+
+// Rules returns the registered rules, the built-in ones first
+func Rules() []Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Rule(nil), registry...)
+}
+
+// Linter runs rules on configs
+type Linter struct {
+	rules    []Rule
+	disabled map[string]bool
+}
+
+// New returns a linter running the given rules, or all the registered rules
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = Rules()
+	}
+	return &Linter{rules: rules, disabled: map[string]bool{}}
+}
+
+// Disable turns off rules for all files
+func (l *Linter) Disable(ids ...string) *Linter {
+	for _, id := range ids {
+		l.disabled[id] = true
+	}
+	return l
+}
+
+// Rules returns the rules the linter runs, the disabled ones excluded
+func (l *Linter) Rules() []Rule {
+	rules := make([]Rule, 0, len(l.rules))
+	for _, rule := range l.rules {
+		if !l.disabled[rule.ID()] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// Lint runs the rules on the config and its included files, the findings are sorted by position
+func (l *Linter) Lint(cfg *config.Config) []Finding {
+	disabled := disabledByComments(cfg)
+	var findings []Finding
+	run := &shared{}
+	for _, rule := range l.Rules() {
+		p := &Pass{Config: cfg, rule: rule, shared: run}
+		rule.Check(p)
+		for _, f := range p.findings {
+			if ids, ok := disabled[f.File]; ok && (ids[""] || ids[f.Rule]) {
+				continue
+			}
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+	return findings
+}
+
+// Lint runs the registered rules on the config
+func Lint(cfg *config.Config) []Finding {
+	return New().Lint(cfg)
+}
+
+const disableComment = "gonginx:disable"
+
+// disabledByComments returns the rules disabled in each file, "" for all of them
+func disabledByComments(cfg *config.Config) map[string]map[string]bool {
+	disabled := map[string]map[string]bool{}
+	disable := func(file string, comments []string) {
+		for _, comment := range comments {
+			text := strings.TrimSpace(strings.TrimPrefix(comment, "#"))
+			rest, ok := strings.CutPrefix(text, disableComment)
+			if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+				continue
+			}
+			if disabled[file] == nil {
+				disabled[file] = map[string]bool{}
+			}
+			ids := strings.FieldsFunc(rest, func(r rune) bool {
+				return r == ' ' || r == ',' || r == '\t'
+			})
+			if len(ids) == 0 {
+				disabled[file][""] = true
+			}
+			for _, id := range ids {
+				disabled[file][id] = true
+			}
+		}
+	}
+	disable(cfg.FilePath, cfg.ClosingComment)
+	_ = config.Inspect(cfg, func(c *config.Cursor) error {
+		comments := append([]string{}, c.Directive.GetComment()...)
+		for _, comment := range c.Directive.GetInlineComment() {
+			comments = append(comments, comment.Value)
+		}
+		disable(c.File, comments)
+		// the comments at the end of an included file
+		if include, ok := c.Directive.(*config.Include); ok {
+			for _, conf := range include.Configs {
+				disable(conf.FilePath, conf.ClosingComment)
+			}
+		}
+		return nil
+	})
+	return disabled
+}
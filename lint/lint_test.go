@@ -0,0 +1,263 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func parseFile(t *testing.T) *config.Config {
+	t.Helper()
+	p, err := parser.NewParser("../testdata/lint/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+func lines(findings []Finding) []string {
+	out := make([]string, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, f.String())
+	}
+	return out
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+	findings := Lint(parseFile(t))
+	assert.DeepEqual(t, lines(findings), []string{
+		"../testdata/lint/conf.d/admin.conf:3:5: error: duplicate default server for *:80, it is already set at ../testdata/lint/nginx.conf:6 (duplicate-default-server)",
+		`../testdata/lint/conf.d/admin.conf:3:5: warning: conflicting server name "" on *:80, the server at ../testdata/lint/nginx.conf:6 already has it, this one is ignored (conflicting-server-name)`,
+		"../testdata/lint/nginx.conf:7:9: warning: add_header in server drops the headers of http: X-Frame-Options (add-header-inheritance)",
+		"../testdata/lint/nginx.conf:9:13: warning: root in location / is not used by the other locations of the server (root-in-location)",
+		"../testdata/lint/nginx.conf:10:13: warning: if in location is only safe with return or rewrite ... last, it has set (if-in-location)",
+		"../testdata/lint/nginx.conf:11:17: warning: variable $post is defined by set but never used (unused-variable)",
+		`../testdata/lint/nginx.conf:16:13: error: proxy_pass cannot have a URI part in location ~ \.php$ (proxy-pass-uri)`,
+		"../testdata/lint/nginx.conf:19:13: error: location /img without trailing slash and alias /data/img/ with one allow path traversal, like /img../ (alias-trailing-slash)",
+		"../testdata/lint/nginx.conf:22:13: warning: location /files/ with trailing slash and alias /data/files without one join the paths without slash (alias-trailing-slash)",
+	})
+	assert.Equal(t, findings[2].Fix, "repeat the add_header directives of http in this block, or include them from a shared file")
+	assert.Equal(t, findings[2].Directive.GetName(), "add_header")
+
+	data, err := json.Marshal(findings[0])
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"rule":"duplicate-default-server","severity":"error","message":"duplicate default server for *:80, it is already set at ../testdata/lint/nginx.conf:6","fix":"remove default_server from one of the listen directives","file":"../testdata/lint/conf.d/admin.conf","line":3,"column":5}`)
+}
+
+func TestLinter_Disable(t *testing.T) {
+	t.Parallel()
+	findings := New().Disable("add-header-inheritance", "alias-trailing-slash", "duplicate-default-server", "conflicting-server-name", "unused-variable").Lint(parseFile(t))
+	assert.Equal(t, len(findings), 3)
+
+	findings = New(Rules()[0]).Lint(parseFile(t))
+	assert.Equal(t, len(findings), 1)
+	assert.Equal(t, findings[0].Rule, "if-in-location")
+}
+
+func TestLinter_DisableComments(t *testing.T) {
+	t.Parallel()
+	body := "server {\n    location / {\n        root /var/www;\n    }\n    location /api {\n    }\n}\n"
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "first line", config: "# gonginx:disable root-in-location\n" + body},
+		{name: "end of the file", config: body + "# gonginx:disable root-in-location\n"},
+		{name: "end of the last block of the file", config: strings.Replace(body, "    }\n}", "    }\n    # gonginx:disable root-in-location\n}", 1)},
+		// the comment belongs to the directive after the block, in the same file
+		{name: "end of a block before a sibling", config: strings.Replace(body, "    }\n}\n", "    }\n    # gonginx:disable root-in-location\n}\nworker_processes 1;\n", 1)},
+		{name: "all rules", config: body + "# gonginx:disable\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parser.NewStringParser(tt.config).Parse()
+			assert.NilError(t, err)
+			assert.Equal(t, len(New(Rules()...).Lint(c)), 0)
+		})
+	}
+
+	c, err := parser.NewStringParser(body).Parse()
+	assert.NilError(t, err)
+	assert.Equal(t, len(New(Rules()...).Lint(c)), 1)
+}
+
+func TestRegister(t *testing.T) {
+	c, err := parser.NewStringParser("http {\n    server_tokens on;\n}\n").Parse()
+	assert.NilError(t, err)
+	rule := NewRule("server-tokens", "server_tokens should be off", Info, func(p *Pass) {
+		_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+			if c.Directive.GetName() == "server_tokens" && c.Directive.GetParameters()[0].GetValue() == "on" {
+				p.ReportAt(c, "server_tokens on shows the nginx version", "set server_tokens off")
+			}
+			return nil
+		})
+	})
+	Register(rule)
+	defer func() {
+		mu.Lock()
+		registry = registry[:len(registry)-1]
+		mu.Unlock()
+	}()
+	assert.DeepEqual(t, lines(Lint(c)), []string{":2:5: info: server_tokens on shows the nginx version (server-tokens)"})
+}
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/lint/security.conf")
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lines(Audit(c)), []string{
+		"../testdata/lint/security.conf:4:5: error: ssl_protocols allows TLSv1, TLSv1.1 (ssl-protocols)",
+		"../testdata/lint/security.conf:5:5: warning: server_tokens on shows the nginx version in headers and error pages (server-tokens)",
+		"../testdata/lint/security.conf:10:5: warning: server_tokens on shows the nginx version in headers and error pages (server-tokens)",
+		"../testdata/lint/security.conf:10:5: warning: plain HTTP server without a redirect to HTTPS (https-redirect)",
+		"../testdata/lint/security.conf:12:9: warning: autoindex on lists the files of the directories (autoindex)",
+		"../testdata/lint/security.conf:14:13: error: allow all in the sensitive location /admin/ (allow-all-sensitive)",
+		"../testdata/lint/security.conf:17:5: warning: TLS server without a Strict-Transport-Security header (hsts)",
+		"../testdata/lint/security.conf:20:9: error: ssl_ciphers allows weak ciphers: RC4-SHA, DES-CBC3-SHA (ssl-ciphers)",
+		"../testdata/lint/security.conf:23:5: warning: ssl_prefer_server_ciphers is off, clients choose the cipher (ssl-prefer-server-ciphers)",
+	})
+}
+
+func TestVirtualHosts(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/lint/vhosts/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+
+	var hosts []string
+	for _, h := range VirtualHosts(c) {
+		hosts = append(hosts, fmt.Sprintf("%s %s %t %s:%d", h.Address, h.Name, h.Default, h.File, h.Line))
+	}
+	assert.DeepEqual(t, hosts, []string{
+		"*:80 _ true ../testdata/lint/vhosts/nginx.conf:3",
+		"*:443 api.example.com true ../testdata/lint/vhosts/sites-enabled/api-v2.conf:2",
+		"*:443 v2.example.com true ../testdata/lint/vhosts/sites-enabled/api-v2.conf:2",
+		"*:80 www.example.com false ../testdata/lint/vhosts/sites-enabled/api-v2.conf:6",
+		"*:443 api.example.com true ../testdata/lint/vhosts/sites-enabled/api.conf:2",
+		"*:443 www.example.com true ../testdata/lint/vhosts/sites-enabled/api.conf:2",
+		"[::]:443 api.example.com false ../testdata/lint/vhosts/sites-enabled/api.conf:3",
+		"[::]:443 www.example.com false ../testdata/lint/vhosts/sites-enabled/api.conf:3",
+	})
+
+	findings := New(Rules()...).Lint(c)
+	assert.DeepEqual(t, lines(findings), []string{
+		"../testdata/lint/vhosts/sites-enabled/api.conf:2:5: error: duplicate default server for *:443, it is already set at ../testdata/lint/vhosts/sites-enabled/api-v2.conf:2 (duplicate-default-server)",
+		`../testdata/lint/vhosts/sites-enabled/api.conf:2:5: warning: conflicting server name "api.example.com" on *:443, the server at ../testdata/lint/vhosts/sites-enabled/api-v2.conf:2 already has it, this one is ignored (conflicting-server-name)`,
+	})
+}
+
+func TestVariables(t *testing.T) {
+	t.Parallel()
+	p, err := parser.NewParser("../testdata/lint/variables.conf")
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+
+	table := Variables(c)
+	var defs []string
+	for _, d := range table.Definitions {
+		defs = append(defs, fmt.Sprintf("%s %s:%d %t", d.Name, d.Directive.GetName(), d.Line, d.Capture))
+	}
+	assert.DeepEqual(t, defs, []string{
+		"backend map:3 false",
+		`tenant ~^(?<tenant>[a-z]+)\.example\.com$:5 true`,
+		"unused_map map:8 false",
+		"office geo:11 false",
+		"variant split_clients:15 false",
+		"sub server_name:20 true",
+		"host set:21 false",
+		"limit_rate set:22 false",
+		"user_id location:23 true",
+		"tenant if:32 true",
+		"office set:40 false",
+	})
+	assert.Equal(t, len(table.References), 35)
+
+	var rules []Rule
+	for _, rule := range Rules() {
+		if strings.HasSuffix(rule.ID(), "-variable") {
+			rules = append(rules, rule)
+		}
+	}
+	findings := New(rules...).Lint(c)
+	assert.DeepEqual(t, lines(findings), []string{
+		"../testdata/lint/variables.conf:6:9: error: unknown variable $api_backend (undefined-variable)",
+		"../testdata/lint/variables.conf:8:5: warning: variable $unused_map is defined by map but never used (unused-variable)",
+		"../testdata/lint/variables.conf:15:5: warning: variable $variant is defined by split_clients but never used (unused-variable)",
+		"../testdata/lint/variables.conf:21:9: warning: set defines $host over the built-in variable (shadowed-variable)",
+		"../testdata/lint/variables.conf:25:13: error: unknown variable $varaint (undefined-variable)",
+		"../testdata/lint/variables.conf:29:13: error: unknown variable $offcie (undefined-variable)",
+		"../testdata/lint/variables.conf:40:13: warning: set redefines $office, it is already defined at ../testdata/lint/variables.conf:11 (shadowed-variable)",
+		"../testdata/lint/variables.conf:42:13: warning: unknown variable $reqeust_uri (undefined-variable)",
+	})
+	assert.Equal(t, findings[4].Fix, "did you mean $variant?")
+	assert.Equal(t, findings[5].Fix, "did you mean $office?")
+	assert.Equal(t, findings[7].Fix, "did you mean $request_uri?")
+	assert.Equal(t, findings[0].Fix, "")
+
+	RegisterVariables("$api_backend", "my_module_*")
+	defer func() {
+		mu.Lock()
+		delete(builtinVariables, "api_backend")
+		builtinPrefixes = builtinPrefixes[:len(builtinPrefixes)-1]
+		mu.Unlock()
+	}()
+	assert.Assert(t, IsBuiltinVariable("api_backend"))
+	assert.Assert(t, IsBuiltinVariable("my_module_x"))
+	assert.Assert(t, !IsBuiltinVariable("my_module_"))
+	assert.Assert(t, IsBuiltinVariable("http_user_agent"))
+	assert.Assert(t, IsBuiltinVariable("1"))
+	assert.Assert(t, !IsBuiltinVariable("hots"))
+}
+
+func TestPass_Variables(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser("http {\n    set $a 1;\n    return 200 $a;\n}\n").Parse()
+	assert.NilError(t, err)
+	var tables []*VariableTable
+	record := func(p *Pass) { tables = append(tables, p.Variables()) }
+	New(NewRule("first", "", Info, record), NewRule("second", "", Info, record)).Lint(c)
+	assert.Equal(t, len(tables), 2)
+	assert.Assert(t, tables[0] == tables[1], "the table is computed once for a run")
+	assert.Equal(t, len(tables[0].Definitions), 1)
+
+	p := &Pass{Config: c}
+	assert.Equal(t, len(p.Variables().References), 1)
+}
+
+func TestPass_Servers(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser("http {\n    server {\n        listen 443 ssl;\n    }\n}\n").Parse()
+	assert.NilError(t, err)
+	var resolved [][]server
+	record := func(p *Pass) { resolved = append(resolved, p.servers()) }
+	New(NewRule("first", "", Info, record), NewRule("second", "", Info, record)).Lint(c)
+	assert.Equal(t, len(resolved), 2)
+	assert.Equal(t, len(resolved[0]), 1)
+	assert.Assert(t, resolved[0][0].effective == resolved[1][0].effective, "the servers are resolved once for a run")
+	assert.Assert(t, resolved[0][0].tls)
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	t.Parallel()
+	for address, want := range map[string]string{
+		"80":             "*:80",
+		"*:80":           "*:80",
+		"0.0.0.0:443":    "*:443",
+		"127.0.0.1":      "127.0.0.1:80",
+		"[::]:80":        "[::]:80",
+		"[::1]":          "[::1]:80",
+		"unix:/run/sock": "unix:/run/sock",
+	} {
+		assert.Equal(t, normalizeAddress(address), want, address)
+	}
+}
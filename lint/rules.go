@@ -0,0 +1,186 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+func init() {
+	Register(NewRule("if-in-location", "if in a location with other directives than return or rewrite ... last", Warning, ifInLocation))
+	Register(NewRule("add-header-inheritance", "add_header in a block drops the add_header directives of the enclosing blocks", Warning, addHeaderInheritance))
+	Register(NewRule("proxy-pass-uri", "proxy_pass with a URI in a regex or named location, an if or a limit_except", Error, proxyPassURI))
+	Register(NewRule("alias-trailing-slash", "alias and location with and without a trailing slash", Warning, aliasTrailingSlash))
+	Register(NewRule("root-in-location", "root in location / instead of the server", Warning, rootInLocation))
+	Register(NewRule("duplicate-default-server", "several default servers for the same address", Error, duplicateDefaultServer))
+	Register(NewRule("conflicting-server-name", "several servers with the same server name on the same address", Warning, conflictingServerName))
+}
+
+func ifInLocation(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		if c.Directive.GetName() != "if" || c.Parent() == nil || c.Parent().GetName() != "location" {
+			return nil
+		}
+		for _, d := range config.Flatten(c.Directive.GetBlock()) {
+			if d.GetName() == "return" || (d.GetName() == "rewrite" && lastParam(d) == "last") {
+				continue
+			}
+			p.ReportAt(c, fmt.Sprintf("if in location is only safe with return or rewrite ... last, it has %s", d.GetName()),
+				"keep only return or rewrite ... last in the if, use a map or try_files for the rest")
+			return nil
+		}
+		return nil
+	})
+}
+
+func addHeaderInheritance(p *Pass) {
+	seen := map[config.IDirective]bool{} // the blocks whose first add_header is reported
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		parent := c.Parent()
+		if c.Directive.GetName() != "add_header" || parent == nil || seen[parent] {
+			return nil
+		}
+		seen[parent] = true
+		for i := len(c.Path) - 2; i >= 0; i-- {
+			var inherited []string
+			for _, d := range config.Flatten(c.Path[i].GetBlock()) {
+				if d.GetName() == "add_header" && len(d.GetParameters()) > 0 {
+					inherited = append(inherited, d.GetParameters()[0].GetValue())
+				}
+			}
+			if len(inherited) > 0 {
+				p.ReportAt(c, fmt.Sprintf("add_header in %s drops the headers of %s: %s", head(parent), head(c.Path[i]), strings.Join(inherited, ", ")),
+					fmt.Sprintf("repeat the add_header directives of %s in this block, or include them from a shared file", head(c.Path[i])))
+				break
+			}
+		}
+		return nil
+	})
+}
+
+func proxyPassURI(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		if c.Directive.GetName() != "proxy_pass" || len(c.Directive.GetParameters()) == 0 {
+			return nil
+		}
+		url := c.Directive.GetParameters()[0].GetValue()
+		if !hasURI(url) {
+			return nil
+		}
+		for i := len(c.Path) - 1; i >= 0; i-- {
+			d := c.Path[i]
+			switch d.GetName() {
+			case "if", "limit_except":
+				p.ReportAt(c, fmt.Sprintf("proxy_pass cannot have a URI part inside %s", d.GetName()), "remove the URI part of "+url)
+				return nil
+			case "location":
+				modifier, match := location(d)
+				if modifier == "~" || modifier == "~*" || strings.HasPrefix(match, "@") {
+					p.ReportAt(c, fmt.Sprintf("proxy_pass cannot have a URI part in %s", head(d)),
+						"remove the URI part of "+url+", or use rewrite ... break to change the URI")
+				}
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// hasURI reports whether a proxy_pass URL has a path, URLs with variables are not checked
+func hasURI(url string) bool {
+	if strings.Contains(url, "$") || strings.Contains(url, "unix:") {
+		return false
+	}
+	_, rest, ok := strings.Cut(url, "://")
+	return ok && strings.Contains(rest, "/")
+}
+
+func aliasTrailingSlash(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		parent := c.Parent()
+		if c.Directive.GetName() != "alias" || parent == nil || parent.GetName() != "location" || len(c.Directive.GetParameters()) == 0 {
+			return nil
+		}
+		modifier, match := location(parent)
+		if modifier != "" && modifier != "^~" {
+			return nil
+		}
+		alias := c.Directive.GetParameters()[0].GetValue()
+		switch {
+		case !strings.HasSuffix(match, "/") && strings.HasSuffix(alias, "/"):
+			p.Report(Finding{
+				Severity:  Error,
+				Message:   fmt.Sprintf("location %s without trailing slash and alias %s with one allow path traversal, like %s../", match, alias, match),
+				Fix:       fmt.Sprintf("use location %s/", match),
+				File:      c.File,
+				Directive: c.Directive,
+			})
+		case strings.HasSuffix(match, "/") && !strings.HasSuffix(alias, "/"):
+			p.ReportAt(c, fmt.Sprintf("location %s with trailing slash and alias %s without one join the paths without slash", match, alias),
+				fmt.Sprintf("use alias %s/", alias))
+		}
+		return nil
+	})
+}
+
+func rootInLocation(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		parent := c.Parent()
+		if c.Directive.GetName() != "root" || parent == nil || parent.GetName() != "location" {
+			return nil
+		}
+		if modifier, match := location(parent); modifier == "" && match == "/" {
+			p.ReportAt(c, "root in location / is not used by the other locations of the server", "move root to the server block")
+		}
+		return nil
+	})
+}
+
+// normalizeAddress returns the same address for the forms of a listen address, like 80 and *:80
+func normalizeAddress(address string) string {
+	if strings.HasPrefix(address, "unix:") {
+		return address
+	}
+	if !strings.Contains(address, ":") || strings.HasSuffix(address, "]") {
+		if strings.Trim(address, "0123456789") == "" {
+			return "*:" + address
+		}
+		address += ":80"
+	}
+	if strings.HasPrefix(address, "0.0.0.0:") {
+		return "*:" + strings.TrimPrefix(address, "0.0.0.0:")
+	}
+	return address
+}
+
+// location returns the modifier and the match of a location
+func location(d config.IDirective) (string, string) {
+	if l, ok := d.(*config.Location); ok {
+		return l.ModifierMatch()
+	}
+	params := d.GetParameters()
+	switch len(params) {
+	case 1:
+		return "", params[0].GetValue()
+	case 2:
+		return params[0].GetValue(), params[1].GetValue()
+	}
+	return "", ""
+}
+
+func lastParam(d config.IDirective) string {
+	params := d.GetParameters()
+	if len(params) == 0 {
+		return ""
+	}
+	return params[len(params)-1].GetValue()
+}
+
+func head(d config.IDirective) string {
+	parts := []string{d.GetName()}
+	for _, p := range d.GetParameters() {
+		parts = append(parts, p.GetValue())
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,256 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/effective"
+)
+
+var security = []Rule{
+	NewRule("ssl-protocols", "TLS servers allowing SSLv2, SSLv3, TLSv1 or TLSv1.1", Error, weakProtocols),
+	NewRule("ssl-ciphers", "TLS servers allowing weak ciphers like RC4, DES, MD5 or export ciphers", Error, weakCiphers),
+	NewRule("ssl-prefer-server-ciphers", "TLS servers without ssl_prefer_server_ciphers on", Warning, preferServerCiphers),
+	NewRule("server-tokens", "servers showing the nginx version with server_tokens on", Warning, serverTokens),
+	NewRule("hsts", "TLS servers without a Strict-Transport-Security header", Warning, hsts),
+	NewRule("https-redirect", "plain HTTP servers that do not redirect to HTTPS", Warning, httpsRedirect),
+	NewRule("autoindex", "directory listing with autoindex on", Warning, autoindex),
+	NewRule("allow-all-sensitive", "allow all in a sensitive location like /admin or /.git", Error, allowAllSensitive),
+}
+
+// SensitiveLocations are the parts of location matches the allow-all-sensitive rule looks for
+var SensitiveLocations = []string{"admin", ".git", ".svn", ".env", ".ht", "phpmyadmin", "backup", "status", "private", ".sql"}
+
+// SecurityRules returns the TLS and security audit rules, they are not registered by default
+func SecurityRules() []Rule {
+	return append([]Rule(nil), security...)
+}
+
+// Audit runs the security rules on the config
+func Audit(cfg *config.Config) []Finding {
+	return New(SecurityRules()...).Lint(cfg)
+}
+
+// server is an http server of the config with its effective configuration
+type server struct {
+	cursor    *config.Cursor
+	effective *effective.Config
+	tls       bool // it listens with ssl or quic
+}
+
+// servers returns the servers of the http context, included files are walked
+func servers(cfg *config.Config) []server {
+	var all []server
+	_ = config.Inspect(cfg, func(c *config.Cursor) error {
+		if _, ok := c.Directive.(*config.Server); !ok || !inHTTP(c) {
+			return nil
+		}
+		e, err := effective.Resolve(cfg, c.Directive)
+		if err != nil {
+			return config.SkipChildren
+		}
+		all = append(all, server{cursor: c, effective: e, tls: isTLS(c.Directive, e)})
+		return config.SkipChildren
+	})
+	return all
+}
+
+func inHTTP(c *config.Cursor) bool {
+	for _, d := range c.Path {
+		if d.GetName() == "http" {
+			return true
+		}
+	}
+	return false
+}
+
+func isTLS(s config.IDirective, e *effective.Config) bool {
+	for _, d := range config.Flatten(s.GetBlock()) {
+		if d.GetName() != "listen" {
+			continue
+		}
+		for _, p := range d.GetParameters() {
+			if v := p.GetValue(); v == "ssl" || v == "quic" {
+				return true
+			}
+		}
+	}
+	return e.Value("ssl") == "on"
+}
+
+// reportEffective reports once at the directive an effective value comes from, or at the
+// server when the value is a default one or d is nil
+func reportEffective(p *Pass, reported map[config.IDirective]bool, s server, d *effective.Directive, message, fix string) {
+	f := Finding{Message: message, Fix: fix, File: s.cursor.File, Directive: s.cursor.Directive}
+	if d != nil && d.Source != nil {
+		f.File, f.Directive = d.File, d.Source
+	}
+	if reported[f.Directive] {
+		return
+	}
+	reported[f.Directive] = true
+	p.Report(f)
+}
+
+func first(directives []*effective.Directive) *effective.Directive {
+	if len(directives) == 0 {
+		return nil
+	}
+	return directives[0]
+}
+
+func weakProtocols(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		d := first(s.effective.Get("ssl_protocols"))
+		if !s.tls || d == nil {
+			continue
+		}
+		var weak []string
+		for _, protocol := range d.Parameters {
+			switch protocol {
+			case "SSLv2", "SSLv3", "TLSv1", "TLSv1.1":
+				weak = append(weak, protocol)
+			}
+		}
+		if len(weak) > 0 {
+			reportEffective(p, reported, s, d, fmt.Sprintf("ssl_protocols allows %s", strings.Join(weak, ", ")),
+				"use ssl_protocols TLSv1.2 TLSv1.3")
+		}
+	}
+}
+
+// weakCipher are the parts of OpenSSL cipher names and keywords of weak ciphers
+var weakCipher = map[string]bool{
+	"NULL": true, "aNULL": true, "eNULL": true, "EXP": true, "EXPORT": true, "LOW": true, "MEDIUM": true,
+	"RC4": true, "DES": true, "3DES": true, "MD5": true, "ADH": true, "AECDH": true,
+}
+
+func weakCiphers(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		d := first(s.effective.Get("ssl_ciphers"))
+		if !s.tls || d == nil || len(d.Parameters) == 0 {
+			continue
+		}
+		var weak []string
+		for _, cipher := range strings.FieldsFunc(strings.Trim(d.Parameters[0], `"'`), func(r rune) bool {
+			return r == ':' || r == ' ' || r == ','
+		}) {
+			if strings.HasPrefix(cipher, "!") || strings.HasPrefix(cipher, "-") {
+				continue
+			}
+			for _, part := range strings.FieldsFunc(strings.TrimPrefix(cipher, "+"), func(r rune) bool {
+				return r == '-' || r == '+'
+			}) {
+				if weakCipher[part] {
+					weak = append(weak, cipher)
+					break
+				}
+			}
+		}
+		if len(weak) > 0 {
+			reportEffective(p, reported, s, d, fmt.Sprintf("ssl_ciphers allows weak ciphers: %s", strings.Join(weak, ", ")),
+				"remove them, or exclude them like HIGH:!aNULL:!MD5:!RC4:!3DES")
+		}
+	}
+}
+
+func preferServerCiphers(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		d := first(s.effective.Get("ssl_prefer_server_ciphers"))
+		if s.tls && (d == nil || strings.Join(d.Parameters, " ") != "on") {
+			reportEffective(p, reported, s, d, "ssl_prefer_server_ciphers is off, clients choose the cipher",
+				"set ssl_prefer_server_ciphers on")
+		}
+	}
+}
+
+func serverTokens(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		if d := first(s.effective.Get("server_tokens")); d != nil && strings.Join(d.Parameters, " ") == "on" {
+			reportEffective(p, reported, s, d, "server_tokens on shows the nginx version in headers and error pages",
+				"set server_tokens off in the http block")
+		}
+	}
+}
+
+func hsts(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		if !s.tls {
+			continue
+		}
+		found := false
+		for _, d := range s.effective.Get("add_header") {
+			if len(d.Parameters) > 0 && strings.EqualFold(d.Parameters[0], "Strict-Transport-Security") {
+				found = true
+			}
+		}
+		if !found {
+			reportEffective(p, reported, s, nil, "TLS server without a Strict-Transport-Security header",
+				`add add_header Strict-Transport-Security "max-age=31536000" always`)
+		}
+	}
+}
+
+func httpsRedirect(p *Pass) {
+	reported := map[config.IDirective]bool{}
+	for _, s := range p.servers() {
+		if !s.tls && !redirectsToHTTPS(s.cursor.Directive) {
+			reportEffective(p, reported, s, nil, "plain HTTP server without a redirect to HTTPS",
+				"add return 301 https://$host$request_uri")
+		}
+	}
+}
+
+// redirectsToHTTPS reports whether the server, or its location /, returns or rewrites to an https URL
+func redirectsToHTTPS(s config.IDirective) bool {
+	for _, d := range config.Flatten(s.GetBlock()) {
+		if modifier, match := location(d); d.GetName() == "location" && (modifier == "" || modifier == "=") && match == "/" {
+			if redirectsToHTTPS(d) {
+				return true
+			}
+			continue
+		}
+		if d.GetName() != "return" && d.GetName() != "rewrite" {
+			continue
+		}
+		for _, param := range d.GetParameters() {
+			if strings.HasPrefix(strings.Trim(param.GetValue(), `"'`), "https://") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func autoindex(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		if c.Directive.GetName() == "autoindex" && lastParam(c.Directive) == "on" {
+			p.ReportAt(c, "autoindex on lists the files of the directories", "set autoindex off, or restrict the location with allow and deny")
+		}
+		return nil
+	})
+}
+
+func allowAllSensitive(p *Pass) {
+	_ = config.Inspect(p.Config, func(c *config.Cursor) error {
+		parent := c.Parent()
+		if c.Directive.GetName() != "allow" || lastParam(c.Directive) != "all" || parent == nil || parent.GetName() != "location" {
+			return nil
+		}
+		_, match := location(parent)
+		for _, part := range SensitiveLocations {
+			if strings.Contains(strings.ToLower(match), part) {
+				p.ReportAt(c, fmt.Sprintf("allow all in the sensitive location %s", match),
+					"allow only trusted addresses, then deny all, or protect it with auth_basic")
+				return nil
+			}
+		}
+		return nil
+	})
+}
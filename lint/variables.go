@@ -0,0 +1,359 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+func init() {
+	Register(NewRule("undefined-variable", "variables that are neither built-in nor defined", Error, undefinedVariable))
+	Register(NewRule("unused-variable", "variables defined but never used", Warning, unusedVariable))
+	Register(NewRule("shadowed-variable", "variables defined over a built-in variable or another definition", Warning, shadowedVariable))
+}
+
+// builtinVariables are the variables of the nginx modules, the changeable ones are true
+var builtinVariables = map[string]bool{
+	// core
+	"args": true, "binary_remote_addr": false, "body_bytes_sent": false, "bytes_sent": false, "connection": false,
+	"connection_requests": false, "connection_time": false, "content_length": false, "content_type": false,
+	"document_root": false, "document_uri": false, "host": false, "hostname": false, "https": false, "is_args": false,
+	"limit_rate": true, "msec": false, "nginx_version": false, "pid": false, "pipe": false, "proxy_protocol_addr": false,
+	"proxy_protocol_port": false, "proxy_protocol_server_addr": false, "proxy_protocol_server_port": false,
+	"query_string": false, "realpath_root": false, "remote_addr": false, "remote_port": false, "remote_user": false,
+	"request": false, "request_body": false, "request_body_file": false, "request_completion": false,
+	"request_filename": false, "request_id": false, "request_length": false, "request_method": false,
+	"request_time": false, "request_uri": false, "scheme": false, "server_addr": false, "server_name": false,
+	"server_port": false, "server_protocol": false, "status": false, "tcpinfo_rtt": false, "tcpinfo_rttvar": false,
+	"tcpinfo_snd_cwnd": false, "tcpinfo_rcv_space": false, "time_iso8601": false, "time_local": false, "uri": false,
+	"http2": false, "http3": false, "bytes_received": false, "protocol": false, "session_time": false,
+	// browser, auth_jwt, session_log, mqtt_preread, otel
+	"ancient_browser": false, "modern_browser": false, "msie": false, "jwt_payload": false, "session_log_id": false,
+	"session_log_binary_id": false, "mqtt_preread_clientid": false, "mqtt_preread_username": false,
+	"otel_trace_id": false, "otel_span_id": false, "otel_parent_id": false, "otel_parent_sampled": false,
+	// proxy, fastcgi, memcached, realip, gzip, limits, referer, secure_link, ssi, userid, slice, stub_status
+	"proxy_host": false, "proxy_port": false, "proxy_add_x_forwarded_for": false, "fastcgi_script_name": false,
+	"fastcgi_path_info": false, "memcached_key": true, "realip_remote_addr": false, "realip_remote_port": false,
+	"gzip_ratio": false, "limit_conn_status": false, "limit_req_status": false, "invalid_referer": false,
+	"secure_link": false, "secure_link_expires": false, "date_local": false, "date_gmt": false, "uid_got": false,
+	"uid_reset": true, "uid_set": false, "slice_range": false, "connections_active": false,
+	"connections_reading": false, "connections_writing": false, "connections_waiting": false,
+	// ssl
+	"ssl_alpn_protocol": false, "ssl_cipher": false, "ssl_ciphers": false, "ssl_client_cert": false,
+	"ssl_client_escaped_cert": false, "ssl_client_fingerprint": false, "ssl_client_i_dn": false,
+	"ssl_client_i_dn_legacy": false, "ssl_client_raw_cert": false, "ssl_client_s_dn": false,
+	"ssl_client_s_dn_legacy": false, "ssl_client_serial": false, "ssl_client_v_end": false,
+	"ssl_client_v_remain": false, "ssl_client_v_start": false, "ssl_client_verify": false, "ssl_curve": false,
+	"ssl_curves": false, "ssl_early_data": false, "ssl_protocol": false, "ssl_server_name": false,
+	"ssl_session_id": false, "ssl_session_reused": false, "ssl_preread_protocol": false,
+	"ssl_preread_server_name": false, "ssl_preread_alpn_protocols": false,
+	// upstream
+	"upstream_addr": false, "upstream_bytes_received": false, "upstream_bytes_sent": false,
+	"upstream_cache_status": false, "upstream_connect_time": false, "upstream_first_byte_time": false,
+	"upstream_header_time": false, "upstream_last_server_name": false, "upstream_queue_time": false,
+	"upstream_response_length": false, "upstream_response_time": false, "upstream_session_time": false,
+	"upstream_status": false,
+	// geoip
+	"geoip_country_code": false, "geoip_country_code3": false, "geoip_country_name": false, "geoip_area_code": false,
+	"geoip_city_continent_code": false, "geoip_city_country_code": false, "geoip_city_country_code3": false,
+	"geoip_city_country_name": false, "geoip_dma_code": false, "geoip_latitude": false, "geoip_longitude": false,
+	"geoip_region": false, "geoip_region_name": false, "geoip_city": false, "geoip_postal_code": false,
+	"geoip_org": false,
+}
+
+// builtinPrefixes are the prefixes of the variables of headers, arguments and cookies
+var builtinPrefixes = []string{
+	"arg_", "http_", "cookie_", "sent_http_", "sent_trailer_", "upstream_http_", "upstream_cookie_",
+	"upstream_trailer_", "proxy_protocol_tlv_", "jwt_claim_", "jwt_header_",
+}
+
+// IsBuiltinVariable reports whether the variable, without $, is built in nginx or registered
+// with RegisterVariables, like host or http_user_agent
+func IsBuiltinVariable(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := builtinVariables[name]; ok {
+		return true
+	}
+	for _, prefix := range builtinPrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+	return name != "" && name[0] >= '1' && name[0] <= '9' // regex captures
+}
+
+// RegisterVariables adds the variables of a third party module, a name ending with * is a prefix
+func RegisterVariables(names ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, name := range names {
+		name = strings.TrimPrefix(name, "$")
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			builtinPrefixes = append(builtinPrefixes, prefix)
+			continue
+		}
+		builtinVariables[name] = false
+	}
+}
+
+// VariableUse is a definition or a reference of a variable
+type VariableUse struct {
+	Name      string // without $
+	Directive config.IDirective
+	File      string
+	Line      int
+	Capture   bool // a named capture of a regular expression
+}
+
+// VariableTable is where the variables of a config are defined and referenced, in the
+// order nginx reads them
+type VariableTable struct {
+	Definitions []VariableUse
+	References  []VariableUse
+}
+
+// assignments are the directives that set a variable like set does, the parameters after the variable are values
+var assignments = map[string]bool{
+	"set": true, "auth_request_set": true, "js_var": true, "set_by_lua": true, "set_by_lua_file": true, "set_by_lua_block": true,
+}
+
+// definitions are the directives that define a variable for the whole config
+var definitions = map[string]bool{"map": true, "geo": true, "split_clients": true, "perl_set": true, "js_set": true}
+
+// luaVariable matches ngx.var.name and ngx.var["name"] in lua code
+var luaVariable = regexp.MustCompile(`ngx\.var\.([A-Za-z_][A-Za-z0-9_]*)|ngx\.var\[["']([A-Za-z_][A-Za-z0-9_]*)["']\]`)
+
+// Variables returns the variable table of the config and its included files. Regular
+// expressions are not references but define their named captures, and ngx.var in lua
+// code is a reference.
+func Variables(cfg *config.Config) *VariableTable {
+	t := &VariableTable{}
+	_ = config.Inspect(cfg, func(c *config.Cursor) error {
+		d := c.Directive
+		use := func(name string, capture bool) VariableUse {
+			return VariableUse{Name: name, Directive: d, File: c.File, Line: d.GetLine(), Capture: capture}
+		}
+		define := func(value string) {
+			if name, ok := strings.CutPrefix(value, "$"); ok && name != "" {
+				t.Definitions = append(t.Definitions, use(name, false))
+			}
+		}
+		refer := func(value string) {
+			for _, v := range config.ParseVariables(value) {
+				t.References = append(t.References, use(v.Name, false))
+			}
+		}
+		captures := func(regex string) {
+			for _, name := range config.NamedCaptures(regex) {
+				t.Definitions = append(t.Definitions, use(name, true))
+			}
+		}
+
+		if b := d.GetBlock(); b != nil && b.GetCodeBlock() != "" {
+			for _, m := range luaVariable.FindAllStringSubmatch(b.GetCodeBlock(), -1) {
+				t.References = append(t.References, use(m[1]+m[2], false))
+			}
+		}
+		switch c.Parent().(type) {
+		case *config.Map:
+			if strings.HasPrefix(d.GetName(), "~") {
+				captures(d.GetName())
+			}
+			for _, p := range d.GetParameters() {
+				refer(p.GetValue())
+			}
+			return nil
+		case *config.Geo, *config.SplitClients:
+			return nil // the values of geo and split_clients are strings
+		}
+
+		params := config.Values(d.GetParameters())
+		switch name := d.GetName(); {
+		case len(params) == 0:
+		case assignments[name]:
+			define(params[0])
+			for _, p := range params[1:] {
+				refer(p)
+			}
+		case name == "perl_set" || name == "js_set":
+			define(params[0])
+		case definitions[name]:
+			// map, split_clients and geo: an optional source and the variable
+			define(params[len(params)-1])
+			for _, p := range params[:len(params)-1] {
+				refer(p)
+			}
+		case name == "location":
+			if modifier, match := location(d); modifier == "~" || modifier == "~*" {
+				captures(match)
+			}
+		case name == "rewrite":
+			captures(params[0])
+			for _, p := range params[1:] {
+				refer(p)
+			}
+		case name == "if":
+			for i, p := range params {
+				if i > 0 && (params[i-1] == "~" || params[i-1] == "~*") {
+					captures(p)
+					continue
+				}
+				if i > 0 && (params[i-1] == "!~" || params[i-1] == "!~*") {
+					continue
+				}
+				refer(p)
+			}
+		default:
+			for _, p := range params {
+				if strings.HasPrefix(p, "~") || strings.HasPrefix(p, `"~`) {
+					captures(p) // a regular expression, like in server_name or proxy_redirect
+					continue
+				}
+				refer(p)
+			}
+		}
+		return nil
+	})
+	return t
+}
+
+// defined returns the definitions by name
+func (t *VariableTable) defined() map[string][]VariableUse {
+	defined := map[string][]VariableUse{}
+	for _, d := range t.Definitions {
+		defined[d.Name] = append(defined[d.Name], d)
+	}
+	return defined
+}
+
+func undefinedVariable(p *Pass) {
+	t := p.Variables()
+	defined := t.defined()
+	for _, ref := range t.References {
+		if _, ok := defined[ref.Name]; ok || IsBuiltinVariable(ref.Name) {
+			continue
+		}
+		f := Finding{Message: fmt.Sprintf("unknown variable $%s", ref.Name), File: ref.File, Directive: ref.Directive}
+		if suggestion, builtin := closestVariable(ref.Name, defined); suggestion != "" {
+			f.Fix = fmt.Sprintf("did you mean $%s?", suggestion)
+			if builtin {
+				f.Severity = Warning // a misspelling, or a variable of a module missing in the table
+			}
+		}
+		p.Report(f)
+	}
+}
+
+// closestVariable returns the defined or built-in variable the closest to a misspelled name, empty if none is close,
+// and whether it is a built-in one
+func closestVariable(name string, defined map[string][]VariableUse) (string, bool) {
+	candidates := make([]string, 0, len(defined)+len(builtinVariables))
+	for n := range defined {
+		candidates = append(candidates, n)
+	}
+	mu.RLock()
+	for n := range builtinVariables {
+		candidates = append(candidates, n)
+	}
+	mu.RUnlock()
+	sort.Strings(candidates)
+
+	limit := 2 // letters to add, remove or change
+	if len(name) < 4 {
+		limit = 1
+	}
+	best, bestDistance := "", limit+1
+	for _, candidate := range candidates {
+		if d := distance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	_, defines := defined[best]
+	return best, best != "" && !defines
+}
+
+// distance is the Levenshtein distance between two names
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func unusedVariable(p *Pass) {
+	t := p.Variables()
+	used := map[string]bool{}
+	for _, ref := range t.References {
+		used[ref.Name] = true
+	}
+	reported := map[string]bool{}
+	for _, def := range t.Definitions {
+		if def.Capture || used[def.Name] || reported[def.Name] || IsBuiltinVariable(def.Name) {
+			continue
+		}
+		reported[def.Name] = true
+		p.Report(Finding{
+			Message:   fmt.Sprintf("variable $%s is defined by %s but never used", def.Name, def.Directive.GetName()),
+			Fix:       "remove it, or check the spelling of the variables that should use it",
+			File:      def.File,
+			Directive: def.Directive,
+		})
+	}
+}
+
+func shadowedVariable(p *Pass) {
+	t := p.Variables()
+	first := map[string]VariableUse{}
+	isAssignment := func(d VariableUse) bool { return assignments[d.Directive.GetName()] }
+	for _, def := range t.Definitions {
+		mu.RLock()
+		changeable, builtin := builtinVariables[def.Name]
+		mu.RUnlock()
+		if builtin || IsBuiltinVariable(def.Name) {
+			if !changeable && !def.Capture {
+				p.Report(Finding{
+					Message:   fmt.Sprintf("%s defines $%s over the built-in variable", def.Directive.GetName(), def.Name),
+					Fix:       "rename the variable",
+					File:      def.File,
+					Directive: def.Directive,
+				})
+			}
+			continue
+		}
+		prev, ok := first[def.Name]
+		if !ok {
+			first[def.Name] = def
+			continue
+		}
+		if (isAssignment(prev) && isAssignment(def)) || (prev.Capture && def.Capture) {
+			continue // set again, or captured by several expressions
+		}
+		kind := def.Directive.GetName()
+		if def.Capture {
+			kind = "a named capture in " + kind
+		}
+		p.Report(Finding{
+			Message:   fmt.Sprintf("%s redefines $%s, it is already defined at %s:%d", kind, def.Name, prev.File, prev.Line),
+			Fix:       "rename one of the variables",
+			File:      def.File,
+			Directive: def.Directive,
+		})
+	}
+}
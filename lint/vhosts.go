@@ -0,0 +1,133 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// VirtualHost is a server name a server answers to on an address
+type VirtualHost struct {
+	Address string // like *:443, 127.0.0.1:80, [::]:80 or unix:/run/nginx.sock
+	Name    string // in lower case, empty for a server without server_name
+	Default bool   // the listen has default_server
+	Server  *config.Server
+	Listen  config.IDirective // nil for a server without listen, it listens on *:80
+	File    string            // the file of the server, empty when parsed from a string
+	Line    int               // the line of the listen, or of the server without listen
+}
+
+// VirtualHosts returns the virtual hosts of the servers of the http context, in the order
+// nginx reads them, included files in place of their include directive
+func VirtualHosts(cfg *config.Config) []VirtualHost {
+	var hosts []VirtualHost
+	_ = config.Inspect(cfg, func(c *config.Cursor) error {
+		s, ok := c.Directive.(*config.Server)
+		if !ok || !inHTTP(c) {
+			return nil
+		}
+		var listens, names []config.IDirective
+		for _, d := range config.Flatten(s.GetBlock()) {
+			switch d.GetName() {
+			case "listen":
+				listens = append(listens, d)
+			case "server_name":
+				names = append(names, d)
+			}
+		}
+		serverNames := serverNames(names)
+		add := func(address string, isDefault bool, listen config.IDirective, line int) {
+			for _, name := range serverNames {
+				hosts = append(hosts, VirtualHost{Address: address, Name: name, Default: isDefault, Server: s, Listen: listen, File: c.File, Line: line})
+			}
+		}
+		if len(listens) == 0 {
+			add("*:80", false, nil, s.GetLine())
+		}
+		for _, l := range listens {
+			params := l.GetParameters()
+			if len(params) == 0 {
+				continue
+			}
+			isDefault := false
+			for _, param := range params[1:] {
+				if v := param.GetValue(); v == "default_server" || v == "default" {
+					isDefault = true
+				}
+			}
+			add(normalizeAddress(params[0].GetValue()), isDefault, l, l.GetLine())
+		}
+		return config.SkipChildren
+	})
+	return hosts
+}
+
+// serverNames returns the names of the server_name directives, "" when there are none
+func serverNames(directives []config.IDirective) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, d := range directives {
+		for _, p := range d.GetParameters() {
+			name := strings.ToLower(strings.Trim(p.GetValue(), `"'`))
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, "")
+	}
+	return names
+}
+
+func conflictingServerName(p *Pass) {
+	type key struct{ address, name string }
+	first := map[key]VirtualHost{}
+	for _, host := range VirtualHosts(p.Config) {
+		k := key{host.Address, host.Name}
+		prev, ok := first[k]
+		if !ok {
+			first[k] = host
+			continue
+		}
+		if prev.Server == host.Server {
+			continue
+		}
+		p.Report(Finding{
+			Message: fmt.Sprintf("conflicting server name %q on %s, the server at %s:%d already has it, this one is ignored",
+				host.Name, host.Address, prev.File, prev.Line),
+			Fix:       "remove the server name from one of the servers, or listen on another address",
+			File:      host.File,
+			Line:      host.Line,
+			Directive: host.Listen,
+		})
+	}
+}
+
+func duplicateDefaultServer(p *Pass) {
+	first := map[string]VirtualHost{}
+	reported := map[config.IDirective]bool{} // a listen has a virtual host for each server name
+	for _, host := range VirtualHosts(p.Config) {
+		if !host.Default {
+			continue
+		}
+		prev, ok := first[host.Address]
+		if !ok {
+			first[host.Address] = host
+			continue
+		}
+		if prev.Listen == host.Listen || reported[host.Listen] {
+			continue
+		}
+		reported[host.Listen] = true
+		p.Report(Finding{
+			Message:   fmt.Sprintf("duplicate default server for %s, it is already set at %s:%d", host.Address, prev.File, prev.Line),
+			Fix:       "remove default_server from one of the listen directives",
+			File:      host.File,
+			Line:      host.Line,
+			Directive: host.Listen,
+		})
+	}
+}
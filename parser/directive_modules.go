@@ -0,0 +1,1125 @@
+package parser
+
+// directiveModule groups the directives of an nginx module
+type directiveModule struct {
+	name       string
+	directives []DirectiveSpec
+}
+
+// got the contexts and arguments from the module docs at https://nginx.org/en/docs/
+// and https://github.com/openresty/lua-nginx-module
+var directiveModules = []directiveModule{
+	{"ngx_core_module", []DirectiveSpec{
+		{Name: "daemon", Contexts: MainContext, Args: Flag, Syntax: "daemon on | off;", Default: "on"},
+		{Name: "debug_points", Contexts: MainContext, Args: Take1, Syntax: "debug_points abort | stop;"},
+		{Name: "env", Contexts: MainContext, Args: Take1, Syntax: "env variable[=value];", Default: "TZ"},
+		{Name: "error_log", Contexts: MainContext, Args: OneMore, Syntax: "error_log file [level];", Default: "logs/error.log error"},
+		{Name: "events", Contexts: MainContext, Args: Block | NoArgs, Syntax: "events { ... }"},
+		{Name: "google_perftools_profiles", Contexts: MainContext, Args: Take1, Syntax: "google_perftools_profiles file;"},
+		{Name: "include", Contexts: AnyContext, Args: Take1, Syntax: "include file | mask;"},
+		{Name: "load_module", Contexts: MainContext, Args: Take1, Syntax: "load_module file;", Since: "1.9.11"},
+		{Name: "lock_file", Contexts: MainContext, Args: Take1, Syntax: "lock_file file;", Default: "logs/nginx.lock"},
+		{Name: "master_process", Contexts: MainContext, Args: Flag, Syntax: "master_process on | off;", Default: "on"},
+		{Name: "pcre_jit", Contexts: MainContext, Args: Flag, Syntax: "pcre_jit on | off;", Default: "off", Since: "1.1.12"},
+		{Name: "pid", Contexts: MainContext, Args: Take1, Syntax: "pid file;", Default: "logs/nginx.pid"},
+		{Name: "ssl_engine", Contexts: MainContext, Args: Take1, Syntax: "ssl_engine device;"},
+		{Name: "thread_pool", Contexts: MainContext, Args: Take23, Syntax: "thread_pool name threads=number [max_queue=number];", Default: "default threads=32 max_queue=65536", Since: "1.7.11"},
+		{Name: "timer_resolution", Contexts: MainContext, Args: Take1, Syntax: "timer_resolution interval;"},
+		{Name: "user", Contexts: MainContext, Args: Take12, Syntax: "user user [group];", Default: "nobody nobody"},
+		{Name: "worker_cpu_affinity", Contexts: MainContext, Args: OneMore, Syntax: "worker_cpu_affinity cpumask ... | auto [cpumask];"},
+		{Name: "worker_priority", Contexts: MainContext, Args: Take1, Syntax: "worker_priority number;", Default: "0"},
+		{Name: "worker_processes", Contexts: MainContext, Args: Take1, Syntax: "worker_processes number | auto;", Default: "1"},
+		{Name: "worker_rlimit_core", Contexts: MainContext, Args: Take1, Syntax: "worker_rlimit_core size;"},
+		{Name: "worker_rlimit_nofile", Contexts: MainContext, Args: Take1, Syntax: "worker_rlimit_nofile number;"},
+		{Name: "worker_shutdown_timeout", Contexts: MainContext, Args: Take1, Syntax: "worker_shutdown_timeout time;", Since: "1.11.11"},
+		{Name: "working_directory", Contexts: MainContext, Args: Take1, Syntax: "working_directory directory;"},
+	}},
+	{"ngx_event_core_module", []DirectiveSpec{
+		{Name: "accept_mutex", Contexts: EventsContext, Args: Flag, Syntax: "accept_mutex on | off;", Default: "off"},
+		{Name: "accept_mutex_delay", Contexts: EventsContext, Args: Take1, Syntax: "accept_mutex_delay time;", Default: "500ms"},
+		{Name: "debug_connection", Contexts: EventsContext, Args: Take1, Syntax: "debug_connection address | CIDR | unix:;"},
+		{Name: "multi_accept", Contexts: EventsContext, Args: Flag, Syntax: "multi_accept on | off;", Default: "off"},
+		{Name: "use", Contexts: EventsContext, Args: Take1, Syntax: "use method;"},
+		{Name: "worker_aio_requests", Contexts: EventsContext, Args: Take1, Syntax: "worker_aio_requests number;", Default: "32", Since: "1.1.4"},
+		{Name: "worker_connections", Contexts: EventsContext, Args: Take1, Syntax: "worker_connections number;", Default: "512"},
+	}},
+	{"ngx_mgmt_module", []DirectiveSpec{
+		{Name: "mgmt", Contexts: MainContext, Args: Block | NoArgs, Syntax: "mgmt { ... }", Since: "1.27.2"},
+		{Name: "connect_timeout", Contexts: MgmtContext, Args: Take1, Syntax: "connect_timeout time;", Default: "15s"},
+		{Name: "read_timeout", Contexts: MgmtContext, Args: Take1, Syntax: "read_timeout time;", Default: "60s"},
+		{Name: "resolver", Contexts: MgmtContext, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone];"},
+		{Name: "resolver_timeout", Contexts: MgmtContext, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s"},
+		{Name: "send_timeout", Contexts: MgmtContext, Args: Take1, Syntax: "send_timeout time;", Default: "60s"},
+		{Name: "ssl_crl", Contexts: MgmtContext, Args: Take1, Syntax: "ssl_crl file;"},
+		{Name: "ssl_name", Contexts: MgmtContext, Args: Take1, Syntax: "ssl_name name;"},
+		{Name: "ssl_server_name", Contexts: MgmtContext, Args: Flag, Syntax: "ssl_server_name on | off;", Default: "off"},
+		{Name: "ssl_trusted_certificate", Contexts: MgmtContext, Args: Take1, Syntax: "ssl_trusted_certificate file;"},
+		{Name: "ssl_verify", Contexts: MgmtContext, Args: Flag, Syntax: "ssl_verify on | off;", Default: "on"},
+		{Name: "ssl_verify_depth", Contexts: MgmtContext, Args: Take1, Syntax: "ssl_verify_depth number;", Default: "1"},
+		{Name: "usage_report", Contexts: MgmtContext, Args: NoArgs | Take12, Syntax: "usage_report [endpoint=address] [interval=time];"},
+		{Name: "uuid_file", Contexts: MgmtContext, Args: Take1, Syntax: "uuid_file file;", Default: "logs/uuid"},
+	}},
+	{"ngx_http_core_module", []DirectiveSpec{
+		{Name: "absolute_redirect", Contexts: httpContexts, Args: Flag, Syntax: "absolute_redirect on | off;", Default: "on", Since: "1.11.8"},
+		{Name: "aio", Contexts: httpContexts, Args: Take1, Syntax: "aio on | off | threads[=pool];", Default: "off"},
+		{Name: "aio_write", Contexts: httpContexts, Args: Flag, Syntax: "aio_write on | off;", Default: "off", Since: "1.9.13"},
+		{Name: "alias", Contexts: LocationContext, Args: Take1, Syntax: "alias path;"},
+		{Name: "auth_delay", Contexts: httpContexts, Args: Take1, Syntax: "auth_delay time;", Default: "0s", Since: "1.17.10"},
+		{Name: "chunked_transfer_encoding", Contexts: httpContexts, Args: Flag, Syntax: "chunked_transfer_encoding on | off;", Default: "on"},
+		{Name: "client_body_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "client_body_buffer_size size;", Default: "8k|16k"},
+		{Name: "client_body_in_file_only", Contexts: httpContexts, Args: Take1, Syntax: "client_body_in_file_only on | clean | off;", Default: "off"},
+		{Name: "client_body_in_single_buffer", Contexts: httpContexts, Args: Flag, Syntax: "client_body_in_single_buffer on | off;", Default: "off"},
+		{Name: "client_body_temp_path", Contexts: httpContexts, Args: Take1234, Syntax: "client_body_temp_path path [level1 [level2 [level3]]];", Default: "client_body_temp"},
+		{Name: "client_body_timeout", Contexts: httpContexts, Args: Take1, Syntax: "client_body_timeout time;", Default: "60s"},
+		{Name: "client_header_buffer_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "client_header_buffer_size size;", Default: "1k"},
+		{Name: "client_header_timeout", Contexts: httpSrvContexts, Args: Take1, Syntax: "client_header_timeout time;", Default: "60s"},
+		{Name: "client_max_body_size", Contexts: httpContexts, Args: Take1, Syntax: "client_max_body_size size;", Default: "1m"},
+		{Name: "connection_pool_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "connection_pool_size size;", Default: "256|512"},
+		{Name: "default_type", Contexts: httpContexts, Args: Take1, Syntax: "default_type mime-type;", Default: "text/plain"},
+		{Name: "directio", Contexts: httpContexts, Args: Take1, Syntax: "directio size | off;", Default: "off", Since: "0.7.7"},
+		{Name: "directio_alignment", Contexts: httpContexts, Args: Take1, Syntax: "directio_alignment size;", Default: "512", Since: "0.8.11"},
+		{Name: "disable_symlinks", Contexts: httpContexts, Args: Take12, Syntax: "disable_symlinks off | on | if_not_owner [from=part];", Default: "off", Since: "1.1.15"},
+		{Name: "error_log", Contexts: httpContexts, Args: OneMore, Syntax: "error_log file [level];", Default: "logs/error.log error"},
+		{Name: "error_page", Contexts: httpIfContexts, Args: TwoMore, Syntax: "error_page code ... [=[response]] uri;"},
+		{Name: "etag", Contexts: httpContexts, Args: Flag, Syntax: "etag on | off;", Default: "on", Since: "1.3.3"},
+		{Name: "http", Contexts: MainContext, Args: Block | NoArgs, Syntax: "http { ... }"},
+		{Name: "if_modified_since", Contexts: httpContexts, Args: Take1, Syntax: "if_modified_since off | exact | before;", Default: "exact", Since: "0.7.24"},
+		{Name: "ignore_invalid_headers", Contexts: httpSrvContexts, Args: Flag, Syntax: "ignore_invalid_headers on | off;", Default: "on"},
+		{Name: "internal", Contexts: LocationContext, Args: NoArgs, Syntax: "internal;"},
+		{Name: "keepalive_disable", Contexts: httpContexts, Args: Take12, Syntax: "keepalive_disable none | browser ...;", Default: "msie6"},
+		{Name: "keepalive_requests", Contexts: httpContexts, Args: Take1, Syntax: "keepalive_requests number;", Default: "1000", Since: "0.8.0"},
+		{Name: "keepalive_time", Contexts: httpContexts, Args: Take1, Syntax: "keepalive_time time;", Default: "1h", Since: "1.19.10"},
+		{Name: "keepalive_timeout", Contexts: httpContexts, Args: Take12, Syntax: "keepalive_timeout timeout [header_timeout];", Default: "75s"},
+		{Name: "large_client_header_buffers", Contexts: httpSrvContexts, Args: Take2, Syntax: "large_client_header_buffers number size;", Default: "4 8k"},
+		{Name: "limit_except", Contexts: LocationContext, Args: Block | OneMore, Syntax: "limit_except method ... { ... }"},
+		{Name: "limit_rate", Contexts: httpIfContexts, Args: Take1, Syntax: "limit_rate rate;", Default: "0"},
+		{Name: "limit_rate_after", Contexts: httpIfContexts, Args: Take1, Syntax: "limit_rate_after size;", Default: "0", Since: "0.8.0"},
+		{Name: "lingering_close", Contexts: httpContexts, Args: Take1, Syntax: "lingering_close off | on | always;", Default: "on", Since: "1.1.0"},
+		{Name: "lingering_time", Contexts: httpContexts, Args: Take1, Syntax: "lingering_time time;", Default: "30s"},
+		{Name: "lingering_timeout", Contexts: httpContexts, Args: Take1, Syntax: "lingering_timeout time;", Default: "5s"},
+		{Name: "listen", Contexts: ServerContext, Args: OneMore, Syntax: "listen address[:port] | port | unix:path [default_server] [ssl] [http2 | quic] [proxy_protocol] [backlog=number] [rcvbuf=size] [sndbuf=size] [deferred] [bind] [ipv6only=on|off] [reuseport] [so_keepalive=on|off|[keepidle]:[keepintvl]:[keepcnt]];", Default: "*:80 | *:8000"},
+		{Name: "location", Contexts: ServerContext | LocationContext, Args: Block | Take12, Syntax: "location [ = | ~ | ~* | ^~ ] uri { ... } | @name { ... }"},
+		{Name: "log_not_found", Contexts: httpContexts, Args: Flag, Syntax: "log_not_found on | off;", Default: "on"},
+		{Name: "log_subrequest", Contexts: httpContexts, Args: Flag, Syntax: "log_subrequest on | off;", Default: "off"},
+		{Name: "max_ranges", Contexts: httpContexts, Args: Take1, Syntax: "max_ranges number;", Since: "1.1.2"},
+		{Name: "merge_slashes", Contexts: httpSrvContexts, Args: Flag, Syntax: "merge_slashes on | off;", Default: "on"},
+		{Name: "msie_padding", Contexts: httpContexts, Args: Flag, Syntax: "msie_padding on | off;", Default: "on"},
+		{Name: "msie_refresh", Contexts: httpContexts, Args: Flag, Syntax: "msie_refresh on | off;", Default: "off"},
+		{Name: "open_file_cache", Contexts: httpContexts, Args: Take12, Syntax: "open_file_cache off | max=N [inactive=time];", Default: "off"},
+		{Name: "open_file_cache_errors", Contexts: httpContexts, Args: Flag, Syntax: "open_file_cache_errors on | off;", Default: "off"},
+		{Name: "open_file_cache_min_uses", Contexts: httpContexts, Args: Take1, Syntax: "open_file_cache_min_uses number;", Default: "1"},
+		{Name: "open_file_cache_valid", Contexts: httpContexts, Args: Take1, Syntax: "open_file_cache_valid time;", Default: "60s"},
+		{Name: "output_buffers", Contexts: httpContexts, Args: Take2, Syntax: "output_buffers number size;", Default: "2 32k"},
+		{Name: "port_in_redirect", Contexts: httpContexts, Args: Flag, Syntax: "port_in_redirect on | off;", Default: "on"},
+		{Name: "postpone_output", Contexts: httpContexts, Args: Take1, Syntax: "postpone_output size;", Default: "1460"},
+		{Name: "read_ahead", Contexts: httpContexts, Args: Take1, Syntax: "read_ahead size;", Default: "0"},
+		{Name: "recursive_error_pages", Contexts: httpContexts, Args: Flag, Syntax: "recursive_error_pages on | off;", Default: "off"},
+		{Name: "request_pool_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "request_pool_size size;", Default: "4k"},
+		{Name: "reset_timedout_connection", Contexts: httpContexts, Args: Flag, Syntax: "reset_timedout_connection on | off;", Default: "off"},
+		{Name: "resolver", Contexts: httpContexts, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone];"},
+		{Name: "resolver_timeout", Contexts: httpContexts, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s"},
+		{Name: "root", Contexts: httpIfContexts, Args: Take1, Syntax: "root path;", Default: "html"},
+		{Name: "satisfy", Contexts: httpContexts, Args: Take1, Syntax: "satisfy all | any;", Default: "all"},
+		{Name: "send_lowat", Contexts: httpContexts, Args: Take1, Syntax: "send_lowat size;", Default: "0"},
+		{Name: "send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "send_timeout time;", Default: "60s"},
+		{Name: "sendfile", Contexts: httpIfContexts, Args: Flag, Syntax: "sendfile on | off;", Default: "off"},
+		{Name: "sendfile_max_chunk", Contexts: httpContexts, Args: Take1, Syntax: "sendfile_max_chunk size;", Default: "2m"},
+		{Name: "server", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "server { ... }"},
+		{Name: "server_name", Contexts: ServerContext, Args: OneMore, Syntax: "server_name name ...;", Default: `""`},
+		{Name: "server_name_in_redirect", Contexts: httpContexts, Args: Flag, Syntax: "server_name_in_redirect on | off;", Default: "off"},
+		{Name: "server_names_hash_bucket_size", Contexts: HTTPContext, Args: Take1, Syntax: "server_names_hash_bucket_size size;", Default: "32|64|128"},
+		{Name: "server_names_hash_max_size", Contexts: HTTPContext, Args: Take1, Syntax: "server_names_hash_max_size size;", Default: "512"},
+		{Name: "server_tokens", Contexts: httpContexts, Args: Take1, Syntax: "server_tokens on | off | build | string;", Default: "on"},
+		{Name: "subrequest_output_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "subrequest_output_buffer_size size;", Default: "4k|8k", Since: "1.13.10"},
+		{Name: "tcp_nodelay", Contexts: httpContexts, Args: Flag, Syntax: "tcp_nodelay on | off;", Default: "on"},
+		{Name: "tcp_nopush", Contexts: httpContexts, Args: Flag, Syntax: "tcp_nopush on | off;", Default: "off"},
+		{Name: "try_files", Contexts: ServerContext | LocationContext, Args: TwoMore, Syntax: "try_files file ... uri | file ... =code;"},
+		{Name: "types", Contexts: httpContexts, Args: Block | NoArgs, Syntax: "types { ... }", Default: "text/html html; image/gif gif; image/jpeg jpg;"},
+		{Name: "types_hash_bucket_size", Contexts: httpContexts, Args: Take1, Syntax: "types_hash_bucket_size size;", Default: "64"},
+		{Name: "types_hash_max_size", Contexts: httpContexts, Args: Take1, Syntax: "types_hash_max_size size;", Default: "1024"},
+		{Name: "underscores_in_headers", Contexts: httpSrvContexts, Args: Flag, Syntax: "underscores_in_headers on | off;", Default: "off"},
+		{Name: "variables_hash_bucket_size", Contexts: HTTPContext, Args: Take1, Syntax: "variables_hash_bucket_size size;", Default: "64"},
+		{Name: "variables_hash_max_size", Contexts: HTTPContext, Args: Take1, Syntax: "variables_hash_max_size size;", Default: "1024"},
+	}},
+	{"ngx_http_access_module", []DirectiveSpec{
+		{Name: "allow", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "allow address | CIDR | unix: | all;"},
+		{Name: "deny", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "deny address | CIDR | unix: | all;"},
+	}},
+	{"ngx_http_addition_module", []DirectiveSpec{
+		{Name: "add_after_body", Contexts: httpContexts, Args: Take1, Syntax: "add_after_body uri;"},
+		{Name: "add_before_body", Contexts: httpContexts, Args: Take1, Syntax: "add_before_body uri;"},
+		{Name: "addition_types", Contexts: httpContexts, Args: OneMore, Syntax: "addition_types mime-type ...;", Default: "text/html", Since: "0.7.9"},
+	}},
+	{"ngx_http_api_module", []DirectiveSpec{
+		{Name: "api", Contexts: LocationContext, Args: NoArgs | Take1, Syntax: "api [write=on|off];", Since: "1.13.3"},
+		{Name: "status_zone", Contexts: ServerContext | LocationContext | IfInLocationContext, Args: Take1, Syntax: "status_zone zone;", Since: "1.13.12"},
+	}},
+	{"ngx_http_auth_basic_module", []DirectiveSpec{
+		{Name: "auth_basic", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "auth_basic string | off;", Default: "off"},
+		{Name: "auth_basic_user_file", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "auth_basic_user_file file;"},
+	}},
+	{"ngx_http_auth_jwt_module", []DirectiveSpec{
+		{Name: "auth_jwt", Contexts: httpContexts | LimitExceptContext, Args: Take12, Syntax: "auth_jwt string [token=$variable] | off;", Default: "off", Since: "1.11.3"},
+		{Name: "auth_jwt_claim_set", Contexts: HTTPContext, Args: TwoMore, Syntax: "auth_jwt_claim_set $variable name ...;", Since: "1.11.10"},
+		{Name: "auth_jwt_header_set", Contexts: HTTPContext, Args: TwoMore, Syntax: "auth_jwt_header_set $variable name ...;", Since: "1.11.10"},
+		{Name: "auth_jwt_key_cache", Contexts: httpContexts, Args: Take1, Syntax: "auth_jwt_key_cache time;", Default: "0", Since: "1.21.4"},
+		{Name: "auth_jwt_key_file", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "auth_jwt_key_file file;", Since: "1.11.3"},
+		{Name: "auth_jwt_key_request", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "auth_jwt_key_request uri;", Since: "1.15.6"},
+		{Name: "auth_jwt_leeway", Contexts: httpContexts, Args: Take1, Syntax: "auth_jwt_leeway time;", Default: "0s", Since: "1.13.10"},
+		{Name: "auth_jwt_require", Contexts: httpContexts | LimitExceptContext, Args: OneMore, Syntax: "auth_jwt_require $value ... [error=401 | 403];", Since: "1.21.2"},
+		{Name: "auth_jwt_type", Contexts: httpContexts | LimitExceptContext, Args: Take1, Syntax: "auth_jwt_type signed | encrypted | nested;", Default: "signed", Since: "1.19.7"},
+	}},
+	{"ngx_http_auth_request_module", []DirectiveSpec{
+		{Name: "auth_request", Contexts: httpContexts, Args: Take1, Syntax: "auth_request uri | off;", Default: "off", Since: "1.5.4"},
+		{Name: "auth_request_set", Contexts: httpContexts, Args: Take2, Syntax: "auth_request_set $variable value;", Since: "1.5.4"},
+	}},
+	{"ngx_http_autoindex_module", []DirectiveSpec{
+		{Name: "autoindex", Contexts: httpContexts, Args: Flag, Syntax: "autoindex on | off;", Default: "off"},
+		{Name: "autoindex_exact_size", Contexts: httpContexts, Args: Flag, Syntax: "autoindex_exact_size on | off;", Default: "on"},
+		{Name: "autoindex_format", Contexts: httpContexts, Args: Take1, Syntax: "autoindex_format html | xml | json | jsonp;", Default: "html", Since: "1.7.9"},
+		{Name: "autoindex_localtime", Contexts: httpContexts, Args: Flag, Syntax: "autoindex_localtime on | off;", Default: "off"},
+	}},
+	{"ngx_http_browser_module", []DirectiveSpec{
+		{Name: "ancient_browser", Contexts: httpContexts, Args: OneMore, Syntax: "ancient_browser string ...;"},
+		{Name: "ancient_browser_value", Contexts: httpContexts, Args: Take1, Syntax: "ancient_browser_value string;", Default: "1"},
+		{Name: "modern_browser", Contexts: httpContexts, Args: Take12, Syntax: "modern_browser browser version | unlisted;"},
+		{Name: "modern_browser_value", Contexts: httpContexts, Args: Take1, Syntax: "modern_browser_value string;", Default: "1"},
+	}},
+	{"ngx_http_charset_module", []DirectiveSpec{
+		{Name: "charset", Contexts: httpIfContexts, Args: Take1, Syntax: "charset charset | off;", Default: "off"},
+		{Name: "charset_map", Contexts: HTTPContext, Args: Block | Take2, Syntax: "charset_map charset1 charset2 { ... }"},
+		{Name: "charset_types", Contexts: httpContexts, Args: OneMore, Syntax: "charset_types mime-type ...;", Default: "text/html text/xml text/plain text/vnd.wap.wml application/javascript application/rss+xml", Since: "0.7.9"},
+		{Name: "override_charset", Contexts: httpIfContexts, Args: Flag, Syntax: "override_charset on | off;", Default: "off"},
+		{Name: "source_charset", Contexts: httpIfContexts, Args: Take1, Syntax: "source_charset charset;"},
+	}},
+	{"ngx_http_dav_module", []DirectiveSpec{
+		{Name: "create_full_put_path", Contexts: httpContexts, Args: Flag, Syntax: "create_full_put_path on | off;", Default: "off"},
+		{Name: "dav_access", Contexts: httpContexts, Args: Take123, Syntax: "dav_access users:permissions ...;", Default: "user:rw"},
+		{Name: "dav_methods", Contexts: httpContexts, Args: OneMore, Syntax: "dav_methods off | method ...;", Default: "off"},
+		{Name: "min_delete_depth", Contexts: httpContexts, Args: Take1, Syntax: "min_delete_depth number;", Default: "0"},
+	}},
+	{"ngx_http_empty_gif_module", []DirectiveSpec{
+		{Name: "empty_gif", Contexts: LocationContext, Args: NoArgs, Syntax: "empty_gif;"},
+	}},
+	{"ngx_http_f4f_module", []DirectiveSpec{
+		{Name: "f4f", Contexts: LocationContext, Args: NoArgs, Syntax: "f4f;"},
+		{Name: "f4f_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "f4f_buffer_size size;", Default: "512k"},
+	}},
+	{"ngx_http_fastcgi_module", []DirectiveSpec{
+		{Name: "fastcgi_bind", Contexts: httpContexts, Args: Take12, Syntax: "fastcgi_bind address [transparent] | off;", Since: "0.8.22"},
+		{Name: "fastcgi_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_buffer_size size;", Default: "4k|8k"},
+		{Name: "fastcgi_buffering", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_buffering on | off;", Default: "on", Since: "1.5.6"},
+		{Name: "fastcgi_buffers", Contexts: httpContexts, Args: Take2, Syntax: "fastcgi_buffers number size;", Default: "8 4k|8k"},
+		{Name: "fastcgi_busy_buffers_size", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_busy_buffers_size size;", Default: "8k|16k"},
+		{Name: "fastcgi_cache", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache zone | off;", Default: "off"},
+		{Name: "fastcgi_cache_background_update", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_cache_background_update on | off;", Default: "off", Since: "1.11.10"},
+		{Name: "fastcgi_cache_bypass", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_cache_bypass string ...;"},
+		{Name: "fastcgi_cache_key", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache_key string;"},
+		{Name: "fastcgi_cache_lock", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_cache_lock on | off;", Default: "off", Since: "1.1.12"},
+		{Name: "fastcgi_cache_lock_age", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache_lock_age time;", Default: "5s", Since: "1.7.8"},
+		{Name: "fastcgi_cache_lock_timeout", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache_lock_timeout time;", Default: "5s", Since: "1.1.12"},
+		{Name: "fastcgi_cache_max_range_offset", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache_max_range_offset number;", Since: "1.11.6"},
+		{Name: "fastcgi_cache_methods", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_cache_methods GET | HEAD | POST ...;", Default: "GET HEAD"},
+		{Name: "fastcgi_cache_min_uses", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_cache_min_uses number;", Default: "1"},
+		{Name: "fastcgi_cache_path", Contexts: HTTPContext, Args: TwoMore, Syntax: "fastcgi_cache_path path [levels=levels] [use_temp_path=on|off] keys_zone=name:size [inactive=time] [max_size=size] [min_free=size] [manager_files=number] [manager_sleep=time] [manager_threshold=time] [loader_files=number] [loader_sleep=time] [loader_threshold=time] [purger=on|off] [purger_files=number] [purger_sleep=time] [purger_threshold=time];"},
+		{Name: "fastcgi_cache_purge", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_cache_purge string ...;"},
+		{Name: "fastcgi_cache_revalidate", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_cache_revalidate on | off;", Default: "off", Since: "1.5.7"},
+		{Name: "fastcgi_cache_use_stale", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_cache_use_stale error | timeout | invalid_header | updating | http_500 | http_503 | http_403 | http_404 | http_429 | off ...;", Default: "off"},
+		{Name: "fastcgi_cache_valid", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_cache_valid [code ...] time;"},
+		{Name: "fastcgi_catch_stderr", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_catch_stderr string;"},
+		{Name: "fastcgi_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_connect_timeout time;", Default: "60s"},
+		{Name: "fastcgi_force_ranges", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_force_ranges on | off;", Default: "off", Since: "1.7.7"},
+		{Name: "fastcgi_hide_header", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_hide_header field;"},
+		{Name: "fastcgi_ignore_client_abort", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_ignore_client_abort on | off;", Default: "off"},
+		{Name: "fastcgi_ignore_headers", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_ignore_headers field ...;"},
+		{Name: "fastcgi_index", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_index name;"},
+		{Name: "fastcgi_intercept_errors", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_intercept_errors on | off;", Default: "off"},
+		{Name: "fastcgi_keep_conn", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_keep_conn on | off;", Default: "off", Since: "1.1.4"},
+		{Name: "fastcgi_limit_rate", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_limit_rate rate;", Default: "0", Since: "1.7.7"},
+		{Name: "fastcgi_max_temp_file_size", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_max_temp_file_size size;", Default: "1024m"},
+		{Name: "fastcgi_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "fastcgi_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_next_upstream_timeout time;", Default: "0", Since: "1.7.5"},
+		{Name: "fastcgi_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_next_upstream_tries number;", Default: "0", Since: "1.7.5"},
+		{Name: "fastcgi_no_cache", Contexts: httpContexts, Args: OneMore, Syntax: "fastcgi_no_cache string ...;"},
+		{Name: "fastcgi_param", Contexts: httpContexts, Args: Take23, Syntax: "fastcgi_param parameter value [if_not_empty];"},
+		{Name: "fastcgi_pass", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "fastcgi_pass address;"},
+		{Name: "fastcgi_pass_header", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_pass_header field;"},
+		{Name: "fastcgi_pass_request_body", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_pass_request_body on | off;", Default: "on"},
+		{Name: "fastcgi_pass_request_headers", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_pass_request_headers on | off;", Default: "on"},
+		{Name: "fastcgi_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_read_timeout time;", Default: "60s"},
+		{Name: "fastcgi_request_buffering", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_request_buffering on | off;", Default: "on", Since: "1.7.11"},
+		{Name: "fastcgi_send_lowat", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_send_lowat size;", Default: "0"},
+		{Name: "fastcgi_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_send_timeout time;", Default: "60s"},
+		{Name: "fastcgi_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "fastcgi_socket_keepalive on | off;", Default: "off", Since: "1.15.6"},
+		{Name: "fastcgi_split_path_info", Contexts: LocationContext, Args: Take1, Syntax: "fastcgi_split_path_info regex;"},
+		{Name: "fastcgi_store", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_store on | off | string;", Default: "off"},
+		{Name: "fastcgi_store_access", Contexts: httpContexts, Args: Take123, Syntax: "fastcgi_store_access users:permissions ...;", Default: "user:rw"},
+		{Name: "fastcgi_temp_file_write_size", Contexts: httpContexts, Args: Take1, Syntax: "fastcgi_temp_file_write_size size;", Default: "8k|16k"},
+		{Name: "fastcgi_temp_path", Contexts: httpContexts, Args: Take1234, Syntax: "fastcgi_temp_path path [level1 [level2 [level3]]];", Default: "fastcgi_temp"},
+	}},
+	{"ngx_http_flv_module", []DirectiveSpec{
+		{Name: "flv", Contexts: LocationContext, Args: NoArgs, Syntax: "flv;"},
+	}},
+	{"ngx_http_geo_module", []DirectiveSpec{
+		{Name: "geo", Contexts: HTTPContext, Args: Block | Take12, Syntax: "geo [$address] $variable { ... }"},
+	}},
+	{"ngx_http_geoip_module", []DirectiveSpec{
+		{Name: "geoip_city", Contexts: HTTPContext, Args: Take12, Syntax: "geoip_city file;"},
+		{Name: "geoip_country", Contexts: HTTPContext, Args: Take12, Syntax: "geoip_country file;"},
+		{Name: "geoip_org", Contexts: HTTPContext, Args: Take12, Syntax: "geoip_org file;", Since: "1.0.3"},
+		{Name: "geoip_proxy", Contexts: HTTPContext, Args: Take1, Syntax: "geoip_proxy address | CIDR;", Since: "1.3.0"},
+		{Name: "geoip_proxy_recursive", Contexts: HTTPContext, Args: Flag, Syntax: "geoip_proxy_recursive on | off;", Default: "off", Since: "1.3.0"},
+	}},
+	{"ngx_http_grpc_module", []DirectiveSpec{
+		{Name: "grpc_bind", Contexts: httpContexts, Args: Take12, Syntax: "grpc_bind address [transparent] | off;"},
+		{Name: "grpc_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "grpc_buffer_size size;", Default: "4k|8k"},
+		{Name: "grpc_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "grpc_connect_timeout time;", Default: "60s"},
+		{Name: "grpc_hide_header", Contexts: httpContexts, Args: Take1, Syntax: "grpc_hide_header field;"},
+		{Name: "grpc_ignore_headers", Contexts: httpContexts, Args: OneMore, Syntax: "grpc_ignore_headers field ...;"},
+		{Name: "grpc_intercept_errors", Contexts: httpContexts, Args: Flag, Syntax: "grpc_intercept_errors on | off;", Default: "off"},
+		{Name: "grpc_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "grpc_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "grpc_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "grpc_next_upstream_timeout time;", Default: "0"},
+		{Name: "grpc_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "grpc_next_upstream_tries number;", Default: "0"},
+		{Name: "grpc_pass", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "grpc_pass address;"},
+		{Name: "grpc_pass_header", Contexts: httpContexts, Args: Take1, Syntax: "grpc_pass_header field;"},
+		{Name: "grpc_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "grpc_read_timeout time;", Default: "60s"},
+		{Name: "grpc_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "grpc_send_timeout time;", Default: "60s"},
+		{Name: "grpc_set_header", Contexts: httpContexts, Args: Take2, Syntax: "grpc_set_header field value;"},
+		{Name: "grpc_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "grpc_socket_keepalive on | off;", Default: "off"},
+		{Name: "grpc_ssl_certificate", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_certificate file;"},
+		{Name: "grpc_ssl_certificate_key", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_certificate_key file;"},
+		{Name: "grpc_ssl_ciphers", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_ciphers ciphers;", Default: "DEFAULT"},
+		{Name: "grpc_ssl_conf_command", Contexts: httpContexts, Args: Take2, Syntax: "grpc_ssl_conf_command name value;"},
+		{Name: "grpc_ssl_crl", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_crl file;"},
+		{Name: "grpc_ssl_name", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_name name;", Default: "host from grpc_pass"},
+		{Name: "grpc_ssl_password_file", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_password_file file;"},
+		{Name: "grpc_ssl_protocols", Contexts: httpContexts, Args: OneMore, Syntax: "grpc_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "grpc_ssl_server_name", Contexts: httpContexts, Args: Flag, Syntax: "grpc_ssl_server_name on | off;", Default: "off"},
+		{Name: "grpc_ssl_session_reuse", Contexts: httpContexts, Args: Flag, Syntax: "grpc_ssl_session_reuse on | off;", Default: "on"},
+		{Name: "grpc_ssl_trusted_certificate", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_trusted_certificate file;"},
+		{Name: "grpc_ssl_verify", Contexts: httpContexts, Args: Flag, Syntax: "grpc_ssl_verify on | off;", Default: "off"},
+		{Name: "grpc_ssl_verify_depth", Contexts: httpContexts, Args: Take1, Syntax: "grpc_ssl_verify_depth number;", Default: "1"},
+	}},
+	{"ngx_http_gunzip_module", []DirectiveSpec{
+		{Name: "gunzip", Contexts: httpContexts, Args: Flag, Syntax: "gunzip on | off;", Default: "off"},
+		{Name: "gunzip_buffers", Contexts: httpContexts, Args: Take2, Syntax: "gunzip_buffers number size;", Default: "32 4k|16 8k"},
+	}},
+	{"ngx_http_gzip_module", []DirectiveSpec{
+		{Name: "gzip", Contexts: httpIfContexts, Args: Flag, Syntax: "gzip on | off;", Default: "off"},
+		{Name: "gzip_buffers", Contexts: httpContexts, Args: Take2, Syntax: "gzip_buffers number size;", Default: "32 4k|16 8k"},
+		{Name: "gzip_comp_level", Contexts: httpContexts, Args: Take1, Syntax: "gzip_comp_level level;", Default: "1"},
+		{Name: "gzip_disable", Contexts: httpContexts, Args: OneMore, Syntax: "gzip_disable regex ...;", Since: "0.6.23"},
+		{Name: "gzip_http_version", Contexts: httpContexts, Args: Take1, Syntax: "gzip_http_version 1.0 | 1.1;", Default: "1.1"},
+		{Name: "gzip_min_length", Contexts: httpContexts, Args: Take1, Syntax: "gzip_min_length length;", Default: "20"},
+		{Name: "gzip_proxied", Contexts: httpContexts, Args: OneMore, Syntax: "gzip_proxied off | expired | no-cache | no-store | private | no_last_modified | no_etag | auth | any ...;", Default: "off"},
+		{Name: "gzip_types", Contexts: httpContexts, Args: OneMore, Syntax: "gzip_types mime-type ...;", Default: "text/html"},
+		{Name: "gzip_vary", Contexts: httpContexts, Args: Flag, Syntax: "gzip_vary on | off;", Default: "off"},
+	}},
+	{"ngx_http_gzip_static_module", []DirectiveSpec{
+		{Name: "gzip_static", Contexts: httpContexts, Args: Take1, Syntax: "gzip_static on | off | always;", Default: "off"},
+	}},
+	{"ngx_http_headers_module", []DirectiveSpec{
+		{Name: "add_header", Contexts: httpIfContexts, Args: Take23, Syntax: "add_header name value [always];"},
+		{Name: "add_trailer", Contexts: httpIfContexts, Args: Take23, Syntax: "add_trailer name value [always];", Since: "1.13.2"},
+		{Name: "expires", Contexts: httpIfContexts, Args: Take12, Syntax: "expires [modified] time | epoch | max | off;", Default: "off"},
+	}},
+	{"ngx_http_hls_module", []DirectiveSpec{
+		{Name: "hls", Contexts: LocationContext, Args: NoArgs, Syntax: "hls;"},
+		{Name: "hls_buffers", Contexts: httpContexts, Args: Take2, Syntax: "hls_buffers number size;", Default: "8 2m"},
+		{Name: "hls_forward_args", Contexts: httpContexts, Args: Flag, Syntax: "hls_forward_args on | off;", Default: "off", Since: "1.5.12"},
+		{Name: "hls_fragment", Contexts: httpContexts, Args: Take1, Syntax: "hls_fragment time;", Default: "5s"},
+		{Name: "hls_mp4_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "hls_mp4_buffer_size size;", Default: "512k"},
+		{Name: "hls_mp4_max_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "hls_mp4_max_buffer_size size;", Default: "10m"},
+	}},
+	{"ngx_http_image_filter_module", []DirectiveSpec{
+		{Name: "image_filter", Contexts: LocationContext, Args: Take123, Syntax: "image_filter off | test | size | rotate 90 | 180 | 270 | resize width height | crop width height;", Default: "off"},
+		{Name: "image_filter_buffer", Contexts: httpContexts, Args: Take1, Syntax: "image_filter_buffer size;", Default: "1M"},
+		{Name: "image_filter_interlace", Contexts: httpContexts, Args: Flag, Syntax: "image_filter_interlace on | off;", Default: "off", Since: "1.3.15"},
+		{Name: "image_filter_jpeg_quality", Contexts: httpContexts, Args: Take1, Syntax: "image_filter_jpeg_quality quality;", Default: "75"},
+		{Name: "image_filter_sharpen", Contexts: httpContexts, Args: Take1, Syntax: "image_filter_sharpen percent;", Default: "0"},
+		{Name: "image_filter_transparency", Contexts: httpContexts, Args: Flag, Syntax: "image_filter_transparency on | off;", Default: "on"},
+		{Name: "image_filter_webp_quality", Contexts: httpContexts, Args: Take1, Syntax: "image_filter_webp_quality quality;", Default: "80", Since: "1.11.6"},
+	}},
+	{"ngx_http_index_module", []DirectiveSpec{
+		{Name: "index", Contexts: httpContexts, Args: OneMore, Syntax: "index file ...;", Default: "index.html"},
+	}},
+	{"ngx_http_internal_redirect_module", []DirectiveSpec{
+		{Name: "internal_redirect", Contexts: ServerContext | LocationContext, Args: Take1, Syntax: "internal_redirect uri;", Since: "1.23.4"},
+	}},
+	{"ngx_http_js_module", []DirectiveSpec{
+		{Name: "js_body_filter", Contexts: LocationContext | IfInLocationContext | LimitExceptContext, Args: Take12, Syntax: "js_body_filter function | module.function [buffer_type=string | buffer];", Since: "0.5.2"},
+		{Name: "js_content", Contexts: LocationContext | IfInLocationContext | LimitExceptContext, Args: Take1, Syntax: "js_content function | module.function;"},
+		{Name: "js_fetch_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_buffer_size size;", Default: "16k", Since: "0.7.4"},
+		{Name: "js_fetch_ciphers", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_ciphers ciphers;", Default: "HIGH:!aNULL:!MD5", Since: "0.7.0"},
+		{Name: "js_fetch_max_response_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_max_response_buffer_size size;", Default: "1m", Since: "0.7.4"},
+		{Name: "js_fetch_protocols", Contexts: httpContexts, Args: OneMore, Syntax: "js_fetch_protocols [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1 TLSv1.1 TLSv1.2", Since: "0.7.0"},
+		{Name: "js_fetch_timeout", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_timeout time;", Default: "60s", Since: "0.7.4"},
+		{Name: "js_fetch_trusted_certificate", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_trusted_certificate file;", Since: "0.7.0"},
+		{Name: "js_fetch_verify", Contexts: httpContexts, Args: Flag, Syntax: "js_fetch_verify on | off;", Default: "on", Since: "0.7.4"},
+		{Name: "js_fetch_verify_depth", Contexts: httpContexts, Args: Take1, Syntax: "js_fetch_verify_depth number;", Default: "100", Since: "0.7.0"},
+		{Name: "js_header_filter", Contexts: LocationContext | IfInLocationContext | LimitExceptContext, Args: Take1, Syntax: "js_header_filter function | module.function;", Since: "0.5.1"},
+		{Name: "js_import", Contexts: httpContexts, Args: Take13, Syntax: "js_import module.js | export_name from module.js;", Since: "0.4.0"},
+		{Name: "js_include", Contexts: HTTPContext, Args: Take1, Syntax: "js_include file;"},
+		{Name: "js_path", Contexts: httpContexts, Args: Take1, Syntax: "js_path path;", Since: "0.3.0"},
+		{Name: "js_periodic", Contexts: LocationContext, Args: OneMore, Syntax: "js_periodic function | module.function [interval=time] [jitter=number] [worker_affinity=mask];", Since: "0.8.1"},
+		{Name: "js_preload_object", Contexts: httpContexts, Args: Take13, Syntax: "js_preload_object name.json | name from file.json;", Since: "0.7.8"},
+		{Name: "js_set", Contexts: httpContexts, Args: Take23, Syntax: "js_set $variable function | module.function [nocache];"},
+		{Name: "js_shared_dict_zone", Contexts: HTTPContext, Args: OneMore, Syntax: "js_shared_dict_zone zone=name:size [timeout=time] [type=string|number] [evict];", Since: "0.8.0"},
+		{Name: "js_var", Contexts: httpContexts, Args: Take12, Syntax: "js_var $variable [value];", Since: "0.5.3"},
+	}},
+	{"ngx_http_keyval_module", []DirectiveSpec{
+		{Name: "keyval", Contexts: HTTPContext, Args: Take3, Syntax: "keyval key $variable zone=name;", Since: "1.13.3"},
+		{Name: "keyval_zone", Contexts: HTTPContext, Args: OneMore, Syntax: "keyval_zone zone=name:size [state=file] [timeout=time] [type=string|ip|prefix] [sync];", Since: "1.13.3"},
+	}},
+	{"ngx_http_limit_conn_module", []DirectiveSpec{
+		{Name: "limit_conn", Contexts: httpContexts, Args: Take2, Syntax: "limit_conn zone number;"},
+		{Name: "limit_conn_dry_run", Contexts: httpContexts, Args: Flag, Syntax: "limit_conn_dry_run on | off;", Default: "off", Since: "1.17.6"},
+		{Name: "limit_conn_log_level", Contexts: httpContexts, Args: Take1, Syntax: "limit_conn_log_level info | notice | warn | error;", Default: "error", Since: "0.8.18"},
+		{Name: "limit_conn_status", Contexts: httpContexts, Args: Take1, Syntax: "limit_conn_status code;", Default: "503", Since: "1.3.15"},
+		{Name: "limit_conn_zone", Contexts: HTTPContext, Args: Take2, Syntax: "limit_conn_zone key zone=name:size;"},
+		{Name: "limit_zone", Contexts: HTTPContext, Args: Take3, Syntax: "limit_zone name $variable size;"},
+	}},
+	{"ngx_http_limit_req_module", []DirectiveSpec{
+		{Name: "limit_req", Contexts: httpContexts, Args: Take123, Syntax: "limit_req zone=name [burst=number] [nodelay | delay=number];"},
+		{Name: "limit_req_dry_run", Contexts: httpContexts, Args: Flag, Syntax: "limit_req_dry_run on | off;", Default: "off", Since: "1.17.1"},
+		{Name: "limit_req_log_level", Contexts: httpContexts, Args: Take1, Syntax: "limit_req_log_level info | notice | warn | error;", Default: "error", Since: "0.8.18"},
+		{Name: "limit_req_status", Contexts: httpContexts, Args: Take1, Syntax: "limit_req_status code;", Default: "503", Since: "1.3.15"},
+		{Name: "limit_req_zone", Contexts: HTTPContext, Args: Take34, Syntax: "limit_req_zone key zone=name:size rate=rate [sync];"},
+	}},
+	{"ngx_http_log_module", []DirectiveSpec{
+		{Name: "access_log", Contexts: httpIfContexts | LimitExceptContext, Args: OneMore, Syntax: "access_log path [format [buffer=size] [gzip[=level]] [flush=time] [if=condition]] | off;", Default: "logs/access.log combined"},
+		{Name: "log_format", Contexts: HTTPContext, Args: TwoMore, Syntax: "log_format name [escape=default|json|none] string ...;", Default: `combined "..."`},
+		{Name: "open_log_file_cache", Contexts: httpContexts, Args: Take1234, Syntax: "open_log_file_cache max=N [inactive=time] [min_uses=N] [valid=time] | off;", Default: "off"},
+	}},
+	{"ngx_http_map_module", []DirectiveSpec{
+		{Name: "map", Contexts: HTTPContext, Args: Block | Take2, Syntax: "map string $variable { ... }"},
+		{Name: "map_hash_bucket_size", Contexts: HTTPContext, Args: Take1, Syntax: "map_hash_bucket_size size;", Default: "32|64|128"},
+		{Name: "map_hash_max_size", Contexts: HTTPContext, Args: Take1, Syntax: "map_hash_max_size size;", Default: "2048"},
+	}},
+	{"ngx_http_memcached_module", []DirectiveSpec{
+		{Name: "memcached_bind", Contexts: httpContexts, Args: Take12, Syntax: "memcached_bind address [transparent] | off;"},
+		{Name: "memcached_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "memcached_buffer_size size;", Default: "4k|8k"},
+		{Name: "memcached_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "memcached_connect_timeout time;", Default: "60s"},
+		{Name: "memcached_gzip_flag", Contexts: httpContexts, Args: Take1, Syntax: "memcached_gzip_flag flag;"},
+		{Name: "memcached_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "memcached_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "memcached_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "memcached_next_upstream_timeout time;", Default: "0"},
+		{Name: "memcached_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "memcached_next_upstream_tries number;", Default: "0"},
+		{Name: "memcached_pass", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "memcached_pass address;"},
+		{Name: "memcached_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "memcached_read_timeout time;", Default: "60s"},
+		{Name: "memcached_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "memcached_send_timeout time;", Default: "60s"},
+		{Name: "memcached_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "memcached_socket_keepalive on | off;", Default: "off"},
+	}},
+	{"ngx_http_mirror_module", []DirectiveSpec{
+		{Name: "mirror", Contexts: httpContexts, Args: Take1, Syntax: "mirror uri | off;", Default: "off"},
+		{Name: "mirror_request_body", Contexts: httpContexts, Args: Flag, Syntax: "mirror_request_body on | off;", Default: "on"},
+	}},
+	{"ngx_http_mp4_module", []DirectiveSpec{
+		{Name: "mp4", Contexts: LocationContext, Args: NoArgs, Syntax: "mp4;"},
+		{Name: "mp4_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "mp4_buffer_size size;", Default: "512K"},
+		{Name: "mp4_limit_rate", Contexts: httpContexts, Args: Take1, Syntax: "mp4_limit_rate on | off | factor;", Default: "off"},
+		{Name: "mp4_limit_rate_after", Contexts: httpContexts, Args: Take1, Syntax: "mp4_limit_rate_after time;", Default: "60s"},
+		{Name: "mp4_max_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "mp4_max_buffer_size size;", Default: "10M"},
+		{Name: "mp4_start_key_frame", Contexts: httpContexts, Args: Flag, Syntax: "mp4_start_key_frame on | off;", Default: "off", Since: "1.21.4"},
+	}},
+	{"ngx_http_perl_module", []DirectiveSpec{
+		{Name: "perl", Contexts: LocationContext | LimitExceptContext, Args: Take1, Syntax: "perl module::function | 'sub { ... }';"},
+		{Name: "perl_modules", Contexts: HTTPContext, Args: Take1, Syntax: "perl_modules path;"},
+		{Name: "perl_require", Contexts: HTTPContext, Args: Take1, Syntax: "perl_require module;"},
+		{Name: "perl_set", Contexts: HTTPContext, Args: Take2, Syntax: "perl_set $variable module::function | 'sub { ... }';"},
+	}},
+	{"ngx_http_proxy_module", []DirectiveSpec{
+		{Name: "proxy_bind", Contexts: httpContexts, Args: Take12, Syntax: "proxy_bind address [transparent] | off;", Since: "0.8.22"},
+		{Name: "proxy_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_buffer_size size;", Default: "4k|8k"},
+		{Name: "proxy_buffering", Contexts: httpContexts, Args: Flag, Syntax: "proxy_buffering on | off;", Default: "on"},
+		{Name: "proxy_buffers", Contexts: httpContexts, Args: Take2, Syntax: "proxy_buffers number size;", Default: "8 4k|8k"},
+		{Name: "proxy_busy_buffers_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_busy_buffers_size size;", Default: "8k|16k"},
+		{Name: "proxy_cache", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache zone | off;", Default: "off"},
+		{Name: "proxy_cache_background_update", Contexts: httpContexts, Args: Flag, Syntax: "proxy_cache_background_update on | off;", Default: "off", Since: "1.11.10"},
+		{Name: "proxy_cache_bypass", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_cache_bypass string ...;"},
+		{Name: "proxy_cache_convert_head", Contexts: httpContexts, Args: Flag, Syntax: "proxy_cache_convert_head on | off;", Default: "on", Since: "1.9.7"},
+		{Name: "proxy_cache_key", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache_key string;", Default: "$scheme$proxy_host$request_uri"},
+		{Name: "proxy_cache_lock", Contexts: httpContexts, Args: Flag, Syntax: "proxy_cache_lock on | off;", Default: "off", Since: "1.1.12"},
+		{Name: "proxy_cache_lock_age", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache_lock_age time;", Default: "5s", Since: "1.7.8"},
+		{Name: "proxy_cache_lock_timeout", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache_lock_timeout time;", Default: "5s", Since: "1.1.12"},
+		{Name: "proxy_cache_max_range_offset", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache_max_range_offset number;", Since: "1.11.6"},
+		{Name: "proxy_cache_methods", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_cache_methods GET | HEAD | POST ...;", Default: "GET HEAD"},
+		{Name: "proxy_cache_min_uses", Contexts: httpContexts, Args: Take1, Syntax: "proxy_cache_min_uses number;", Default: "1"},
+		{Name: "proxy_cache_path", Contexts: HTTPContext, Args: TwoMore, Syntax: "proxy_cache_path path [levels=levels] [use_temp_path=on|off] keys_zone=name:size [inactive=time] [max_size=size] [min_free=size] [manager_files=number] [manager_sleep=time] [manager_threshold=time] [loader_files=number] [loader_sleep=time] [loader_threshold=time] [purger=on|off] [purger_files=number] [purger_sleep=time] [purger_threshold=time];"},
+		{Name: "proxy_cache_purge", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_cache_purge string ...;"},
+		{Name: "proxy_cache_revalidate", Contexts: httpContexts, Args: Flag, Syntax: "proxy_cache_revalidate on | off;", Default: "off", Since: "1.5.7"},
+		{Name: "proxy_cache_use_stale", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_cache_use_stale error | timeout | invalid_header | updating | http_500 | http_503 | http_403 | http_404 | http_429 | off ...;", Default: "off"},
+		{Name: "proxy_cache_valid", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_cache_valid [code ...] time;"},
+		{Name: "proxy_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "proxy_connect_timeout time;", Default: "60s"},
+		{Name: "proxy_cookie_domain", Contexts: httpContexts, Args: Take12, Syntax: "proxy_cookie_domain off | domain replacement;", Default: "off", Since: "1.1.15"},
+		{Name: "proxy_cookie_flags", Contexts: httpContexts, Args: Take1234, Syntax: "proxy_cookie_flags off | cookie [flag ...];", Default: "off", Since: "1.19.3"},
+		{Name: "proxy_cookie_path", Contexts: httpContexts, Args: Take12, Syntax: "proxy_cookie_path off | path replacement;", Default: "off", Since: "1.1.15"},
+		{Name: "proxy_force_ranges", Contexts: httpContexts, Args: Flag, Syntax: "proxy_force_ranges on | off;", Default: "off", Since: "1.7.7"},
+		{Name: "proxy_headers_hash_bucket_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_headers_hash_bucket_size size;", Default: "64"},
+		{Name: "proxy_headers_hash_max_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_headers_hash_max_size size;", Default: "512"},
+		{Name: "proxy_hide_header", Contexts: httpContexts, Args: Take1, Syntax: "proxy_hide_header field;"},
+		{Name: "proxy_http_version", Contexts: httpContexts, Args: Take1, Syntax: "proxy_http_version 1.0 | 1.1;", Default: "1.0", Since: "1.1.4"},
+		{Name: "proxy_ignore_client_abort", Contexts: httpContexts, Args: Flag, Syntax: "proxy_ignore_client_abort on | off;", Default: "off"},
+		{Name: "proxy_ignore_headers", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_ignore_headers field ...;"},
+		{Name: "proxy_intercept_errors", Contexts: httpContexts, Args: Flag, Syntax: "proxy_intercept_errors on | off;", Default: "off"},
+		{Name: "proxy_limit_rate", Contexts: httpContexts, Args: Take1, Syntax: "proxy_limit_rate rate;", Default: "0", Since: "1.7.7"},
+		{Name: "proxy_max_temp_file_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_max_temp_file_size size;", Default: "1024m"},
+		{Name: "proxy_method", Contexts: httpContexts, Args: Take1, Syntax: "proxy_method method;"},
+		{Name: "proxy_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "proxy_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "proxy_next_upstream_timeout time;", Default: "0", Since: "1.7.5"},
+		{Name: "proxy_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "proxy_next_upstream_tries number;", Default: "0", Since: "1.7.5"},
+		{Name: "proxy_no_cache", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_no_cache string ...;"},
+		{Name: "proxy_pass", Contexts: LocationContext | IfInLocationContext | LimitExceptContext, Args: Take1, Syntax: "proxy_pass URL;"},
+		{Name: "proxy_pass_header", Contexts: httpContexts, Args: Take1, Syntax: "proxy_pass_header field;"},
+		{Name: "proxy_pass_request_body", Contexts: httpContexts, Args: Flag, Syntax: "proxy_pass_request_body on | off;", Default: "on"},
+		{Name: "proxy_pass_request_headers", Contexts: httpContexts, Args: Flag, Syntax: "proxy_pass_request_headers on | off;", Default: "on"},
+		{Name: "proxy_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "proxy_read_timeout time;", Default: "60s"},
+		{Name: "proxy_redirect", Contexts: httpContexts, Args: Take12, Syntax: "proxy_redirect default | off | redirect replacement;", Default: "default"},
+		{Name: "proxy_request_buffering", Contexts: httpContexts, Args: Flag, Syntax: "proxy_request_buffering on | off;", Default: "on", Since: "1.7.11"},
+		{Name: "proxy_send_lowat", Contexts: httpContexts, Args: Take1, Syntax: "proxy_send_lowat size;", Default: "0"},
+		{Name: "proxy_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "proxy_send_timeout time;", Default: "60s"},
+		{Name: "proxy_set_body", Contexts: httpContexts, Args: Take1, Syntax: "proxy_set_body value;"},
+		{Name: "proxy_set_header", Contexts: httpContexts, Args: Take2, Syntax: "proxy_set_header field value;", Default: "Host $proxy_host"},
+		{Name: "proxy_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "proxy_socket_keepalive on | off;", Default: "off", Since: "1.15.6"},
+		{Name: "proxy_ssl_certificate", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_certificate file;", Since: "1.7.8"},
+		{Name: "proxy_ssl_certificate_key", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_certificate_key file;", Since: "1.7.8"},
+		{Name: "proxy_ssl_ciphers", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_ciphers ciphers;", Default: "DEFAULT", Since: "1.5.6"},
+		{Name: "proxy_ssl_conf_command", Contexts: httpContexts, Args: Take2, Syntax: "proxy_ssl_conf_command name value;", Since: "1.19.4"},
+		{Name: "proxy_ssl_crl", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_crl file;", Since: "1.7.0"},
+		{Name: "proxy_ssl_name", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_name name;", Default: "$proxy_host", Since: "1.7.0"},
+		{Name: "proxy_ssl_password_file", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_password_file file;", Since: "1.7.8"},
+		{Name: "proxy_ssl_protocols", Contexts: httpContexts, Args: OneMore, Syntax: "proxy_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3", Since: "1.5.6"},
+		{Name: "proxy_ssl_server_name", Contexts: httpContexts, Args: Flag, Syntax: "proxy_ssl_server_name on | off;", Default: "off", Since: "1.7.0"},
+		{Name: "proxy_ssl_session_reuse", Contexts: httpContexts, Args: Flag, Syntax: "proxy_ssl_session_reuse on | off;", Default: "on"},
+		{Name: "proxy_ssl_trusted_certificate", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_trusted_certificate file;", Since: "1.7.0"},
+		{Name: "proxy_ssl_verify", Contexts: httpContexts, Args: Flag, Syntax: "proxy_ssl_verify on | off;", Default: "off", Since: "1.7.0"},
+		{Name: "proxy_ssl_verify_depth", Contexts: httpContexts, Args: Take1, Syntax: "proxy_ssl_verify_depth number;", Default: "1", Since: "1.7.0"},
+		{Name: "proxy_store", Contexts: httpContexts, Args: Take1, Syntax: "proxy_store on | off | string;", Default: "off"},
+		{Name: "proxy_store_access", Contexts: httpContexts, Args: Take123, Syntax: "proxy_store_access users:permissions ...;", Default: "user:rw"},
+		{Name: "proxy_temp_file_write_size", Contexts: httpContexts, Args: Take1, Syntax: "proxy_temp_file_write_size size;", Default: "8k|16k"},
+		{Name: "proxy_temp_path", Contexts: httpContexts, Args: Take1234, Syntax: "proxy_temp_path path [level1 [level2 [level3]]];", Default: "proxy_temp"},
+	}},
+	{"ngx_http_random_index_module", []DirectiveSpec{
+		{Name: "random_index", Contexts: LocationContext, Args: Flag, Syntax: "random_index on | off;", Default: "off"},
+	}},
+	{"ngx_http_realip_module", []DirectiveSpec{
+		{Name: "real_ip_header", Contexts: httpContexts, Args: Take1, Syntax: "real_ip_header field | X-Real-IP | X-Forwarded-For | proxy_protocol;", Default: "X-Real-IP"},
+		{Name: "real_ip_recursive", Contexts: httpContexts, Args: Flag, Syntax: "real_ip_recursive on | off;", Default: "off", Since: "1.3.0"},
+		{Name: "set_real_ip_from", Contexts: httpContexts, Args: Take1, Syntax: "set_real_ip_from address | CIDR | unix:;"},
+	}},
+	{"ngx_http_referer_module", []DirectiveSpec{
+		{Name: "referer_hash_bucket_size", Contexts: ServerContext | LocationContext, Args: Take1, Syntax: "referer_hash_bucket_size size;", Default: "64", Since: "1.0.5"},
+		{Name: "referer_hash_max_size", Contexts: ServerContext | LocationContext, Args: Take1, Syntax: "referer_hash_max_size size;", Default: "2048", Since: "1.0.5"},
+		{Name: "valid_referers", Contexts: ServerContext | LocationContext, Args: OneMore, Syntax: "valid_referers none | blocked | server_names | string ...;"},
+	}},
+	{"ngx_http_rewrite_module", []DirectiveSpec{
+		{Name: "break", Contexts: rewriteContexts, Args: NoArgs, Syntax: "break;"},
+		{Name: "if", Contexts: ServerContext | LocationContext, Args: Block | OneMore, Syntax: "if (condition) { ... }"},
+		{Name: "return", Contexts: rewriteContexts, Args: Take12, Syntax: "return code [text] | code URL | URL;"},
+		{Name: "rewrite", Contexts: rewriteContexts, Args: Take23, Syntax: "rewrite regex replacement [flag];"},
+		{Name: "rewrite_log", Contexts: HTTPContext | rewriteContexts, Args: Flag, Syntax: "rewrite_log on | off;", Default: "off"},
+		{Name: "set", Contexts: rewriteContexts, Args: Take2, Syntax: "set $variable value;"},
+		{Name: "uninitialized_variable_warn", Contexts: HTTPContext | rewriteContexts, Args: Flag, Syntax: "uninitialized_variable_warn on | off;", Default: "on"},
+	}},
+	{"ngx_http_scgi_module", []DirectiveSpec{
+		{Name: "scgi_bind", Contexts: httpContexts, Args: Take12, Syntax: "scgi_bind address [transparent] | off;"},
+		{Name: "scgi_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "scgi_buffer_size size;", Default: "4k|8k"},
+		{Name: "scgi_buffering", Contexts: httpContexts, Args: Flag, Syntax: "scgi_buffering on | off;", Default: "on"},
+		{Name: "scgi_buffers", Contexts: httpContexts, Args: Take2, Syntax: "scgi_buffers number size;", Default: "8 4k|8k"},
+		{Name: "scgi_busy_buffers_size", Contexts: httpContexts, Args: Take1, Syntax: "scgi_busy_buffers_size size;", Default: "8k|16k"},
+		{Name: "scgi_cache", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache zone | off;", Default: "off"},
+		{Name: "scgi_cache_background_update", Contexts: httpContexts, Args: Flag, Syntax: "scgi_cache_background_update on | off;", Default: "off"},
+		{Name: "scgi_cache_bypass", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_cache_bypass string ...;"},
+		{Name: "scgi_cache_key", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache_key string;"},
+		{Name: "scgi_cache_lock", Contexts: httpContexts, Args: Flag, Syntax: "scgi_cache_lock on | off;", Default: "off"},
+		{Name: "scgi_cache_lock_age", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache_lock_age time;", Default: "5s"},
+		{Name: "scgi_cache_lock_timeout", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache_lock_timeout time;", Default: "5s"},
+		{Name: "scgi_cache_max_range_offset", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache_max_range_offset number;"},
+		{Name: "scgi_cache_methods", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_cache_methods GET | HEAD | POST ...;", Default: "GET HEAD"},
+		{Name: "scgi_cache_min_uses", Contexts: httpContexts, Args: Take1, Syntax: "scgi_cache_min_uses number;", Default: "1"},
+		{Name: "scgi_cache_path", Contexts: HTTPContext, Args: TwoMore, Syntax: "scgi_cache_path path [levels=levels] [use_temp_path=on|off] keys_zone=name:size [inactive=time] [max_size=size] [min_free=size] [manager_files=number] [manager_sleep=time] [manager_threshold=time] [loader_files=number] [loader_sleep=time] [loader_threshold=time] [purger=on|off] [purger_files=number] [purger_sleep=time] [purger_threshold=time];"},
+		{Name: "scgi_cache_purge", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_cache_purge string ...;"},
+		{Name: "scgi_cache_revalidate", Contexts: httpContexts, Args: Flag, Syntax: "scgi_cache_revalidate on | off;", Default: "off"},
+		{Name: "scgi_cache_use_stale", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_cache_use_stale error | timeout | invalid_header | updating | http_500 | http_503 | http_403 | http_404 | http_429 | off ...;", Default: "off"},
+		{Name: "scgi_cache_valid", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_cache_valid [code ...] time;"},
+		{Name: "scgi_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "scgi_connect_timeout time;", Default: "60s"},
+		{Name: "scgi_force_ranges", Contexts: httpContexts, Args: Flag, Syntax: "scgi_force_ranges on | off;", Default: "off"},
+		{Name: "scgi_hide_header", Contexts: httpContexts, Args: Take1, Syntax: "scgi_hide_header field;"},
+		{Name: "scgi_ignore_client_abort", Contexts: httpContexts, Args: Flag, Syntax: "scgi_ignore_client_abort on | off;", Default: "off"},
+		{Name: "scgi_ignore_headers", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_ignore_headers field ...;"},
+		{Name: "scgi_intercept_errors", Contexts: httpContexts, Args: Flag, Syntax: "scgi_intercept_errors on | off;", Default: "off"},
+		{Name: "scgi_limit_rate", Contexts: httpContexts, Args: Take1, Syntax: "scgi_limit_rate rate;", Default: "0"},
+		{Name: "scgi_max_temp_file_size", Contexts: httpContexts, Args: Take1, Syntax: "scgi_max_temp_file_size size;", Default: "1024m"},
+		{Name: "scgi_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "scgi_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "scgi_next_upstream_timeout time;", Default: "0"},
+		{Name: "scgi_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "scgi_next_upstream_tries number;", Default: "0"},
+		{Name: "scgi_no_cache", Contexts: httpContexts, Args: OneMore, Syntax: "scgi_no_cache string ...;"},
+		{Name: "scgi_param", Contexts: httpContexts, Args: Take23, Syntax: "scgi_param parameter value [if_not_empty];"},
+		{Name: "scgi_pass", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "scgi_pass address;"},
+		{Name: "scgi_pass_header", Contexts: httpContexts, Args: Take1, Syntax: "scgi_pass_header field;"},
+		{Name: "scgi_pass_request_body", Contexts: httpContexts, Args: Flag, Syntax: "scgi_pass_request_body on | off;", Default: "on"},
+		{Name: "scgi_pass_request_headers", Contexts: httpContexts, Args: Flag, Syntax: "scgi_pass_request_headers on | off;", Default: "on"},
+		{Name: "scgi_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "scgi_read_timeout time;", Default: "60s"},
+		{Name: "scgi_request_buffering", Contexts: httpContexts, Args: Flag, Syntax: "scgi_request_buffering on | off;", Default: "on"},
+		{Name: "scgi_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "scgi_send_timeout time;", Default: "60s"},
+		{Name: "scgi_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "scgi_socket_keepalive on | off;", Default: "off"},
+		{Name: "scgi_store", Contexts: httpContexts, Args: Take1, Syntax: "scgi_store on | off | string;", Default: "off"},
+		{Name: "scgi_store_access", Contexts: httpContexts, Args: Take123, Syntax: "scgi_store_access users:permissions ...;", Default: "user:rw"},
+		{Name: "scgi_temp_file_write_size", Contexts: httpContexts, Args: Take1, Syntax: "scgi_temp_file_write_size size;", Default: "8k|16k"},
+		{Name: "scgi_temp_path", Contexts: httpContexts, Args: Take1234, Syntax: "scgi_temp_path path [level1 [level2 [level3]]];", Default: "scgi_temp"},
+	}},
+	{"ngx_http_secure_link_module", []DirectiveSpec{
+		{Name: "secure_link", Contexts: httpContexts, Args: Take1, Syntax: "secure_link expression;"},
+		{Name: "secure_link_md5", Contexts: httpContexts, Args: Take1, Syntax: "secure_link_md5 expression;"},
+		{Name: "secure_link_secret", Contexts: LocationContext, Args: Take1, Syntax: "secure_link_secret word;"},
+	}},
+	{"ngx_http_session_log_module", []DirectiveSpec{
+		{Name: "session_log", Contexts: httpContexts, Args: Take1, Syntax: "session_log name | off;", Default: "off"},
+		{Name: "session_log_format", Contexts: HTTPContext, Args: TwoMore, Syntax: "session_log_format name string ...;", Default: `combined "..."`},
+		{Name: "session_log_zone", Contexts: HTTPContext, Args: TwoMore, Syntax: "session_log_zone path zone=name:size [format=format] [timeout=time] [id=id] [md5=md5];"},
+	}},
+	{"ngx_http_slice_module", []DirectiveSpec{
+		{Name: "slice", Contexts: httpContexts, Args: Take1, Syntax: "slice size;", Default: "0", Since: "1.9.8"},
+	}},
+	{"ngx_http_split_clients_module", []DirectiveSpec{
+		{Name: "split_clients", Contexts: HTTPContext, Args: Block | Take2, Syntax: "split_clients string $variable { ... }"},
+	}},
+	{"ngx_http_ssi_module", []DirectiveSpec{
+		{Name: "ssi", Contexts: httpIfContexts, Args: Flag, Syntax: "ssi on | off;", Default: "off"},
+		{Name: "ssi_last_modified", Contexts: httpContexts, Args: Flag, Syntax: "ssi_last_modified on | off;", Default: "off", Since: "1.5.1"},
+		{Name: "ssi_min_file_chunk", Contexts: httpContexts, Args: Take1, Syntax: "ssi_min_file_chunk size;", Default: "1k"},
+		{Name: "ssi_silent_errors", Contexts: httpContexts, Args: Flag, Syntax: "ssi_silent_errors on | off;", Default: "off"},
+		{Name: "ssi_types", Contexts: httpContexts, Args: OneMore, Syntax: "ssi_types mime-type ...;", Default: "text/html"},
+		{Name: "ssi_value_length", Contexts: httpContexts, Args: Take1, Syntax: "ssi_value_length length;", Default: "256"},
+	}},
+	{"ngx_http_ssl_module", []DirectiveSpec{
+		{Name: "ssl", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl on | off;", Default: "off"},
+		{Name: "ssl_buffer_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_buffer_size size;", Default: "16k", Since: "1.5.9"},
+		{Name: "ssl_certificate", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_certificate file;"},
+		{Name: "ssl_certificate_key", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_certificate_key file;"},
+		{Name: "ssl_ciphers", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_ciphers ciphers;", Default: "HIGH:!aNULL:!MD5"},
+		{Name: "ssl_client_certificate", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_client_certificate file;"},
+		{Name: "ssl_conf_command", Contexts: httpSrvContexts, Args: Take2, Syntax: "ssl_conf_command name value;", Since: "1.19.4"},
+		{Name: "ssl_crl", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_crl file;"},
+		{Name: "ssl_dhparam", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_dhparam file;"},
+		{Name: "ssl_early_data", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_early_data on | off;", Default: "off", Since: "1.15.3"},
+		{Name: "ssl_ecdh_curve", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_ecdh_curve curve;", Default: "auto", Since: "1.1.0"},
+		{Name: "ssl_ocsp", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_ocsp on | off | leaf;", Default: "off", Since: "1.19.0"},
+		{Name: "ssl_ocsp_cache", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_ocsp_cache off | [shared:name:size];", Default: "off", Since: "1.19.0"},
+		{Name: "ssl_ocsp_responder", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_ocsp_responder url;", Since: "1.19.0"},
+		{Name: "ssl_password_file", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_password_file file;", Since: "1.7.3"},
+		{Name: "ssl_prefer_server_ciphers", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_prefer_server_ciphers on | off;", Default: "off"},
+		{Name: "ssl_protocols", Contexts: httpSrvContexts, Args: OneMore, Syntax: "ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "ssl_reject_handshake", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_reject_handshake on | off;", Default: "off", Since: "1.19.4"},
+		{Name: "ssl_session_cache", Contexts: httpSrvContexts, Args: Take12, Syntax: "ssl_session_cache off | none | [builtin[:size]] [shared:name:size];", Default: "none"},
+		{Name: "ssl_session_ticket_key", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_session_ticket_key file;", Since: "1.5.7"},
+		{Name: "ssl_session_tickets", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_session_tickets on | off;", Default: "on", Since: "1.5.9"},
+		{Name: "ssl_session_timeout", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_session_timeout time;", Default: "5m"},
+		{Name: "ssl_stapling", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_stapling on | off;", Default: "off", Since: "1.3.7"},
+		{Name: "ssl_stapling_file", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_stapling_file file;", Since: "1.3.7"},
+		{Name: "ssl_stapling_responder", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_stapling_responder url;", Since: "1.3.7"},
+		{Name: "ssl_stapling_verify", Contexts: httpSrvContexts, Args: Flag, Syntax: "ssl_stapling_verify on | off;", Default: "off", Since: "1.3.7"},
+		{Name: "ssl_trusted_certificate", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_trusted_certificate file;", Since: "1.3.7"},
+		{Name: "ssl_verify_client", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_verify_client on | off | optional | optional_no_ca;", Default: "off"},
+		{Name: "ssl_verify_depth", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_verify_depth number;", Default: "1"},
+	}},
+	{"ngx_http_status_module", []DirectiveSpec{
+		{Name: "status", Contexts: LocationContext, Args: NoArgs, Syntax: "status;"},
+		{Name: "status_format", Contexts: httpContexts, Args: Take12, Syntax: "status_format json | jsonp [callback];", Default: "json"},
+	}},
+	{"ngx_http_stub_status_module", []DirectiveSpec{
+		{Name: "stub_status", Contexts: ServerContext | LocationContext, Args: NoArgs | Take1, Syntax: "stub_status;"},
+	}},
+	{"ngx_http_sub_module", []DirectiveSpec{
+		{Name: "sub_filter", Contexts: httpContexts, Args: Take2, Syntax: "sub_filter string replacement;"},
+		{Name: "sub_filter_last_modified", Contexts: httpContexts, Args: Flag, Syntax: "sub_filter_last_modified on | off;", Default: "off", Since: "1.5.1"},
+		{Name: "sub_filter_once", Contexts: httpContexts, Args: Flag, Syntax: "sub_filter_once on | off;", Default: "on"},
+		{Name: "sub_filter_types", Contexts: httpContexts, Args: OneMore, Syntax: "sub_filter_types mime-type ...;", Default: "text/html"},
+	}},
+	{"ngx_http_upstream_module", []DirectiveSpec{
+		{Name: "hash", Contexts: UpstreamContext, Args: Take12, Syntax: "hash key [consistent];", Since: "1.7.2"},
+		{Name: "ip_hash", Contexts: UpstreamContext, Args: NoArgs, Syntax: "ip_hash;"},
+		{Name: "keepalive", Contexts: UpstreamContext, Args: Take1, Syntax: "keepalive connections;", Since: "1.1.4"},
+		{Name: "keepalive_requests", Contexts: UpstreamContext, Args: Take1, Syntax: "keepalive_requests number;", Default: "1000", Since: "1.15.3"},
+		{Name: "keepalive_time", Contexts: UpstreamContext, Args: Take1, Syntax: "keepalive_time time;", Default: "1h", Since: "1.19.10"},
+		{Name: "keepalive_timeout", Contexts: UpstreamContext, Args: Take1, Syntax: "keepalive_timeout timeout;", Default: "60s", Since: "1.15.3"},
+		{Name: "least_conn", Contexts: UpstreamContext, Args: NoArgs, Syntax: "least_conn;", Since: "1.3.1"},
+		{Name: "least_time", Contexts: UpstreamContext, Args: Take12, Syntax: "least_time header | last_byte [inflight];", Since: "1.7.10"},
+		{Name: "ntlm", Contexts: UpstreamContext, Args: NoArgs, Syntax: "ntlm;", Since: "1.9.2"},
+		{Name: "queue", Contexts: UpstreamContext, Args: Take12, Syntax: "queue number [timeout=time];", Since: "1.5.12"},
+		{Name: "random", Contexts: UpstreamContext, Args: NoArgs | Take12, Syntax: "random [two [method]];", Since: "1.15.1"},
+		{Name: "resolver", Contexts: UpstreamContext, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone];", Since: "1.27.3"},
+		{Name: "resolver_timeout", Contexts: UpstreamContext, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s", Since: "1.27.3"},
+		{Name: "server", Contexts: UpstreamContext, Args: OneMore, Syntax: "server address [parameters];"},
+		{Name: "state", Contexts: UpstreamContext, Args: Take1, Syntax: "state file;", Since: "1.9.7"},
+		{Name: "sticky", Contexts: UpstreamContext, Args: OneMore, Syntax: "sticky cookie name [expires=time] [domain=domain] [httponly] [samesite=strict|lax|none|$variable] [secure] [path=path] | route $variable ... | learn create=$variable lookup=$variable zone=name:size [timeout=time] [header] [sync];"},
+		{Name: "sticky_cookie_insert", Contexts: UpstreamContext, Args: OneMore, Syntax: "sticky_cookie_insert name [expires=time] [domain=domain] [path=path];"},
+		{Name: "upstream", Contexts: HTTPContext, Args: Block | Take1, Syntax: "upstream name { ... }"},
+		{Name: "zone", Contexts: UpstreamContext, Args: Take12, Syntax: "zone name [size];", Since: "1.9.0"},
+	}},
+	{"ngx_http_upstream_conf_module", []DirectiveSpec{
+		{Name: "upstream_conf", Contexts: LocationContext, Args: NoArgs, Syntax: "upstream_conf;"},
+	}},
+	{"ngx_http_upstream_hc_module", []DirectiveSpec{
+		{Name: "health_check", Contexts: LocationContext, Args: AnyArgs, Syntax: "health_check [parameters];"},
+		{Name: "match", Contexts: HTTPContext, Args: Block | Take1, Syntax: "match name { ... }"},
+	}},
+	{"ngx_http_userid_module", []DirectiveSpec{
+		{Name: "userid", Contexts: httpContexts, Args: Take1, Syntax: "userid on | v1 | log | off;", Default: "off"},
+		{Name: "userid_domain", Contexts: httpContexts, Args: Take1, Syntax: "userid_domain name | none;", Default: "none"},
+		{Name: "userid_expires", Contexts: httpContexts, Args: Take1, Syntax: "userid_expires time | max | off;", Default: "off"},
+		{Name: "userid_flags", Contexts: httpContexts, Args: OneMore, Syntax: "userid_flags off | flag ...;", Default: "off", Since: "1.19.3"},
+		{Name: "userid_mark", Contexts: httpContexts, Args: Take1, Syntax: "userid_mark letter | digit | = | off;", Default: "off"},
+		{Name: "userid_name", Contexts: httpContexts, Args: Take1, Syntax: "userid_name name;", Default: "uid"},
+		{Name: "userid_p3p", Contexts: httpContexts, Args: Take1, Syntax: "userid_p3p string | none;", Default: "none"},
+		{Name: "userid_path", Contexts: httpContexts, Args: Take1, Syntax: "userid_path path;", Default: "/"},
+		{Name: "userid_service", Contexts: httpContexts, Args: Take1, Syntax: "userid_service number;", Default: "IP address of the server"},
+	}},
+	{"ngx_http_uwsgi_module", []DirectiveSpec{
+		{Name: "uwsgi_bind", Contexts: httpContexts, Args: Take12, Syntax: "uwsgi_bind address [transparent] | off;"},
+		{Name: "uwsgi_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_buffer_size size;", Default: "4k|8k"},
+		{Name: "uwsgi_buffering", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_buffering on | off;", Default: "on"},
+		{Name: "uwsgi_buffers", Contexts: httpContexts, Args: Take2, Syntax: "uwsgi_buffers number size;", Default: "8 4k|8k"},
+		{Name: "uwsgi_busy_buffers_size", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_busy_buffers_size size;", Default: "8k|16k"},
+		{Name: "uwsgi_cache", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache zone | off;", Default: "off"},
+		{Name: "uwsgi_cache_background_update", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_cache_background_update on | off;", Default: "off"},
+		{Name: "uwsgi_cache_bypass", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_cache_bypass string ...;"},
+		{Name: "uwsgi_cache_key", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache_key string;"},
+		{Name: "uwsgi_cache_lock", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_cache_lock on | off;", Default: "off"},
+		{Name: "uwsgi_cache_lock_age", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache_lock_age time;", Default: "5s"},
+		{Name: "uwsgi_cache_lock_timeout", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache_lock_timeout time;", Default: "5s"},
+		{Name: "uwsgi_cache_max_range_offset", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache_max_range_offset number;"},
+		{Name: "uwsgi_cache_methods", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_cache_methods GET | HEAD | POST ...;", Default: "GET HEAD"},
+		{Name: "uwsgi_cache_min_uses", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_cache_min_uses number;", Default: "1"},
+		{Name: "uwsgi_cache_path", Contexts: HTTPContext, Args: TwoMore, Syntax: "uwsgi_cache_path path [levels=levels] [use_temp_path=on|off] keys_zone=name:size [inactive=time] [max_size=size] [min_free=size] [manager_files=number] [manager_sleep=time] [manager_threshold=time] [loader_files=number] [loader_sleep=time] [loader_threshold=time] [purger=on|off] [purger_files=number] [purger_sleep=time] [purger_threshold=time];"},
+		{Name: "uwsgi_cache_purge", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_cache_purge string ...;"},
+		{Name: "uwsgi_cache_revalidate", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_cache_revalidate on | off;", Default: "off"},
+		{Name: "uwsgi_cache_use_stale", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_cache_use_stale error | timeout | invalid_header | updating | http_500 | http_503 | http_403 | http_404 | http_429 | off ...;", Default: "off"},
+		{Name: "uwsgi_cache_valid", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_cache_valid [code ...] time;"},
+		{Name: "uwsgi_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_connect_timeout time;", Default: "60s"},
+		{Name: "uwsgi_force_ranges", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_force_ranges on | off;", Default: "off"},
+		{Name: "uwsgi_hide_header", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_hide_header field;"},
+		{Name: "uwsgi_ignore_client_abort", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_ignore_client_abort on | off;", Default: "off"},
+		{Name: "uwsgi_ignore_headers", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_ignore_headers field ...;"},
+		{Name: "uwsgi_intercept_errors", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_intercept_errors on | off;", Default: "off"},
+		{Name: "uwsgi_limit_rate", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_limit_rate rate;", Default: "0"},
+		{Name: "uwsgi_max_temp_file_size", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_max_temp_file_size size;", Default: "1024m"},
+		{Name: "uwsgi_modifier1", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_modifier1 number;", Default: "0"},
+		{Name: "uwsgi_modifier2", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_modifier2 number;", Default: "0"},
+		{Name: "uwsgi_next_upstream", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_next_upstream error | timeout | invalid_header | http_500 | http_503 | http_403 | http_404 | http_429 | non_idempotent | off ...;", Default: "error timeout"},
+		{Name: "uwsgi_next_upstream_timeout", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_next_upstream_timeout time;", Default: "0"},
+		{Name: "uwsgi_next_upstream_tries", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_next_upstream_tries number;", Default: "0"},
+		{Name: "uwsgi_no_cache", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_no_cache string ...;"},
+		{Name: "uwsgi_param", Contexts: httpContexts, Args: Take23, Syntax: "uwsgi_param parameter value [if_not_empty];"},
+		{Name: "uwsgi_pass", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "uwsgi_pass [protocol://]address;"},
+		{Name: "uwsgi_pass_header", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_pass_header field;"},
+		{Name: "uwsgi_pass_request_body", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_pass_request_body on | off;", Default: "on"},
+		{Name: "uwsgi_pass_request_headers", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_pass_request_headers on | off;", Default: "on"},
+		{Name: "uwsgi_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_read_timeout time;", Default: "60s"},
+		{Name: "uwsgi_request_buffering", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_request_buffering on | off;", Default: "on"},
+		{Name: "uwsgi_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_send_timeout time;", Default: "60s"},
+		{Name: "uwsgi_socket_keepalive", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_socket_keepalive on | off;", Default: "off"},
+		{Name: "uwsgi_ssl_certificate", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_certificate file;"},
+		{Name: "uwsgi_ssl_certificate_key", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_certificate_key file;"},
+		{Name: "uwsgi_ssl_ciphers", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_ciphers ciphers;", Default: "DEFAULT"},
+		{Name: "uwsgi_ssl_conf_command", Contexts: httpContexts, Args: Take2, Syntax: "uwsgi_ssl_conf_command name value;"},
+		{Name: "uwsgi_ssl_crl", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_crl file;"},
+		{Name: "uwsgi_ssl_name", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_name name;"},
+		{Name: "uwsgi_ssl_password_file", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_password_file file;"},
+		{Name: "uwsgi_ssl_protocols", Contexts: httpContexts, Args: OneMore, Syntax: "uwsgi_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "uwsgi_ssl_server_name", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_ssl_server_name on | off;", Default: "off"},
+		{Name: "uwsgi_ssl_session_reuse", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_ssl_session_reuse on | off;", Default: "on"},
+		{Name: "uwsgi_ssl_trusted_certificate", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_trusted_certificate file;"},
+		{Name: "uwsgi_ssl_verify", Contexts: httpContexts, Args: Flag, Syntax: "uwsgi_ssl_verify on | off;", Default: "off"},
+		{Name: "uwsgi_ssl_verify_depth", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_ssl_verify_depth number;", Default: "1"},
+		{Name: "uwsgi_store", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_store on | off | string;", Default: "off"},
+		{Name: "uwsgi_store_access", Contexts: httpContexts, Args: Take123, Syntax: "uwsgi_store_access users:permissions ...;", Default: "user:rw"},
+		{Name: "uwsgi_temp_file_write_size", Contexts: httpContexts, Args: Take1, Syntax: "uwsgi_temp_file_write_size size;", Default: "8k|16k"},
+		{Name: "uwsgi_temp_path", Contexts: httpContexts, Args: Take1234, Syntax: "uwsgi_temp_path path [level1 [level2 [level3]]];", Default: "uwsgi_temp"},
+	}},
+	{"ngx_http_v2_module", []DirectiveSpec{
+		{Name: "http2", Contexts: httpSrvContexts, Args: Flag, Syntax: "http2 on | off;", Default: "off", Since: "1.25.1"},
+		{Name: "http2_body_preread_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_body_preread_size size;", Default: "64k", Since: "1.11.0"},
+		{Name: "http2_chunk_size", Contexts: httpContexts, Args: Take1, Syntax: "http2_chunk_size size;", Default: "8k"},
+		{Name: "http2_idle_timeout", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_idle_timeout time;", Default: "3m"},
+		{Name: "http2_max_concurrent_pushes", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_max_concurrent_pushes number;", Default: "10", Since: "1.13.9"},
+		{Name: "http2_max_concurrent_streams", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_max_concurrent_streams number;", Default: "128"},
+		{Name: "http2_max_field_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_max_field_size size;", Default: "4k"},
+		{Name: "http2_max_header_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_max_header_size size;", Default: "16k"},
+		{Name: "http2_max_requests", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_max_requests number;", Default: "1000", Since: "1.11.6"},
+		{Name: "http2_push", Contexts: httpContexts, Args: Take1, Syntax: "http2_push uri | off;", Default: "off", Since: "1.13.9"},
+		{Name: "http2_push_preload", Contexts: httpContexts, Args: Flag, Syntax: "http2_push_preload on | off;", Default: "off", Since: "1.13.9"},
+		{Name: "http2_recv_buffer_size", Contexts: HTTPContext, Args: Take1, Syntax: "http2_recv_buffer_size size;", Default: "256k"},
+		{Name: "http2_recv_timeout", Contexts: httpSrvContexts, Args: Take1, Syntax: "http2_recv_timeout time;", Default: "30s"},
+	}},
+	{"ngx_http_v3_module", []DirectiveSpec{
+		{Name: "http3", Contexts: httpSrvContexts, Args: Flag, Syntax: "http3 on | off;", Default: "on", Since: "1.25.0"},
+		{Name: "http3_hq", Contexts: httpSrvContexts, Args: Flag, Syntax: "http3_hq on | off;", Default: "off", Since: "1.25.0"},
+		{Name: "http3_max_concurrent_streams", Contexts: httpSrvContexts, Args: Take1, Syntax: "http3_max_concurrent_streams number;", Default: "128", Since: "1.25.0"},
+		{Name: "http3_stream_buffer_size", Contexts: httpSrvContexts, Args: Take1, Syntax: "http3_stream_buffer_size size;", Default: "64k", Since: "1.25.0"},
+		{Name: "quic_active_connection_id_limit", Contexts: httpSrvContexts, Args: Take1, Syntax: "quic_active_connection_id_limit number;", Default: "2", Since: "1.25.0"},
+		{Name: "quic_bpf", Contexts: MainContext, Args: Flag, Syntax: "quic_bpf on | off;", Default: "off", Since: "1.25.0"},
+		{Name: "quic_gso", Contexts: httpSrvContexts, Args: Flag, Syntax: "quic_gso on | off;", Default: "off", Since: "1.25.0"},
+		{Name: "quic_host_key", Contexts: httpSrvContexts, Args: Take1, Syntax: "quic_host_key file;", Since: "1.25.0"},
+		{Name: "quic_retry", Contexts: httpSrvContexts, Args: Flag, Syntax: "quic_retry on | off;", Default: "off", Since: "1.25.0"},
+	}},
+	{"ngx_http_xslt_module", []DirectiveSpec{
+		{Name: "xml_entities", Contexts: httpContexts, Args: Take1, Syntax: "xml_entities path;"},
+		{Name: "xslt_last_modified", Contexts: httpContexts, Args: Flag, Syntax: "xslt_last_modified on | off;", Default: "off", Since: "1.5.1"},
+		{Name: "xslt_param", Contexts: httpContexts, Args: Take2, Syntax: "xslt_param parameter value;", Since: "1.1.18"},
+		{Name: "xslt_string_param", Contexts: httpContexts, Args: Take2, Syntax: "xslt_string_param parameter value;", Since: "1.1.18"},
+		{Name: "xslt_stylesheet", Contexts: LocationContext, Args: OneMore, Syntax: "xslt_stylesheet stylesheet [parameter=value ...];"},
+		{Name: "xslt_types", Contexts: httpContexts, Args: OneMore, Syntax: "xslt_types mime-type ...;", Default: "text/xml"},
+	}},
+	{"ngx_otel_module", []DirectiveSpec{
+		{Name: "otel_exporter", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "otel_exporter { ... }"},
+		{Name: "otel_service_name", Contexts: HTTPContext, Args: Take1, Syntax: "otel_service_name name;", Default: "unknown_service:nginx"},
+		{Name: "otel_span_attr", Contexts: httpContexts, Args: Take2, Syntax: "otel_span_attr name value;"},
+		{Name: "otel_span_name", Contexts: httpContexts, Args: Take1, Syntax: "otel_span_name name;"},
+		{Name: "otel_trace", Contexts: httpContexts, Args: Take1, Syntax: "otel_trace on | off | $variable;", Default: "off"},
+		{Name: "otel_trace_context", Contexts: httpContexts, Args: Take1, Syntax: "otel_trace_context extract | inject | propagate | ignore;", Default: "ignore"},
+	}},
+	{"ngx_mail_core_module", []DirectiveSpec{
+		{Name: "error_log", Contexts: mailContexts, Args: OneMore, Syntax: "error_log file [level];", Default: "logs/error.log error"},
+		{Name: "listen", Contexts: MailServerContext, Args: OneMore, Syntax: "listen address:port [ssl] [proxy_protocol] [backlog=number] [rcvbuf=size] [sndbuf=size] [bind] [ipv6only=on|off] [so_keepalive=on|off|[keepidle]:[keepintvl]:[keepcnt]];"},
+		{Name: "mail", Contexts: MainContext, Args: Block | NoArgs, Syntax: "mail { ... }"},
+		{Name: "max_errors", Contexts: mailContexts, Args: Take1, Syntax: "max_errors number;", Default: "5", Since: "1.21.0"},
+		{Name: "protocol", Contexts: MailServerContext, Args: Take1, Syntax: "protocol imap | pop3 | smtp;"},
+		{Name: "resolver", Contexts: mailContexts, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone] | off;", Default: "off"},
+		{Name: "resolver_timeout", Contexts: mailContexts, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s"},
+		{Name: "server", Contexts: MailContext, Args: Block | NoArgs, Syntax: "server { ... }"},
+		{Name: "server_name", Contexts: mailContexts, Args: Take1, Syntax: "server_name name;", Default: "hostname"},
+		{Name: "timeout", Contexts: mailContexts, Args: Take1, Syntax: "timeout time;", Default: "60s"},
+	}},
+	{"ngx_mail_auth_http_module", []DirectiveSpec{
+		{Name: "auth_http", Contexts: mailContexts, Args: Take1, Syntax: "auth_http URL;"},
+		{Name: "auth_http_header", Contexts: mailContexts, Args: Take2, Syntax: "auth_http_header header value;"},
+		{Name: "auth_http_pass_client_cert", Contexts: mailContexts, Args: Flag, Syntax: "auth_http_pass_client_cert on | off;", Default: "off", Since: "1.7.11"},
+		{Name: "auth_http_timeout", Contexts: mailContexts, Args: Take1, Syntax: "auth_http_timeout time;", Default: "60s"},
+	}},
+	{"ngx_mail_proxy_module", []DirectiveSpec{
+		{Name: "proxy_buffer", Contexts: mailContexts, Args: Take1, Syntax: "proxy_buffer size;", Default: "4k|8k"},
+		{Name: "proxy_pass_error_message", Contexts: mailContexts, Args: Flag, Syntax: "proxy_pass_error_message on | off;", Default: "off"},
+		{Name: "proxy_protocol", Contexts: mailContexts, Args: Flag, Syntax: "proxy_protocol on | off;", Default: "off", Since: "1.19.8"},
+		{Name: "proxy_smtp_auth", Contexts: mailContexts, Args: Flag, Syntax: "proxy_smtp_auth on | off;", Default: "off", Since: "1.19.4"},
+		{Name: "proxy_timeout", Contexts: mailContexts, Args: Take1, Syntax: "proxy_timeout timeout;", Default: "24h"},
+		{Name: "xclient", Contexts: mailContexts, Args: Flag, Syntax: "xclient on | off;", Default: "on"},
+	}},
+	{"ngx_mail_realip_module", []DirectiveSpec{
+		{Name: "set_real_ip_from", Contexts: mailContexts, Args: Take1, Syntax: "set_real_ip_from address | CIDR | unix:;", Since: "1.19.8"},
+	}},
+	{"ngx_mail_ssl_module", []DirectiveSpec{
+		{Name: "ssl", Contexts: mailContexts, Args: Flag, Syntax: "ssl on | off;", Default: "off"},
+		{Name: "ssl_certificate", Contexts: mailContexts, Args: Take1, Syntax: "ssl_certificate file;"},
+		{Name: "ssl_certificate_key", Contexts: mailContexts, Args: Take1, Syntax: "ssl_certificate_key file;"},
+		{Name: "ssl_ciphers", Contexts: mailContexts, Args: Take1, Syntax: "ssl_ciphers ciphers;", Default: "HIGH:!aNULL:!MD5"},
+		{Name: "ssl_client_certificate", Contexts: mailContexts, Args: Take1, Syntax: "ssl_client_certificate file;"},
+		{Name: "ssl_conf_command", Contexts: mailContexts, Args: Take2, Syntax: "ssl_conf_command name value;"},
+		{Name: "ssl_crl", Contexts: mailContexts, Args: Take1, Syntax: "ssl_crl file;"},
+		{Name: "ssl_dhparam", Contexts: mailContexts, Args: Take1, Syntax: "ssl_dhparam file;"},
+		{Name: "ssl_ecdh_curve", Contexts: mailContexts, Args: Take1, Syntax: "ssl_ecdh_curve curve;", Default: "auto"},
+		{Name: "ssl_password_file", Contexts: mailContexts, Args: Take1, Syntax: "ssl_password_file file;"},
+		{Name: "ssl_prefer_server_ciphers", Contexts: mailContexts, Args: Flag, Syntax: "ssl_prefer_server_ciphers on | off;", Default: "off"},
+		{Name: "ssl_protocols", Contexts: mailContexts, Args: OneMore, Syntax: "ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "ssl_session_cache", Contexts: mailContexts, Args: Take12, Syntax: "ssl_session_cache off | none | [builtin[:size]] [shared:name:size];", Default: "none"},
+		{Name: "ssl_session_ticket_key", Contexts: mailContexts, Args: Take1, Syntax: "ssl_session_ticket_key file;"},
+		{Name: "ssl_session_tickets", Contexts: mailContexts, Args: Flag, Syntax: "ssl_session_tickets on | off;", Default: "on"},
+		{Name: "ssl_session_timeout", Contexts: mailContexts, Args: Take1, Syntax: "ssl_session_timeout time;", Default: "5m"},
+		{Name: "ssl_trusted_certificate", Contexts: mailContexts, Args: Take1, Syntax: "ssl_trusted_certificate file;"},
+		{Name: "ssl_verify_client", Contexts: mailContexts, Args: Take1, Syntax: "ssl_verify_client on | off | optional | optional_no_ca;", Default: "off"},
+		{Name: "ssl_verify_depth", Contexts: mailContexts, Args: Take1, Syntax: "ssl_verify_depth number;", Default: "1"},
+		{Name: "starttls", Contexts: mailContexts, Args: Take1, Syntax: "starttls on | off | only;", Default: "off"},
+	}},
+	{"ngx_mail_imap_module", []DirectiveSpec{
+		{Name: "imap_auth", Contexts: mailContexts, Args: OneMore, Syntax: "imap_auth method ...;", Default: "plain"},
+		{Name: "imap_capabilities", Contexts: mailContexts, Args: OneMore, Syntax: "imap_capabilities extension ...;", Default: "IMAP4 IMAP4rev1 UIDPLUS"},
+		{Name: "imap_client_buffer", Contexts: mailContexts, Args: Take1, Syntax: "imap_client_buffer size;", Default: "4k|8k"},
+	}},
+	{"ngx_mail_pop3_module", []DirectiveSpec{
+		{Name: "pop3_auth", Contexts: mailContexts, Args: OneMore, Syntax: "pop3_auth method ...;", Default: "plain"},
+		{Name: "pop3_capabilities", Contexts: mailContexts, Args: OneMore, Syntax: "pop3_capabilities extension ...;", Default: "TOP USER UIDL"},
+	}},
+	{"ngx_mail_smtp_module", []DirectiveSpec{
+		{Name: "smtp_auth", Contexts: mailContexts, Args: OneMore, Syntax: "smtp_auth method ...;", Default: "plain login"},
+		{Name: "smtp_capabilities", Contexts: mailContexts, Args: OneMore, Syntax: "smtp_capabilities extension ...;"},
+		{Name: "smtp_client_buffer", Contexts: mailContexts, Args: Take1, Syntax: "smtp_client_buffer size;", Default: "4k|8k"},
+		{Name: "smtp_greeting_delay", Contexts: mailContexts, Args: Take1, Syntax: "smtp_greeting_delay time;", Default: "0"},
+	}},
+	{"ngx_stream_core_module", []DirectiveSpec{
+		{Name: "error_log", Contexts: streamContexts, Args: OneMore, Syntax: "error_log file [level];", Default: "logs/error.log error"},
+		{Name: "listen", Contexts: StreamServerContext, Args: OneMore, Syntax: "listen address:port [ssl] [udp] [proxy_protocol] [fastopen=number] [backlog=number] [rcvbuf=size] [sndbuf=size] [bind] [ipv6only=on|off] [reuseport] [so_keepalive=on|off|[keepidle]:[keepintvl]:[keepcnt]];"},
+		{Name: "preread_buffer_size", Contexts: streamContexts, Args: Take1, Syntax: "preread_buffer_size size;", Default: "16k", Since: "1.11.5"},
+		{Name: "preread_timeout", Contexts: streamContexts, Args: Take1, Syntax: "preread_timeout timeout;", Default: "30s", Since: "1.11.5"},
+		{Name: "proxy_protocol_timeout", Contexts: streamContexts, Args: Take1, Syntax: "proxy_protocol_timeout timeout;", Default: "30s", Since: "1.11.4"},
+		{Name: "resolver", Contexts: streamContexts, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone];", Since: "1.11.3"},
+		{Name: "resolver_timeout", Contexts: streamContexts, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s", Since: "1.11.3"},
+		{Name: "server", Contexts: StreamContext, Args: Block | NoArgs, Syntax: "server { ... }"},
+		{Name: "server_name", Contexts: StreamServerContext, Args: OneMore, Syntax: "server_name name ...;", Default: `""`, Since: "1.25.5"},
+		{Name: "stream", Contexts: MainContext, Args: Block | NoArgs, Syntax: "stream { ... }"},
+		{Name: "tcp_nodelay", Contexts: streamContexts, Args: Flag, Syntax: "tcp_nodelay on | off;", Default: "on", Since: "1.9.4"},
+		{Name: "variables_hash_bucket_size", Contexts: StreamContext, Args: Take1, Syntax: "variables_hash_bucket_size size;", Default: "64", Since: "1.11.2"},
+		{Name: "variables_hash_max_size", Contexts: StreamContext, Args: Take1, Syntax: "variables_hash_max_size size;", Default: "1024", Since: "1.11.2"},
+	}},
+	{"ngx_stream_access_module", []DirectiveSpec{
+		{Name: "allow", Contexts: streamContexts, Args: Take1, Syntax: "allow address | CIDR | unix: | all;"},
+		{Name: "deny", Contexts: streamContexts, Args: Take1, Syntax: "deny address | CIDR | unix: | all;"},
+	}},
+	{"ngx_stream_geo_module", []DirectiveSpec{
+		{Name: "geo", Contexts: StreamContext, Args: Block | Take12, Syntax: "geo [$address] $variable { ... }"},
+	}},
+	{"ngx_stream_geoip_module", []DirectiveSpec{
+		{Name: "geoip_city", Contexts: StreamContext, Args: Take1, Syntax: "geoip_city file;"},
+		{Name: "geoip_country", Contexts: StreamContext, Args: Take1, Syntax: "geoip_country file;"},
+		{Name: "geoip_org", Contexts: StreamContext, Args: Take1, Syntax: "geoip_org file;"},
+	}},
+	{"ngx_stream_js_module", []DirectiveSpec{
+		{Name: "js_access", Contexts: streamContexts, Args: Take1, Syntax: "js_access function | module.function;"},
+		{Name: "js_fetch_buffer_size", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_buffer_size size;", Default: "16k", Since: "0.7.4"},
+		{Name: "js_fetch_ciphers", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_ciphers ciphers;", Default: "HIGH:!aNULL:!MD5", Since: "0.7.0"},
+		{Name: "js_fetch_max_response_buffer_size", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_max_response_buffer_size size;", Default: "1m", Since: "0.7.4"},
+		{Name: "js_fetch_protocols", Contexts: streamContexts, Args: OneMore, Syntax: "js_fetch_protocols [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1 TLSv1.1 TLSv1.2", Since: "0.7.0"},
+		{Name: "js_fetch_timeout", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_timeout time;", Default: "60s", Since: "0.7.4"},
+		{Name: "js_fetch_trusted_certificate", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_trusted_certificate file;", Since: "0.7.0"},
+		{Name: "js_fetch_verify", Contexts: streamContexts, Args: Flag, Syntax: "js_fetch_verify on | off;", Default: "on", Since: "0.7.4"},
+		{Name: "js_fetch_verify_depth", Contexts: streamContexts, Args: Take1, Syntax: "js_fetch_verify_depth number;", Default: "100", Since: "0.7.0"},
+		{Name: "js_filter", Contexts: streamContexts, Args: Take1, Syntax: "js_filter function | module.function;"},
+		{Name: "js_import", Contexts: streamContexts, Args: Take13, Syntax: "js_import module.js | export_name from module.js;", Since: "0.4.0"},
+		{Name: "js_include", Contexts: StreamContext, Args: Take1, Syntax: "js_include file;"},
+		{Name: "js_path", Contexts: streamContexts, Args: Take1, Syntax: "js_path path;", Since: "0.3.0"},
+		{Name: "js_periodic", Contexts: StreamServerContext, Args: OneMore, Syntax: "js_periodic function | module.function [interval=time] [jitter=number] [worker_affinity=mask];", Since: "0.8.1"},
+		{Name: "js_preload_object", Contexts: streamContexts, Args: Take13, Syntax: "js_preload_object name.json | name from file.json;", Since: "0.7.8"},
+		{Name: "js_preread", Contexts: streamContexts, Args: Take1, Syntax: "js_preread function | module.function;"},
+		{Name: "js_set", Contexts: streamContexts, Args: Take23, Syntax: "js_set $variable function | module.function [nocache];"},
+		{Name: "js_shared_dict_zone", Contexts: StreamContext, Args: OneMore, Syntax: "js_shared_dict_zone zone=name:size [timeout=time] [type=string|number] [evict];", Since: "0.8.0"},
+		{Name: "js_var", Contexts: streamContexts, Args: Take12, Syntax: "js_var $variable [value];", Since: "0.5.3"},
+	}},
+	{"ngx_stream_keyval_module", []DirectiveSpec{
+		{Name: "keyval", Contexts: StreamContext, Args: Take3, Syntax: "keyval key $variable zone=name;", Since: "1.13.7"},
+		{Name: "keyval_zone", Contexts: StreamContext, Args: OneMore, Syntax: "keyval_zone zone=name:size [state=file] [timeout=time] [type=string|ip|prefix] [sync];", Since: "1.13.7"},
+	}},
+	{"ngx_stream_limit_conn_module", []DirectiveSpec{
+		{Name: "limit_conn", Contexts: streamContexts, Args: Take2, Syntax: "limit_conn zone number;"},
+		{Name: "limit_conn_dry_run", Contexts: streamContexts, Args: Flag, Syntax: "limit_conn_dry_run on | off;", Default: "off", Since: "1.17.6"},
+		{Name: "limit_conn_log_level", Contexts: streamContexts, Args: Take1, Syntax: "limit_conn_log_level info | notice | warn | error;", Default: "error"},
+		{Name: "limit_conn_zone", Contexts: StreamContext, Args: Take2, Syntax: "limit_conn_zone key zone=name:size;"},
+	}},
+	{"ngx_stream_log_module", []DirectiveSpec{
+		{Name: "access_log", Contexts: streamContexts, Args: OneMore, Syntax: "access_log path format [buffer=size] [gzip[=level]] [flush=time] [if=condition] | off;", Default: "off"},
+		{Name: "log_format", Contexts: StreamContext, Args: TwoMore, Syntax: "log_format name [escape=default|json|none] string ...;"},
+		{Name: "open_log_file_cache", Contexts: streamContexts, Args: Take1234, Syntax: "open_log_file_cache max=N [inactive=time] [min_uses=N] [valid=time] | off;", Default: "off"},
+	}},
+	{"ngx_stream_map_module", []DirectiveSpec{
+		{Name: "map", Contexts: StreamContext, Args: Block | Take2, Syntax: "map string $variable { ... }"},
+		{Name: "map_hash_bucket_size", Contexts: StreamContext, Args: Take1, Syntax: "map_hash_bucket_size size;", Default: "32|64|128"},
+		{Name: "map_hash_max_size", Contexts: StreamContext, Args: Take1, Syntax: "map_hash_max_size size;", Default: "2048"},
+	}},
+	{"ngx_stream_mqtt_filter_module", []DirectiveSpec{
+		{Name: "mqtt", Contexts: streamContexts, Args: Flag, Syntax: "mqtt on | off;", Default: "off"},
+		{Name: "mqtt_buffers", Contexts: streamContexts, Args: Take2, Syntax: "mqtt_buffers number size;", Default: "100 1k", Since: "1.25.1"},
+		{Name: "mqtt_rewrite_buffer_size", Contexts: StreamServerContext, Args: Take1, Syntax: "mqtt_rewrite_buffer_size size;", Default: "4k|8k"},
+		{Name: "mqtt_set_connect", Contexts: StreamServerContext, Args: Take2, Syntax: "mqtt_set_connect field value;"},
+	}},
+	{"ngx_stream_mqtt_preread_module", []DirectiveSpec{
+		{Name: "mqtt_preread", Contexts: streamContexts, Args: Flag, Syntax: "mqtt_preread on | off;", Default: "off"},
+	}},
+	{"ngx_stream_proxy_module", []DirectiveSpec{
+		{Name: "proxy_bind", Contexts: streamContexts, Args: Take12, Syntax: "proxy_bind address [transparent] | off;", Since: "1.9.2"},
+		{Name: "proxy_buffer_size", Contexts: streamContexts, Args: Take1, Syntax: "proxy_buffer_size size;", Default: "16k", Since: "1.9.4"},
+		{Name: "proxy_connect_timeout", Contexts: streamContexts, Args: Take1, Syntax: "proxy_connect_timeout time;", Default: "60s"},
+		{Name: "proxy_download_rate", Contexts: streamContexts, Args: Take1, Syntax: "proxy_download_rate rate;", Default: "0", Since: "1.9.3"},
+		{Name: "proxy_half_close", Contexts: streamContexts, Args: Flag, Syntax: "proxy_half_close on | off;", Default: "off", Since: "1.21.4"},
+		{Name: "proxy_next_upstream", Contexts: streamContexts, Args: Flag, Syntax: "proxy_next_upstream on | off;", Default: "on"},
+		{Name: "proxy_next_upstream_timeout", Contexts: streamContexts, Args: Take1, Syntax: "proxy_next_upstream_timeout time;", Default: "0"},
+		{Name: "proxy_next_upstream_tries", Contexts: streamContexts, Args: Take1, Syntax: "proxy_next_upstream_tries number;", Default: "0"},
+		{Name: "proxy_pass", Contexts: StreamServerContext, Args: Take1, Syntax: "proxy_pass address;"},
+		{Name: "proxy_protocol", Contexts: streamContexts, Args: Flag, Syntax: "proxy_protocol on | off;", Default: "off", Since: "1.9.2"},
+		{Name: "proxy_requests", Contexts: streamContexts, Args: Take1, Syntax: "proxy_requests number;", Default: "0", Since: "1.15.7"},
+		{Name: "proxy_responses", Contexts: streamContexts, Args: Take1, Syntax: "proxy_responses number;"},
+		{Name: "proxy_session_drop", Contexts: streamContexts, Args: Flag, Syntax: "proxy_session_drop on | off;", Default: "off", Since: "1.15.8"},
+		{Name: "proxy_socket_keepalive", Contexts: streamContexts, Args: Flag, Syntax: "proxy_socket_keepalive on | off;", Default: "off", Since: "1.15.6"},
+		{Name: "proxy_ssl", Contexts: streamContexts, Args: Flag, Syntax: "proxy_ssl on | off;", Default: "off"},
+		{Name: "proxy_ssl_certificate", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_certificate file;"},
+		{Name: "proxy_ssl_certificate_key", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_certificate_key file;"},
+		{Name: "proxy_ssl_ciphers", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_ciphers ciphers;", Default: "DEFAULT"},
+		{Name: "proxy_ssl_conf_command", Contexts: streamContexts, Args: Take2, Syntax: "proxy_ssl_conf_command name value;", Since: "1.19.4"},
+		{Name: "proxy_ssl_crl", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_crl file;"},
+		{Name: "proxy_ssl_name", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_name name;", Default: "host from proxy_pass"},
+		{Name: "proxy_ssl_password_file", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_password_file file;"},
+		{Name: "proxy_ssl_protocols", Contexts: streamContexts, Args: OneMore, Syntax: "proxy_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "proxy_ssl_server_name", Contexts: streamContexts, Args: Flag, Syntax: "proxy_ssl_server_name on | off;", Default: "off"},
+		{Name: "proxy_ssl_session_reuse", Contexts: streamContexts, Args: Flag, Syntax: "proxy_ssl_session_reuse on | off;", Default: "on"},
+		{Name: "proxy_ssl_trusted_certificate", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_trusted_certificate file;"},
+		{Name: "proxy_ssl_verify", Contexts: streamContexts, Args: Flag, Syntax: "proxy_ssl_verify on | off;", Default: "off"},
+		{Name: "proxy_ssl_verify_depth", Contexts: streamContexts, Args: Take1, Syntax: "proxy_ssl_verify_depth number;", Default: "1"},
+		{Name: "proxy_timeout", Contexts: streamContexts, Args: Take1, Syntax: "proxy_timeout timeout;", Default: "10m"},
+		{Name: "proxy_upload_rate", Contexts: streamContexts, Args: Take1, Syntax: "proxy_upload_rate rate;", Default: "0", Since: "1.9.3"},
+	}},
+	{"ngx_stream_realip_module", []DirectiveSpec{
+		{Name: "set_real_ip_from", Contexts: streamContexts, Args: Take1, Syntax: "set_real_ip_from address | CIDR | unix:;"},
+	}},
+	{"ngx_stream_return_module", []DirectiveSpec{
+		{Name: "return", Contexts: StreamServerContext, Args: Take1, Syntax: "return value;"},
+	}},
+	{"ngx_stream_set_module", []DirectiveSpec{
+		{Name: "set", Contexts: StreamServerContext, Args: Take2, Syntax: "set $variable value;"},
+	}},
+	{"ngx_stream_split_clients_module", []DirectiveSpec{
+		{Name: "split_clients", Contexts: StreamContext, Args: Block | Take2, Syntax: "split_clients string $variable { ... }"},
+	}},
+	{"ngx_stream_ssl_module", []DirectiveSpec{
+		{Name: "ssl_alpn", Contexts: streamContexts, Args: OneMore, Syntax: "ssl_alpn protocol ...;", Since: "1.21.4"},
+		{Name: "ssl_certificate", Contexts: streamContexts, Args: Take1, Syntax: "ssl_certificate file;"},
+		{Name: "ssl_certificate_key", Contexts: streamContexts, Args: Take1, Syntax: "ssl_certificate_key file;"},
+		{Name: "ssl_ciphers", Contexts: streamContexts, Args: Take1, Syntax: "ssl_ciphers ciphers;", Default: "HIGH:!aNULL:!MD5"},
+		{Name: "ssl_client_certificate", Contexts: streamContexts, Args: Take1, Syntax: "ssl_client_certificate file;"},
+		{Name: "ssl_conf_command", Contexts: streamContexts, Args: Take2, Syntax: "ssl_conf_command name value;", Since: "1.19.4"},
+		{Name: "ssl_crl", Contexts: streamContexts, Args: Take1, Syntax: "ssl_crl file;"},
+		{Name: "ssl_dhparam", Contexts: streamContexts, Args: Take1, Syntax: "ssl_dhparam file;"},
+		{Name: "ssl_ecdh_curve", Contexts: streamContexts, Args: Take1, Syntax: "ssl_ecdh_curve curve;", Default: "auto"},
+		{Name: "ssl_handshake_timeout", Contexts: streamContexts, Args: Take1, Syntax: "ssl_handshake_timeout time;", Default: "60s"},
+		{Name: "ssl_ocsp", Contexts: streamContexts, Args: Take1, Syntax: "ssl_ocsp on | off | leaf;", Default: "off", Since: "1.27.2"},
+		{Name: "ssl_ocsp_cache", Contexts: streamContexts, Args: Take1, Syntax: "ssl_ocsp_cache off | [shared:name:size];", Default: "off", Since: "1.27.2"},
+		{Name: "ssl_ocsp_responder", Contexts: streamContexts, Args: Take1, Syntax: "ssl_ocsp_responder url;", Since: "1.27.2"},
+		{Name: "ssl_password_file", Contexts: streamContexts, Args: Take1, Syntax: "ssl_password_file file;"},
+		{Name: "ssl_prefer_server_ciphers", Contexts: streamContexts, Args: Flag, Syntax: "ssl_prefer_server_ciphers on | off;", Default: "off"},
+		{Name: "ssl_protocols", Contexts: streamContexts, Args: OneMore, Syntax: "ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "ssl_reject_handshake", Contexts: streamContexts, Args: Flag, Syntax: "ssl_reject_handshake on | off;", Default: "off", Since: "1.25.5"},
+		{Name: "ssl_session_cache", Contexts: streamContexts, Args: Take12, Syntax: "ssl_session_cache off | none | [builtin[:size]] [shared:name:size];", Default: "none"},
+		{Name: "ssl_session_ticket_key", Contexts: streamContexts, Args: Take1, Syntax: "ssl_session_ticket_key file;"},
+		{Name: "ssl_session_tickets", Contexts: streamContexts, Args: Flag, Syntax: "ssl_session_tickets on | off;", Default: "on"},
+		{Name: "ssl_session_timeout", Contexts: streamContexts, Args: Take1, Syntax: "ssl_session_timeout time;", Default: "5m"},
+		{Name: "ssl_stapling", Contexts: streamContexts, Args: Flag, Syntax: "ssl_stapling on | off;", Default: "off", Since: "1.27.2"},
+		{Name: "ssl_stapling_file", Contexts: streamContexts, Args: Take1, Syntax: "ssl_stapling_file file;", Since: "1.27.2"},
+		{Name: "ssl_stapling_responder", Contexts: streamContexts, Args: Take1, Syntax: "ssl_stapling_responder url;", Since: "1.27.2"},
+		{Name: "ssl_stapling_verify", Contexts: streamContexts, Args: Flag, Syntax: "ssl_stapling_verify on | off;", Default: "off", Since: "1.27.2"},
+		{Name: "ssl_trusted_certificate", Contexts: streamContexts, Args: Take1, Syntax: "ssl_trusted_certificate file;"},
+		{Name: "ssl_verify_client", Contexts: streamContexts, Args: Take1, Syntax: "ssl_verify_client on | off | optional | optional_no_ca;", Default: "off"},
+		{Name: "ssl_verify_depth", Contexts: streamContexts, Args: Take1, Syntax: "ssl_verify_depth number;", Default: "1"},
+	}},
+	{"ngx_stream_ssl_preread_module", []DirectiveSpec{
+		{Name: "ssl_preread", Contexts: streamContexts, Args: Flag, Syntax: "ssl_preread on | off;", Default: "off"},
+	}},
+	{"ngx_stream_status_module", []DirectiveSpec{
+		{Name: "status_zone", Contexts: StreamServerContext, Args: Take1, Syntax: "status_zone zone;"},
+	}},
+	{"ngx_stream_upstream_module", []DirectiveSpec{
+		{Name: "hash", Contexts: StreamUpstreamContext, Args: Take12, Syntax: "hash key [consistent];"},
+		{Name: "least_conn", Contexts: StreamUpstreamContext, Args: NoArgs, Syntax: "least_conn;"},
+		{Name: "least_time", Contexts: StreamUpstreamContext, Args: Take12, Syntax: "least_time connect | first_byte | last_byte [inflight];"},
+		{Name: "random", Contexts: StreamUpstreamContext, Args: NoArgs | Take12, Syntax: "random [two [method]];", Since: "1.15.1"},
+		{Name: "resolver", Contexts: StreamUpstreamContext, Args: OneMore, Syntax: "resolver address ... [valid=time] [ipv4=on|off] [ipv6=on|off] [status_zone=zone];", Since: "1.27.3"},
+		{Name: "resolver_timeout", Contexts: StreamUpstreamContext, Args: Take1, Syntax: "resolver_timeout time;", Default: "30s", Since: "1.27.3"},
+		{Name: "server", Contexts: StreamUpstreamContext, Args: OneMore, Syntax: "server address [parameters];"},
+		{Name: "state", Contexts: StreamUpstreamContext, Args: Take1, Syntax: "state file;", Since: "1.9.7"},
+		{Name: "upstream", Contexts: StreamContext, Args: Block | Take1, Syntax: "upstream name { ... }"},
+		{Name: "zone", Contexts: StreamUpstreamContext, Args: Take12, Syntax: "zone name [size];"},
+	}},
+	{"ngx_stream_upstream_hc_module", []DirectiveSpec{
+		{Name: "health_check", Contexts: StreamServerContext, Args: AnyArgs, Syntax: "health_check [parameters];"},
+		{Name: "health_check_timeout", Contexts: streamContexts, Args: Take1, Syntax: "health_check_timeout timeout;", Default: "5s"},
+		{Name: "match", Contexts: StreamContext, Args: Block | Take1, Syntax: "match name { ... }"},
+	}},
+	{"ngx_stream_zone_sync_module", []DirectiveSpec{
+		{Name: "zone_sync", Contexts: StreamServerContext, Args: NoArgs, Syntax: "zone_sync;"},
+		{Name: "zone_sync_buffers", Contexts: streamContexts, Args: Take2, Syntax: "zone_sync_buffers number size;", Default: "8 4k|8k"},
+		{Name: "zone_sync_connect_retry_interval", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_connect_retry_interval time;", Default: "1s"},
+		{Name: "zone_sync_connect_timeout", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_connect_timeout time;", Default: "5s"},
+		{Name: "zone_sync_interval", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_interval time;", Default: "1s"},
+		{Name: "zone_sync_recv_buffer_size", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_recv_buffer_size size;", Default: "4k|8k"},
+		{Name: "zone_sync_server", Contexts: streamContexts, Args: Take12, Syntax: "zone_sync_server address [resolve];"},
+		{Name: "zone_sync_ssl", Contexts: streamContexts, Args: Flag, Syntax: "zone_sync_ssl on | off;", Default: "off"},
+		{Name: "zone_sync_ssl_certificate", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_certificate file;"},
+		{Name: "zone_sync_ssl_certificate_key", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_certificate_key file;"},
+		{Name: "zone_sync_ssl_ciphers", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_ciphers ciphers;", Default: "DEFAULT"},
+		{Name: "zone_sync_ssl_conf_command", Contexts: streamContexts, Args: Take2, Syntax: "zone_sync_ssl_conf_command name value;"},
+		{Name: "zone_sync_ssl_crl", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_crl file;"},
+		{Name: "zone_sync_ssl_name", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_name name;", Default: "host from zone_sync_server"},
+		{Name: "zone_sync_ssl_password_file", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_password_file file;"},
+		{Name: "zone_sync_ssl_protocols", Contexts: streamContexts, Args: OneMore, Syntax: "zone_sync_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "TLSv1.2 TLSv1.3"},
+		{Name: "zone_sync_ssl_server_name", Contexts: streamContexts, Args: Flag, Syntax: "zone_sync_ssl_server_name on | off;", Default: "off"},
+		{Name: "zone_sync_ssl_trusted_certificate", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_trusted_certificate file;"},
+		{Name: "zone_sync_ssl_verify", Contexts: streamContexts, Args: Flag, Syntax: "zone_sync_ssl_verify on | off;", Default: "off"},
+		{Name: "zone_sync_ssl_verify_depth", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_ssl_verify_depth number;", Default: "1"},
+		{Name: "zone_sync_timeout", Contexts: streamContexts, Args: Take1, Syntax: "zone_sync_timeout timeout;", Default: "5s"},
+	}},
+	{"lua-nginx-module", []DirectiveSpec{
+		{Name: "access_by_lua", Contexts: httpIfContexts, Args: Take1, Syntax: "access_by_lua lua-script-str;"},
+		{Name: "access_by_lua_block", Contexts: httpIfContexts, Args: Block | NoArgs, Syntax: "access_by_lua_block { lua-script }"},
+		{Name: "access_by_lua_file", Contexts: httpIfContexts, Args: Take1, Syntax: "access_by_lua_file path-to-lua-script-file;"},
+		{Name: "access_by_lua_no_postpone", Contexts: HTTPContext, Args: Flag, Syntax: "access_by_lua_no_postpone on | off;", Default: "off"},
+		{Name: "balancer_by_lua_block", Contexts: UpstreamContext, Args: Block | NoArgs, Syntax: "balancer_by_lua_block { lua-script }"},
+		{Name: "balancer_by_lua_file", Contexts: UpstreamContext, Args: Take1, Syntax: "balancer_by_lua_file path-to-lua-script-file;"},
+		{Name: "balancer_keepalive", Contexts: UpstreamContext, Args: Take1, Syntax: "balancer_keepalive connections;"},
+		{Name: "body_filter_by_lua", Contexts: httpIfContexts, Args: Take1, Syntax: "body_filter_by_lua lua-script-str;"},
+		{Name: "body_filter_by_lua_block", Contexts: httpIfContexts, Args: Block | NoArgs, Syntax: "body_filter_by_lua_block { lua-script }"},
+		{Name: "body_filter_by_lua_file", Contexts: httpIfContexts, Args: Take1, Syntax: "body_filter_by_lua_file path-to-lua-script-file;"},
+		{Name: "content_by_lua", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "content_by_lua lua-script-str;"},
+		{Name: "content_by_lua_block", Contexts: LocationContext | IfInLocationContext, Args: Block | NoArgs, Syntax: "content_by_lua_block { lua-script }"},
+		{Name: "content_by_lua_file", Contexts: LocationContext | IfInLocationContext, Args: Take1, Syntax: "content_by_lua_file path-to-lua-script-file;"},
+		{Name: "exit_worker_by_lua_block", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "exit_worker_by_lua_block { lua-script }"},
+		{Name: "exit_worker_by_lua_file", Contexts: HTTPContext, Args: Take1, Syntax: "exit_worker_by_lua_file path-to-lua-script-file;"},
+		{Name: "header_filter_by_lua", Contexts: httpIfContexts, Args: Take1, Syntax: "header_filter_by_lua lua-script-str;"},
+		{Name: "header_filter_by_lua_block", Contexts: httpIfContexts, Args: Block | NoArgs, Syntax: "header_filter_by_lua_block { lua-script }"},
+		{Name: "header_filter_by_lua_file", Contexts: httpIfContexts, Args: Take1, Syntax: "header_filter_by_lua_file path-to-lua-script-file;"},
+		{Name: "init_by_lua", Contexts: HTTPContext, Args: Take1, Syntax: "init_by_lua lua-script-str;"},
+		{Name: "init_by_lua_block", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "init_by_lua_block { lua-script }"},
+		{Name: "init_by_lua_file", Contexts: HTTPContext, Args: Take1, Syntax: "init_by_lua_file path-to-lua-script-file;"},
+		{Name: "init_worker_by_lua", Contexts: HTTPContext, Args: Take1, Syntax: "init_worker_by_lua lua-script-str;"},
+		{Name: "init_worker_by_lua_block", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "init_worker_by_lua_block { lua-script }"},
+		{Name: "init_worker_by_lua_file", Contexts: HTTPContext, Args: Take1, Syntax: "init_worker_by_lua_file path-to-lua-script-file;"},
+		{Name: "log_by_lua", Contexts: httpIfContexts, Args: Take1, Syntax: "log_by_lua lua-script-str;"},
+		{Name: "log_by_lua_block", Contexts: httpIfContexts, Args: Block | NoArgs, Syntax: "log_by_lua_block { lua-script }"},
+		{Name: "log_by_lua_file", Contexts: httpIfContexts, Args: Take1, Syntax: "log_by_lua_file path-to-lua-script-file;"},
+		{Name: "lua_capture_error_log", Contexts: HTTPContext, Args: Take1, Syntax: "lua_capture_error_log size;"},
+		{Name: "lua_check_client_abort", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_check_client_abort on | off;", Default: "off"},
+		{Name: "lua_code_cache", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_code_cache on | off;", Default: "on"},
+		{Name: "lua_http10_buffering", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_http10_buffering on | off;", Default: "on"},
+		{Name: "lua_load_resty_core", Contexts: HTTPContext, Args: Flag, Syntax: "lua_load_resty_core on | off;", Default: "on"},
+		{Name: "lua_malloc_trim", Contexts: HTTPContext, Args: Take1, Syntax: "lua_malloc_trim request-count;", Default: "1000"},
+		{Name: "lua_max_pending_timers", Contexts: HTTPContext, Args: Take1, Syntax: "lua_max_pending_timers count;", Default: "1024"},
+		{Name: "lua_max_running_timers", Contexts: HTTPContext, Args: Take1, Syntax: "lua_max_running_timers count;", Default: "256"},
+		{Name: "lua_need_request_body", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_need_request_body on | off;", Default: "off"},
+		{Name: "lua_package_cpath", Contexts: HTTPContext, Args: Take1, Syntax: "lua_package_cpath lua-style-cpath-str;"},
+		{Name: "lua_package_path", Contexts: HTTPContext, Args: Take1, Syntax: "lua_package_path lua-style-path-str;"},
+		{Name: "lua_regex_cache_max_entries", Contexts: HTTPContext, Args: Take1, Syntax: "lua_regex_cache_max_entries num;", Default: "1024"},
+		{Name: "lua_regex_match_limit", Contexts: HTTPContext, Args: Take1, Syntax: "lua_regex_match_limit num;", Default: "0"},
+		{Name: "lua_sa_restart", Contexts: HTTPContext, Args: Flag, Syntax: "lua_sa_restart on | off;", Default: "on"},
+		{Name: "lua_shared_dict", Contexts: HTTPContext, Args: Take2, Syntax: "lua_shared_dict name size;"},
+		{Name: "lua_socket_buffer_size", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_buffer_size size;", Default: "4k|8k"},
+		{Name: "lua_socket_connect_timeout", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_connect_timeout time;", Default: "60s"},
+		{Name: "lua_socket_keepalive_timeout", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_keepalive_timeout time;", Default: "60s"},
+		{Name: "lua_socket_log_errors", Contexts: httpContexts, Args: Flag, Syntax: "lua_socket_log_errors on | off;", Default: "on"},
+		{Name: "lua_socket_pool_size", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_pool_size size;", Default: "30"},
+		{Name: "lua_socket_read_timeout", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_read_timeout time;", Default: "60s"},
+		{Name: "lua_socket_send_lowat", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_send_lowat size;", Default: "0"},
+		{Name: "lua_socket_send_timeout", Contexts: httpContexts, Args: Take1, Syntax: "lua_socket_send_timeout time;", Default: "60s"},
+		{Name: "lua_ssl_certificate", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_certificate file;"},
+		{Name: "lua_ssl_certificate_key", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_certificate_key file;"},
+		{Name: "lua_ssl_ciphers", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_ciphers ciphers;", Default: "DEFAULT"},
+		{Name: "lua_ssl_conf_command", Contexts: httpContexts, Args: Take2, Syntax: "lua_ssl_conf_command command;"},
+		{Name: "lua_ssl_crl", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_crl file;"},
+		{Name: "lua_ssl_protocols", Contexts: httpContexts, Args: OneMore, Syntax: "lua_ssl_protocols [SSLv2] [SSLv3] [TLSv1] [TLSv1.1] [TLSv1.2] [TLSv1.3];", Default: "SSLv3 TLSv1 TLSv1.1 TLSv1.2"},
+		{Name: "lua_ssl_trusted_certificate", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_trusted_certificate file;"},
+		{Name: "lua_ssl_verify_depth", Contexts: httpContexts, Args: Take1, Syntax: "lua_ssl_verify_depth number;", Default: "1"},
+		{Name: "lua_thread_cache_max_entries", Contexts: HTTPContext, Args: Take1, Syntax: "lua_thread_cache_max_entries num;", Default: "1024"},
+		{Name: "lua_transform_underscores_in_response_headers", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_transform_underscores_in_response_headers on | off;", Default: "on"},
+		{Name: "lua_use_default_type", Contexts: httpIfContexts, Args: Flag, Syntax: "lua_use_default_type on | off;", Default: "on"},
+		{Name: "lua_worker_thread_vm_pool_size", Contexts: HTTPContext, Args: Take1, Syntax: "lua_worker_thread_vm_pool_size size;", Default: "10"},
+		{Name: "rewrite_by_lua", Contexts: httpIfContexts, Args: Take1, Syntax: "rewrite_by_lua lua-script-str;"},
+		{Name: "rewrite_by_lua_block", Contexts: httpIfContexts, Args: Block | NoArgs, Syntax: "rewrite_by_lua_block { lua-script }"},
+		{Name: "rewrite_by_lua_file", Contexts: httpIfContexts, Args: Take1, Syntax: "rewrite_by_lua_file path-to-lua-script-file;"},
+		{Name: "rewrite_by_lua_no_postpone", Contexts: HTTPContext, Args: Flag, Syntax: "rewrite_by_lua_no_postpone on | off;", Default: "off"},
+		{Name: "server_rewrite_by_lua_block", Contexts: httpSrvContexts, Args: Block | NoArgs, Syntax: "server_rewrite_by_lua_block { lua-script }"},
+		{Name: "server_rewrite_by_lua_file", Contexts: httpSrvContexts, Args: Take1, Syntax: "server_rewrite_by_lua_file path-to-lua-script-file;"},
+		{Name: "set_by_lua", Contexts: rewriteContexts, Args: TwoMore, Syntax: "set_by_lua $res lua-script-str [$arg1 $arg2 ...];"},
+		{Name: "set_by_lua_block", Contexts: rewriteContexts, Args: Block | Take1, Syntax: "set_by_lua_block $res { lua-script }"},
+		{Name: "set_by_lua_file", Contexts: rewriteContexts, Args: TwoMore, Syntax: "set_by_lua_file $res path-to-lua-script-file [$arg1 $arg2 ...];"},
+		{Name: "ssl_certificate_by_lua_block", Contexts: httpSrvContexts, Args: Block | NoArgs, Syntax: "ssl_certificate_by_lua_block { lua-script }"},
+		{Name: "ssl_certificate_by_lua_file", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_certificate_by_lua_file path-to-lua-script-file;"},
+		{Name: "ssl_client_hello_by_lua_block", Contexts: httpSrvContexts, Args: Block | NoArgs, Syntax: "ssl_client_hello_by_lua_block { lua-script }"},
+		{Name: "ssl_client_hello_by_lua_file", Contexts: httpSrvContexts, Args: Take1, Syntax: "ssl_client_hello_by_lua_file path-to-lua-script-file;"},
+		{Name: "ssl_session_fetch_by_lua_block", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "ssl_session_fetch_by_lua_block { lua-script }"},
+		{Name: "ssl_session_fetch_by_lua_file", Contexts: HTTPContext, Args: Take1, Syntax: "ssl_session_fetch_by_lua_file path-to-lua-script-file;"},
+		{Name: "ssl_session_store_by_lua_block", Contexts: HTTPContext, Args: Block | NoArgs, Syntax: "ssl_session_store_by_lua_block { lua-script }"},
+		{Name: "ssl_session_store_by_lua_file", Contexts: HTTPContext, Args: Take1, Syntax: "ssl_session_store_by_lua_file path-to-lua-script-file;"},
+	}},
+}
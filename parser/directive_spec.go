@@ -0,0 +1,310 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser/token"
+)
+
+// Context is a set of configuration contexts a directive can appear in
+type Context uint32
+
+const (
+	// MainContext the top level of nginx.conf
+	MainContext Context = 1 << iota
+	// EventsContext the events block
+	EventsContext
+	// HTTPContext the http block
+	HTTPContext
+	// ServerContext a server block in http
+	ServerContext
+	// LocationContext a location block
+	LocationContext
+	// IfInServerContext an if block in a server
+	IfInServerContext
+	// IfInLocationContext an if block in a location
+	IfInLocationContext
+	// LimitExceptContext a limit_except block
+	LimitExceptContext
+	// UpstreamContext an upstream block in http
+	UpstreamContext
+	// StreamContext the stream block
+	StreamContext
+	// StreamServerContext a server block in stream
+	StreamServerContext
+	// StreamUpstreamContext an upstream block in stream
+	StreamUpstreamContext
+	// MailContext the mail block
+	MailContext
+	// MailServerContext a server block in mail
+	MailServerContext
+	// MgmtContext the mgmt block of nginx plus
+	MgmtContext
+
+	// AnyContext any context, like the include directive
+	AnyContext Context = 1<<iota - 1
+)
+
+var contextName = map[Context]string{
+	MainContext:           "main",
+	EventsContext:         "events",
+	HTTPContext:           "http",
+	ServerContext:         "server",
+	LocationContext:       "location",
+	IfInServerContext:     "if in server",
+	IfInLocationContext:   "if in location",
+	LimitExceptContext:    "limit_except",
+	UpstreamContext:       "upstream",
+	StreamContext:         "stream",
+	StreamServerContext:   "stream server",
+	StreamUpstreamContext: "stream upstream",
+	MailContext:           "mail",
+	MailServerContext:     "mail server",
+	MgmtContext:           "mgmt",
+}
+
+// common context sets
+const (
+	httpContexts    = HTTPContext | ServerContext | LocationContext
+	httpIfContexts  = httpContexts | IfInLocationContext
+	httpSrvContexts = HTTPContext | ServerContext
+	rewriteContexts = ServerContext | IfInServerContext | LocationContext | IfInLocationContext
+	streamContexts  = StreamContext | StreamServerContext
+	mailContexts    = MailContext | MailServerContext
+)
+
+// String returns the names of the contexts in the set, like "http, server"
+func (c Context) String() string {
+	if c == AnyContext {
+		return "any"
+	}
+	var names []string
+	for bit := MainContext; bit <= MgmtContext; bit <<= 1 {
+		if c&bit != 0 {
+			names = append(names, contextName[bit])
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// ChildContext returns the context of the block opened by the named directive in the
+// parent context, 0 when the content of the block is not made of known directives (map, types, lua...)
+func ChildContext(parent Context, name string) Context {
+	switch {
+	case parent == MainContext:
+		switch name {
+		case "events":
+			return EventsContext
+		case "http":
+			return HTTPContext
+		case "stream":
+			return StreamContext
+		case "mail":
+			return MailContext
+		case "mgmt":
+			return MgmtContext
+		}
+	case name == "server":
+		switch parent {
+		case HTTPContext:
+			return ServerContext
+		case StreamContext:
+			return StreamServerContext
+		case MailContext:
+			return MailServerContext
+		}
+	case name == "upstream":
+		switch parent {
+		case HTTPContext:
+			return UpstreamContext
+		case StreamContext:
+			return StreamUpstreamContext
+		}
+	case name == "location":
+		if parent&(ServerContext|LocationContext) != 0 {
+			return LocationContext
+		}
+	case name == "if":
+		switch parent {
+		case ServerContext:
+			return IfInServerContext
+		case LocationContext:
+			return IfInLocationContext
+		}
+	case name == "limit_except":
+		if parent == LocationContext {
+			return LimitExceptContext
+		}
+	}
+	return 0
+}
+
+// Args is the set of argument counts a directive accepts, it mirrors the
+// NGX_CONF_* flags of the nginx sources
+type Args uint32
+
+const (
+	// NoArgs takes no argument
+	NoArgs Args = 1 << iota
+	// Take1 takes 1 argument
+	Take1
+	// Take2 takes 2 arguments
+	Take2
+	// Take3 takes 3 arguments
+	Take3
+	// Take4 takes 4 arguments
+	Take4
+	// Take5 takes 5 arguments
+	Take5
+	// Take6 takes 6 arguments
+	Take6
+	// Take7 takes 7 arguments
+	Take7
+	// Flag takes a single on or off argument
+	Flag
+	// OneMore takes at least 1 argument
+	OneMore
+	// TwoMore takes at least 2 arguments
+	TwoMore
+	// AnyArgs takes any number of arguments
+	AnyArgs
+	// Block must be followed by a block instead of a semicolon
+	Block
+)
+
+// common argument counts
+const (
+	Take12   = Take1 | Take2
+	Take13   = Take1 | Take3
+	Take23   = Take2 | Take3
+	Take34   = Take3 | Take4
+	Take123  = Take1 | Take2 | Take3
+	Take1234 = Take1 | Take2 | Take3 | Take4
+)
+
+// accepts reports whether n arguments are allowed
+func (a Args) accepts(n int) bool {
+	switch {
+	case a&AnyArgs != 0,
+		a&OneMore != 0 && n >= 1,
+		a&TwoMore != 0 && n >= 2,
+		a&Flag != 0 && n == 1,
+		a&NoArgs != 0 && n == 0:
+		return true
+	case n >= 1 && n <= 7:
+		return a&(Take1<<(n-1)) != 0
+	}
+	return false
+}
+
+// DirectiveSpec describes a directive: where it can be used, the arguments it takes and its docs
+type DirectiveSpec struct {
+	Name        string
+	Module      string // like ngx_http_proxy_module or lua-nginx-module
+	Syntax      string // as in the docs, like "proxy_pass URL;"
+	Default     string // default value, empty when there is none
+	Contexts    Context
+	Args        Args
+	Inheritable bool   // whether the value set in a block applies to its nested blocks
+	Since       string // nginx version the directive appeared in, empty when it is as old as its module
+}
+
+// DefaultParameters returns the parameters of the default value, nil when there is none or the
+// default only reads as documentation, like alternatives "8k|16k", an empty "" or a placeholder "..."
+func (s DirectiveSpec) DefaultParameters() []string {
+	if s.Default == "" || strings.ContainsAny(s.Default, "|;") || strings.Contains(s.Default, `"`) {
+		return nil
+	}
+	return strings.Fields(s.Default)
+}
+
+// DocURL returns the link to the documentation of the directive
+func (s DirectiveSpec) DocURL() string {
+	switch {
+	case s.Module == "":
+		return ""
+	case s.Module == "lua-nginx-module":
+		return "https://github.com/openresty/lua-nginx-module#" + s.Name
+	case s.Module == "ngx_event_core_module":
+		return "https://nginx.org/en/docs/ngx_core_module.html#" + s.Name
+	}
+	for _, dir := range []string{"http", "stream", "mail"} {
+		if strings.HasPrefix(s.Module, "ngx_"+dir+"_") {
+			return "https://nginx.org/en/docs/" + dir + "/" + s.Module + ".html#" + s.Name
+		}
+	}
+	return "https://nginx.org/en/docs/" + s.Module + ".html#" + s.Name
+}
+
+// LookupDirective returns the spec of the directive in the given context,
+// false if the directive is unknown or not allowed there
+func LookupDirective(name string, ctx Context) (DirectiveSpec, bool) {
+	for _, spec := range DirectiveSpecs[name] {
+		if spec.Contexts&ctx != 0 {
+			return spec, true
+		}
+	}
+	return DirectiveSpec{}, false
+}
+
+// RegisterDirective adds the spec of a third party directive, it becomes a valid directive
+func RegisterDirective(spec DirectiveSpec) {
+	DirectiveSpecs[spec.Name] = append(DirectiveSpecs[spec.Name], spec)
+	ValidDirectives[spec.Name] = spec.Name
+}
+
+// directives that can be set at several levels but are not inherited
+var notInherited = map[string]struct{}{
+	"internal_redirect": {},
+	"set_by_lua":        {},
+	"set_by_lua_block":  {},
+	"set_by_lua_file":   {},
+	"status_zone":       {},
+	"stub_status":       {},
+	"try_files":         {},
+}
+
+// inheritable reports whether a directive allowed at several levels (like http,
+// server and location) is inherited, blocks and rewrite module directives never are
+func inheritable(spec DirectiveSpec) bool {
+	if _, ok := notInherited[spec.Name]; ok || spec.Args&Block != 0 || spec.Module == "ngx_http_rewrite_module" {
+		return false
+	}
+	levels := spec.Contexts & (HTTPContext | ServerContext | LocationContext | StreamContext | StreamServerContext | MailContext | MailServerContext)
+	return levels&(levels-1) != 0
+}
+
+// validate checks that the directive is allowed in the context being parsed
+// and takes the right number of arguments, directives without a spec (like
+// custom directives) are not checked
+func (p *Parser) validate(d *config.Directive, nameToken token.Token, hasBlock bool) error {
+	if !p.opts.validateDirectives || p.context == 0 {
+		return nil
+	}
+	if _, ok := p.opts.customDirectives[d.Name]; ok {
+		return nil
+	}
+	if _, ok := DirectiveSpecs[d.Name]; !ok {
+		return nil
+	}
+	spec, ok := LookupDirective(d.Name, p.context)
+	if !ok {
+		return p.newError(InvalidContext, nameToken, fmt.Sprintf("'%s' directive is not allowed in %s context", d.Name, p.context), nil)
+	}
+
+	switch {
+	case spec.Args&Block != 0 && !hasBlock:
+		return p.newError(InvalidArguments, nameToken, fmt.Sprintf("'%s' directive has no opening '{'", d.Name), nil)
+	case spec.Args&Block == 0 && hasBlock:
+		return p.newError(InvalidArguments, nameToken, fmt.Sprintf("'%s' directive is not terminated by ';'", d.Name), nil)
+	case !spec.Args.accepts(len(d.Parameters)):
+		return p.newError(InvalidArguments, nameToken, fmt.Sprintf("invalid number of arguments in '%s' directive", d.Name), nil)
+	case spec.Args&Flag != 0:
+		if v := strings.ToLower(strings.Trim(d.Parameters[0].Value, `"'`)); v != "on" && v != "off" {
+			return p.newError(InvalidArguments, nameToken, fmt.Sprintf("invalid value '%s' in '%s' directive, it must be 'on' or 'off'", d.Parameters[0].Value, d.Name), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDirectiveValidation_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		conf    string
+		kind    ErrorKind
+		line    int
+		column  int
+		message string
+	}{
+		{
+			name:    "proxy_pass in main",
+			conf:    "user www;\nproxy_pass http://backend;",
+			kind:    InvalidContext,
+			line:    2,
+			column:  1,
+			message: "'proxy_pass' directive is not allowed in main context",
+		},
+		{
+			name:    "worker_processes in location",
+			conf:    "http {\n\tserver {\n\t\tlocation / {\n\t\t\tworker_processes 4;\n\t\t}\n\t}\n}",
+			kind:    InvalidContext,
+			line:    4,
+			column:  4,
+			message: "'worker_processes' directive is not allowed in location context",
+		},
+		{
+			name:    "location in if",
+			conf:    "http {\n\tserver {\n\t\tif ($a) {\n\t\t\tlocation / {}\n\t\t}\n\t}\n}",
+			kind:    InvalidContext,
+			line:    4,
+			column:  4,
+			message: "'location' directive is not allowed in if in server context",
+		},
+		{
+			name:    "http server directive in stream",
+			conf:    "stream {\n\tserver {\n\t\tserver_name_in_redirect on;\n\t}\n}",
+			kind:    InvalidContext,
+			line:    3,
+			column:  3,
+			message: "'server_name_in_redirect' directive is not allowed in stream server context",
+		},
+		{
+			name:    "too many arguments",
+			conf:    "events {\n\tworker_connections 1024 2048;\n}",
+			kind:    InvalidArguments,
+			line:    2,
+			column:  2,
+			message: "invalid number of arguments in 'worker_connections' directive",
+		},
+		{
+			name:    "missing arguments",
+			conf:    "http {\n\tserver {\n\t\tlisten;\n\t}\n}",
+			kind:    InvalidArguments,
+			line:    3,
+			column:  3,
+			message: "invalid number of arguments in 'listen' directive",
+		},
+		{
+			name:    "invalid flag",
+			conf:    "http {\n\tsendfile yes;\n}",
+			kind:    InvalidArguments,
+			line:    2,
+			column:  2,
+			message: "invalid value 'yes' in 'sendfile' directive, it must be 'on' or 'off'",
+		},
+		{
+			name:    "missing block",
+			conf:    "user www;\nhttp;",
+			kind:    InvalidArguments,
+			line:    2,
+			column:  1,
+			message: "'http' directive has no opening '{'",
+		},
+		{
+			name:    "unexpected block",
+			conf:    "http {\n\tgzip on {}\n}",
+			kind:    InvalidArguments,
+			line:    2,
+			column:  2,
+			message: "'gzip' directive is not terminated by ';'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStringParser(tt.conf, WithDirectiveValidation()).Parse()
+			var parseErr *ParseError
+			assert.Assert(t, errors.As(err, &parseErr), "expected a ParseError, got %v", err)
+			assert.Equal(t, parseErr.Kind, tt.kind)
+			assert.Equal(t, parseErr.Line, tt.line)
+			assert.Equal(t, parseErr.Column, tt.column)
+			assert.Equal(t, parseErr.Message, tt.message)
+		})
+	}
+}
+
+func TestDirectiveValidation_ValidConfig(t *testing.T) {
+	t.Parallel()
+	_, err := NewStringParser(`user www www;
+worker_processes auto;
+events {
+	worker_connections 1024;
+}
+http {
+	sendfile on;
+	upstream backend {
+		server 127.0.0.1:8080 weight=5;
+		keepalive 16;
+	}
+	map $http_upgrade $connection_upgrade {
+		default upgrade;
+		'' close;
+	}
+	server {
+		listen 80;
+		server_name example.com;
+		if ($host = www.example.com) {
+			return 301 https://example.com$request_uri;
+		}
+		location / {
+			limit_except GET {
+				deny all;
+			}
+			if ($request_method = POST) {
+				proxy_pass http://backend;
+			}
+			content_by_lua_block {
+				ngx.say("hi")
+			}
+		}
+	}
+}
+stream {
+	upstream dns {
+		server 127.0.0.1:53;
+	}
+	server {
+		listen 53 udp;
+		proxy_pass dns;
+	}
+}
+`, WithDirectiveValidation()).Parse()
+	assert.NilError(t, err)
+
+	p, err := NewParser("../testdata/full_conf/nginx.conf", WithDirectiveValidation(), WithIncludeParsing())
+	assert.NilError(t, err)
+	_, err = p.Parse()
+	assert.NilError(t, err)
+}
+
+func TestDirectiveValidation_Options(t *testing.T) {
+	t.Parallel()
+	// not validated by default
+	_, err := NewStringParser("proxy_pass http://backend;").Parse()
+	assert.NilError(t, err)
+
+	// a snippet can be validated in the context it is included in
+	_, err = NewStringParser("location / {\n\tproxy_pass http://backend;\n}", WithDirectiveValidation(), WithRootContext(ServerContext)).Parse()
+	assert.NilError(t, err)
+
+	// custom directives are only checked by name
+	_, err = NewStringParser("my_directive a b c;", WithDirectiveValidation(), WithCustomDirectives("my_directive")).Parse()
+	assert.NilError(t, err)
+
+	// included files are validated in the context of the include directive
+	p, err := NewParser("../testdata/include-glob/nginx.conf", WithDirectiveValidation(), WithIncludeParsing())
+	assert.NilError(t, err)
+	_, err = p.Parse()
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, InvalidContext)
+	assert.Equal(t, parseErr.File, "../testdata/include-glob/sites-enabled/example.com.conf")
+}
+
+func TestDirectiveValidation_ErrorRecovery(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser(`proxy_pass http://a;
+events {
+	use;
+}
+http {
+	gzip maybe;
+}
+`, WithDirectiveValidation(), WithErrorRecovery()).Parse()
+
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list))
+	assert.Equal(t, len(list), 3)
+	assert.Equal(t, list[0].Kind, InvalidContext)
+	assert.Equal(t, list[1].Kind, InvalidArguments)
+	assert.Equal(t, list[1].Line, 3)
+	assert.Equal(t, list[2].Kind, InvalidArguments)
+	assert.Equal(t, list[2].Line, 6)
+	// the directives are kept
+	assert.Equal(t, len(c.FindDirectives("proxy_pass")), 1)
+	assert.Equal(t, len(c.FindDirectives("gzip")), 1)
+}
+
+func TestDirectiveValidation_OneErrorPerStatement(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser(`http {
+	server {
+		location {
+			root /var/www;
+		}
+		my_block {
+			my_directive on;
+			other_directive;
+		}
+	}
+}
+`, WithDirectiveValidation(), WithErrorRecovery()).Parse()
+
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list))
+	// location without argument is not reported again by its wrapper, the
+	// directives of the unknown block are not reported
+	assert.Equal(t, len(list), 2)
+	assert.Equal(t, list[0].Kind, InvalidArguments)
+	assert.Equal(t, list[0].Line, 3)
+	assert.Equal(t, list[1].Kind, UnknownDirective)
+	assert.Equal(t, list[1].Line, 6)
+	// the directives are kept
+	assert.Equal(t, len(c.FindDirectives("location")), 1)
+	assert.Equal(t, len(c.FindDirectives("root")), 1)
+	assert.Equal(t, len(c.FindDirectives("my_directive")), 1)
+}
+
+func TestArgs_Accepts(t *testing.T) {
+	t.Parallel()
+	assert.Assert(t, NoArgs.accepts(0))
+	assert.Assert(t, !NoArgs.accepts(1))
+	assert.Assert(t, Take12.accepts(2))
+	assert.Assert(t, !Take12.accepts(3))
+	assert.Assert(t, Take1234.accepts(4))
+	assert.Assert(t, !OneMore.accepts(0))
+	assert.Assert(t, OneMore.accepts(9))
+	assert.Assert(t, !TwoMore.accepts(1))
+	assert.Assert(t, AnyArgs.accepts(0))
+	assert.Assert(t, (Block | NoArgs).accepts(0))
+}
+
+func TestContext_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, (HTTPContext | ServerContext | IfInLocationContext).String(), "http, server, if in location")
+	assert.Equal(t, AnyContext.String(), "any")
+}
+
+func TestDirectiveSpecs(t *testing.T) {
+	t.Parallel()
+	spec, ok := LookupDirective("proxy_pass", LocationContext)
+	assert.Assert(t, ok)
+	assert.Equal(t, spec.Module, "ngx_http_proxy_module")
+	assert.Equal(t, spec.Syntax, "proxy_pass URL;")
+	assert.Equal(t, spec.Default, "")
+	assert.Assert(t, !spec.Inheritable)
+	assert.Equal(t, spec.DocURL(), "https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_pass")
+
+	spec, ok = LookupDirective("proxy_pass", StreamServerContext)
+	assert.Assert(t, ok)
+	assert.Equal(t, spec.Module, "ngx_stream_proxy_module")
+	assert.Equal(t, spec.DocURL(), "https://nginx.org/en/docs/stream/ngx_stream_proxy_module.html#proxy_pass")
+
+	_, ok = LookupDirective("proxy_pass", MainContext)
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(DirectiveSpecs["proxy_pass"]), 2)
+
+	spec, _ = LookupDirective("sendfile", HTTPContext)
+	assert.Equal(t, spec.Default, "off")
+	assert.Assert(t, spec.Inheritable)
+
+	for name, want := range map[string][]string{
+		"sendfile":                {"off"},
+		"proxy_next_upstream":     {"error", "timeout"},
+		"proxy_pass":              nil,
+		"client_body_buffer_size": nil, // 8k|16k
+		"fastcgi_buffers":         nil, // 8 4k|8k
+		"listen":                  nil, // *:80 | *:8000
+		"server_name":             nil, // ""
+		"log_format":              nil, // combined "..."
+		"types":                   nil,
+	} {
+		spec, _ := LookupDirective(name, LocationContext|ServerContext|HTTPContext)
+		assert.DeepEqual(t, spec.DefaultParameters(), want)
+	}
+
+	spec, _ = LookupDirective("least_conn", UpstreamContext)
+	assert.Equal(t, spec.Since, "1.3.1")
+
+	spec, _ = LookupDirective("worker_connections", EventsContext)
+	assert.Equal(t, spec.DocURL(), "https://nginx.org/en/docs/ngx_core_module.html#worker_connections")
+
+	spec, _ = LookupDirective("content_by_lua_block", LocationContext)
+	assert.Equal(t, spec.Module, "lua-nginx-module")
+	assert.Equal(t, spec.DocURL(), "https://github.com/openresty/lua-nginx-module#content_by_lua_block")
+
+	for _, name := range []string{"if", "server", "location", "try_files", "rewrite"} {
+		for _, spec := range DirectiveSpecs[name] {
+			assert.Assert(t, !spec.Inheritable, name)
+		}
+	}
+
+	// every spec is a valid directive
+	for name, specs := range DirectiveSpecs {
+		assert.Equal(t, ValidDirectives[name], name)
+		for _, spec := range specs {
+			assert.Assert(t, spec.Syntax != "", name)
+		}
+	}
+	assert.Equal(t, ValidDirectives["default"], "default")
+}
+
+func TestRegisterDirective(t *testing.T) {
+	// not parallel, it changes the registry
+	RegisterDirective(DirectiveSpec{Name: "my_module_flag", Module: "ngx_http_my_module", Syntax: "my_module_flag on | off;", Contexts: httpContexts, Args: Flag})
+	_, err := NewStringParser("http {\n\tmy_module_flag on;\n}", WithDirectiveValidation()).Parse()
+	assert.NilError(t, err)
+	_, err = NewStringParser("http {\n\tmy_module_flag 1;\n}", WithDirectiveValidation()).Parse()
+	assert.ErrorContains(t, err, "it must be 'on' or 'off'")
+}
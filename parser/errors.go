@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tufanbarisyildirim/gonginx/parser/token"
+)
+
+// ErrorKind is the kind of a parse error
+type ErrorKind int
+
+const (
+	// UnknownDirective a directive that is neither a valid nor a custom directive
+	UnknownDirective ErrorKind = iota + 1
+	// UnexpectedToken a token that can not appear where it is
+	UnexpectedToken
+	// UnexpectedEOF the input ends before a block is closed
+	UnexpectedEOF
+	// UnclosedQuote a quoted string that is never closed
+	UnclosedQuote
+	// IncludeFailure an included file that can not be read
+	IncludeFailure
+	// InvalidDirective a directive that is rejected by its wrapper, like a location without a match
+	InvalidDirective
+	// UnclosedLuaCode a lua block that is never closed
+	UnclosedLuaCode
+	// InvalidContext a directive that is not allowed in the block it appears in
+	InvalidContext
+	// InvalidArguments a directive with a wrong number of arguments, or a missing or unexpected block
+	InvalidArguments
+)
+
+var errorKindName = map[ErrorKind]string{
+	UnknownDirective: "UnknownDirective",
+	UnexpectedToken:  "UnexpectedToken",
+	UnexpectedEOF:    "UnexpectedEOF",
+	UnclosedQuote:    "UnclosedQuote",
+	IncludeFailure:   "IncludeFailure",
+	InvalidDirective: "InvalidDirective",
+	UnclosedLuaCode:  "UnclosedLuaCode",
+	InvalidContext:   "InvalidContext",
+	InvalidArguments: "InvalidArguments",
+}
+
+// String returns the name of the error kind
+func (k ErrorKind) String() string {
+	return errorKindName[k]
+}
+
+// ParseError is returned by the parser for any malformed input,
+// it carries the position of the offending token.
+type ParseError struct {
+	Kind    ErrorKind
+	Message string
+	File    string // empty when parsing a string
+	Line    int
+	Column  int
+	Offset  int // byte offset in the file
+	Token   token.Token
+	Err     error // the underlying error, if any
+}
+
+// Error returns the error message with its position
+func (e *ParseError) Error() string {
+	msg := e.Message
+	if e.Err != nil && e.Err.Error() != msg {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err)
+	}
+	if e.File != "" {
+		return fmt.Sprintf("%s in %s on line %d, column %d", msg, e.File, e.Line, e.Column)
+	}
+	return fmt.Sprintf("%s on line %d, column %d", msg, e.Line, e.Column)
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList is the list of errors returned by a parser created WithErrorRecovery
+type ErrorList []*ParseError
+
+// Error returns the first error and the number of other errors
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Unwrap returns the errors in the list
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+// newError creates a parse error positioned at the given token
+func (p *Parser) newError(kind ErrorKind, tok token.Token, message string, err error) *ParseError {
+	return &ParseError{
+		Kind:    kind,
+		Message: message,
+		File:    p.lexer.file,
+		Line:    tok.Line,
+		Column:  tok.Column,
+		Offset:  tok.Offset,
+		Token:   tok,
+		Err:     err,
+	}
+}
+
+// illegalTokenError creates the error for the illegal token the lexer produced
+func (p *Parser) illegalTokenError() *ParseError {
+	if p.lexer.illegalKind == UnclosedLuaCode {
+		return p.newError(UnclosedLuaCode, p.currentToken, "unexpected end of file while scanning a lua code, maybe an unclosed lua code?", nil)
+	}
+	return p.newError(UnclosedQuote, p.currentToken, "unexpected end of file while scanning a string, maybe an unclosed quote?", nil)
+}
+
+// recover records the error and reports whether the parser can keep going
+func (p *Parser) recover(err error) bool {
+	if !p.opts.errorRecovery {
+		return false
+	}
+	var list ErrorList
+	var parseErr *ParseError
+	switch {
+	case errors.As(err, &list):
+		p.errors = append(p.errors, list...)
+	case errors.As(err, &parseErr):
+		// enclosing blocks may report the same error again, like an unexpected eof
+		if n := len(p.errors); n > 0 && p.errors[n-1].Kind == parseErr.Kind && p.errors[n-1].Offset == parseErr.Offset {
+			return true
+		}
+		p.errors = append(p.errors, parseErr)
+	default:
+		p.errors = append(p.errors, p.newError(InvalidDirective, p.currentToken, err.Error(), err))
+	}
+	return true
+}
+
+// synchronize skips the rest of a broken statement, up to the next ';' or
+// the '}' that closes it. It returns false when the current token must not
+// be consumed, because it ends the enclosing block or the file.
+func (p *Parser) synchronize() bool {
+	depth := 0
+	for {
+		switch {
+		case p.curTokenIs(token.EOF), p.curTokenIs(token.Illegal):
+			return false
+		case p.curTokenIs(token.BlockEnd):
+			if depth == 0 {
+				return false
+			}
+			depth--
+			if depth == 0 {
+				return true
+			}
+		case p.curTokenIs(token.BlockStart):
+			depth++
+		case p.curTokenIs(token.Semicolon):
+			if depth == 0 {
+				return true
+			}
+		}
+		p.nextToken()
+	}
+}
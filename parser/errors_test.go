@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/parser/token"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseError_Kinds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		conf   string
+		kind   ErrorKind
+		line   int
+		column int
+		offset int
+		token  token.Type
+	}{
+		{
+			name:   "unknown directive",
+			conf:   "server {\n\ta_directive param;\n}",
+			kind:   UnknownDirective,
+			line:   2,
+			column: 2,
+			offset: 10,
+			token:  token.Keyword,
+		},
+		{
+			name:   "unknown directive on the first line",
+			conf:   "a_directive param;",
+			kind:   UnknownDirective,
+			line:   1,
+			column: 1,
+			offset: 0,
+			token:  token.Keyword,
+		},
+		{
+			name:   "unclosed quote on the first line",
+			conf:   "user \"nginx;",
+			kind:   UnclosedQuote,
+			line:   1,
+			column: 6,
+			offset: 5,
+			token:  token.Illegal,
+		},
+		{
+			name:   "unexpected token",
+			conf:   "server {\n\tlisten 80\n}",
+			kind:   UnexpectedToken,
+			line:   3,
+			column: 1,
+			offset: 20,
+			token:  token.BlockEnd,
+		},
+		{
+			name:   "unexpected eof",
+			conf:   "server {\n\tlisten 80;\n",
+			kind:   UnexpectedEOF,
+			line:   3,
+			column: 1,
+			offset: 21,
+			token:  token.EOF,
+		},
+		{
+			name:   "unclosed quote",
+			conf:   "server {\n\treturn 200 \"hello;\n}",
+			kind:   UnclosedQuote,
+			line:   2,
+			column: 13,
+			offset: 21,
+			token:  token.Illegal,
+		},
+		{
+			name:   "unclosed quote as directive name",
+			conf:   "server {\n\t'listen 80;\n}",
+			kind:   UnclosedQuote,
+			line:   2,
+			column: 2,
+			offset: 10,
+			token:  token.Illegal,
+		},
+		{
+			name:   "unclosed lua code",
+			conf:   "location / {\n\tcontent_by_lua_block { local t = {}\n",
+			kind:   UnclosedLuaCode,
+			line:   2,
+			column: 24,
+			offset: 36,
+			token:  token.Illegal,
+		},
+		{
+			name:   "unclosed lua block",
+			conf:   "location / {\n\tset_by_lua_block $a { return 1",
+			kind:   UnexpectedEOF,
+			line:   2,
+			column: 32,
+			offset: 44,
+			token:  token.EOF,
+		},
+		{
+			name:   "invalid directive",
+			conf:   "server {\n\tlocation {}\n}",
+			kind:   InvalidDirective,
+			line:   2,
+			column: 2,
+			offset: 10,
+			token:  token.Keyword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStringParser(tt.conf).Parse()
+			var parseErr *ParseError
+			assert.Assert(t, errors.As(err, &parseErr), "expected a ParseError, got %v", err)
+			assert.Equal(t, parseErr.Kind, tt.kind)
+			assert.Equal(t, parseErr.Line, tt.line)
+			assert.Equal(t, parseErr.Column, tt.column)
+			assert.Equal(t, parseErr.Offset, tt.offset)
+			assert.Equal(t, parseErr.Token.Type, tt.token)
+			assert.Equal(t, parseErr.File, "")
+		})
+	}
+}
+
+func TestParseError_IncludedFile(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser("../testdata/include-error/nginx.conf", WithIncludeParsing())
+	assert.NilError(t, err)
+	_, err = p.Parse()
+
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, UnknownDirective)
+	assert.Equal(t, parseErr.File, "../testdata/include-error/bad.conf")
+	assert.Equal(t, parseErr.Line, 3)
+	assert.Equal(t, parseErr.Token.Literal, "lisen")
+	assert.Error(t, err, "unknown directive 'lisen' in ../testdata/include-error/bad.conf on line 3, column 5")
+}
+
+func TestParseError_IncludeFailure(t *testing.T) {
+	t.Parallel()
+	_, err := NewStringParser("http {\n\tinclude [.conf;\n}", WithIncludeParsing()).Parse()
+
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, IncludeFailure)
+	assert.Equal(t, parseErr.Line, 2)
+	assert.Assert(t, parseErr.Err != nil)
+}
+
+func TestParser_ErrorRecovery(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser(`user www;
+worker_procesess 4;
+events {
+	worker_connections 1024
+}
+http {
+	server {
+		listen 80;
+		location {
+			root /var/www;
+		}
+		server_name example.com;
+		if ($bad) { return 403 }
+		index index.html;
+	}
+	server {
+		listen 81;
+`, WithErrorRecovery()).Parse()
+
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list), "expected an ErrorList, got %v", err)
+	assert.Equal(t, len(list), 5)
+	assert.Equal(t, list[0].Kind, UnknownDirective)
+	assert.Equal(t, list[0].Line, 2)
+	assert.Equal(t, list[1].Kind, UnexpectedToken)
+	assert.Equal(t, list[1].Line, 5)
+	assert.Equal(t, list[2].Kind, InvalidDirective)
+	assert.Equal(t, list[2].Line, 9)
+	assert.Equal(t, list[3].Kind, UnexpectedToken)
+	assert.Equal(t, list[3].Line, 13)
+	assert.Equal(t, list[4].Kind, UnexpectedEOF)
+	assert.ErrorContains(t, err, "(and 4 more errors)")
+
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, UnknownDirective)
+
+	assert.Assert(t, c != nil)
+	assert.Equal(t, len(c.FindDirectives("worker_procesess")), 1)
+	assert.Equal(t, len(c.FindDirectives("worker_connections")), 0)
+	assert.Equal(t, len(c.FindDirectives("server")), 2)
+	assert.Equal(t, len(c.FindDirectives("listen")), 2)
+	assert.Equal(t, len(c.FindDirectives("location")), 1)
+	assert.Equal(t, len(c.FindDirectives("root")), 1)
+	assert.Equal(t, len(c.FindDirectives("server_name")), 1)
+	assert.Equal(t, len(c.FindDirectives("if")), 1)
+	assert.Equal(t, len(c.FindDirectives("return")), 0)
+	assert.Equal(t, len(c.FindDirectives("index")), 1)
+}
+
+func TestParser_ErrorRecoveryUnclosedQuote(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser("user www;\nserver {\n\treturn 200 \"hello;\n}", WithErrorRecovery()).Parse()
+
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list))
+	assert.Equal(t, len(list), 1)
+	assert.Equal(t, list[0].Kind, UnclosedQuote)
+	assert.Equal(t, len(c.FindDirectives("user")), 1)
+}
+
+func TestParser_ErrorRecoveryIncludedFile(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser("../testdata/include-error/nginx.conf", WithIncludeParsing(), WithErrorRecovery())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list))
+	assert.Equal(t, len(list), 1)
+	assert.Equal(t, list[0].File, "../testdata/include-error/bad.conf")
+	assert.Equal(t, len(c.FindDirectives("listen")), 1)
+	assert.Equal(t, len(c.FindDirectives("lisen")), 1)
+}
+
+func TestParser_NoErrorRecovery(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser("server {\n\tlisten 80;\n}", WithErrorRecovery()).Parse()
+	assert.NilError(t, err)
+	assert.Equal(t, len(c.FindDirectives("listen")), 1)
+}
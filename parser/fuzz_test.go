@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+)
+
+func FuzzParse(f *testing.F) {
+	files, err := filepath.Glob("../testdata/*/*.conf")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(data))
+	}
+	f.Add("server { listen 80; location / { return 200 'ok'; } }")
+	f.Add("upstream;\ninclude;\nhttp;\nlocation;\nserver;")
+	f.Add("content_by_lua_block { local t = { a = '}' } }")
+	f.Add(`log_format main "$remote_addr`)
+
+	f.Fuzz(func(t *testing.T, conf string) {
+		// none of these may panic, whatever the input is
+		c, err := NewStringParser(conf).Parse()
+		if err == nil {
+			_ = dumper.DumpConfig(c, dumper.IndentedStyle)
+			_ = c.FindUpstreams()
+		}
+		c, err = NewStringParser(conf, WithSkipValidDirectivesErr(), WithLossless()).Parse()
+		if err == nil {
+			_ = dumper.DumpConfig(c, dumper.LosslessStyle)
+		}
+	})
+}
@@ -15,8 +15,12 @@ type lexer struct {
 	file       string
 	line       int
 	column     int
+	offset     int
 	inLuaBlock bool
 	Latest     token.Token
+	// illegalKind tells why the lexer produced an Illegal token
+	illegalKind ErrorKind
+	source      *bytes.Buffer
 }
 
 // lex initializes a lexer from string conetnt
@@ -28,10 +32,28 @@ func lex(content string) *lexer {
 func newLexer(r io.Reader) *lexer {
 	return &lexer{
 		line:   1,
+		column: 1,
 		reader: bufio.NewReader(r),
 	}
 }
 
+// keepSource makes the lexer retain everything it reads, so the original
+// text between two offsets can be recovered later. It must be called before
+// the first token is scanned.
+func (s *lexer) keepSource() {
+	s.source = &bytes.Buffer{}
+	s.reader = bufio.NewReader(io.TeeReader(s.reader, s.source))
+}
+
+// text returns the original source between two byte offsets, it only works
+// when the lexer keeps its source.
+func (s *lexer) text(from, to int) string {
+	if s.source == nil || from >= to {
+		return ""
+	}
+	return string(s.source.Bytes()[from:to])
+}
+
 // Scan gives you next token
 func (s *lexer) scan() token.Token {
 	s.Latest = s.getNextToken()
@@ -138,6 +160,7 @@ func (s *lexer) NewToken(tokenType token.Type) token.Token {
 		Type:   tokenType,
 		Line:   s.line,
 		Column: s.column,
+		Offset: s.offset,
 	}
 }
 
@@ -174,7 +197,9 @@ func (s *lexer) scanLuaCode() token.Token {
 	for {
 		ch := s.read()
 		if ch == rune(token.EOF) {
-			panic("unexpected end of file while scanning a string, maybe an unclosed lua code?")
+			s.illegalKind = UnclosedLuaCode
+			ret.Type = token.Illegal
+			return ret.Lit(code.String())
 		}
 		if ch == '#' {
 			code.WriteRune(ch)
@@ -184,6 +209,7 @@ func (s *lexer) scanLuaCode() token.Token {
 			if len(stack) == 0 {
 				// the end of block
 				_ = s.reader.UnreadRune()
+				s.offset--
 				return ret.Lit(code.String())
 			}
 			// maybe it's lua table end, pop stack
@@ -214,7 +240,9 @@ func (s *lexer) scanQuotedString(delimiter rune) token.Token {
 		ch := s.read()
 
 		if ch == rune(token.EOF) {
-			panic("unexpected end of file while scanning a string, maybe an unclosed quote?")
+			s.illegalKind = UnclosedQuote
+			tok.Type = token.Illegal
+			return tok.Lit(buf.String())
 		}
 
 		if ch == '\\' && (s.peek() == delimiter) {
@@ -280,10 +308,11 @@ func (s *lexer) scanKeyword() token.Token {
 }
 
 func (s *lexer) read() rune {
-	ch, _, err := s.reader.ReadRune()
+	ch, size, err := s.reader.ReadRune()
 	if err != nil {
 		return rune(token.EOF)
 	}
+	s.offset += size
 
 	if ch == '\n' {
 		s.column = 1
@@ -36,77 +36,77 @@ directive "with a quoted string\t \r\n \\ with some escaped thing s\" good.";
 	actual := lex(conf).all()
 
 	var expect = token.Tokens{
-		{Type: token.EndOfLine, Literal: "\n", Line: 1, Column: 0},
-		{Type: token.Keyword, Literal: "server", Line: 2, Column: 1},
-		{Type: token.BlockStart, Literal: "{", Line: 2, Column: 8},
-		{Type: token.Comment, Literal: "# simple reverse-proxy", Line: 2, Column: 10},
-		{Type: token.EndOfLine, Literal: "\n", Line: 2, Column: 32},
-		{Type: token.Keyword, Literal: "listen", Line: 3, Column: 5},
-		{Type: token.Keyword, Literal: "80", Line: 3, Column: 18},
-		{Type: token.Semicolon, Literal: ";", Line: 3, Column: 20},
-		{Type: token.EndOfLine, Literal: "\n", Line: 3, Column: 21},
-		{Type: token.Keyword, Literal: "server_name", Line: 4, Column: 5},
-		{Type: token.Keyword, Literal: "gonginx.com", Line: 4, Column: 18},
-		{Type: token.Keyword, Literal: "www.gonginx.com", Line: 4, Column: 30},
-		{Type: token.Semicolon, Literal: ";", Line: 4, Column: 45},
-		{Type: token.EndOfLine, Literal: "\n", Line: 4, Column: 46},
-		{Type: token.Keyword, Literal: "access_log", Line: 5, Column: 5},
-		{Type: token.Keyword, Literal: "logs/gonginx.access.log", Line: 5, Column: 18},
-		{Type: token.Keyword, Literal: "main", Line: 5, Column: 43},
-		{Type: token.Semicolon, Literal: ";", Line: 5, Column: 47},
-		{Type: token.EndOfLine, Literal: "\n", Line: 5, Column: 48},
-		{Type: token.EndOfLine, Literal: "\n", Line: 6, Column: 1},
-		{Type: token.Comment, Literal: "# serve static files", Line: 7, Column: 5},
-		{Type: token.EndOfLine, Literal: "\n", Line: 7, Column: 25},
-		{Type: token.Keyword, Literal: "location", Line: 8, Column: 5},
-		{Type: token.Keyword, Literal: "~", Line: 8, Column: 14},
-		{Type: token.Keyword, Literal: "^/(images|javascript|js|css|flash|media|static)/", Line: 8, Column: 16},
-		{Type: token.BlockStart, Literal: "{", Line: 8, Column: 66},
-		{Type: token.EndOfLine, Literal: "\n", Line: 8, Column: 67},
-		{Type: token.Keyword, Literal: "root", Line: 9, Column: 4},
-		{Type: token.Keyword, Literal: "/var/www/virtual/gonginx/", Line: 9, Column: 12},
-		{Type: token.Semicolon, Literal: ";", Line: 9, Column: 37},
-		{Type: token.EndOfLine, Literal: "\n", Line: 9, Column: 38},
-		{Type: token.Keyword, Literal: "fastcgi_param", Line: 10, Column: 4},
-		{Type: token.Keyword, Literal: "SERVER_SOFTWARE", Line: 10, Column: 19},
-		{Type: token.Keyword, Literal: "nginx/$nginx_version/$server_name", Line: 10, Column: 38},
-		{Type: token.Semicolon, Literal: ";", Line: 10, Column: 71},
-		{Type: token.EndOfLine, Literal: "\n", Line: 10, Column: 72},
-		{Type: token.Keyword, Literal: "expires", Line: 11, Column: 7},
-		{Type: token.Keyword, Literal: "30d", Line: 11, Column: 15},
-		{Type: token.Semicolon, Literal: ";", Line: 11, Column: 18},
-		{Type: token.EndOfLine, Literal: "\n", Line: 11, Column: 19},
-		{Type: token.BlockEnd, Literal: "}", Line: 12, Column: 5},
-		{Type: token.EndOfLine, Literal: "\n", Line: 12, Column: 6},
-		{Type: token.EndOfLine, Literal: "\n", Line: 13, Column: 1},
-		{Type: token.Comment, Literal: "# pass requests for dynamic content", Line: 14, Column: 5},
-		{Type: token.EndOfLine, Literal: "\n", Line: 14, Column: 40},
-		{Type: token.Keyword, Literal: "location", Line: 15, Column: 5},
-		{Type: token.Keyword, Literal: "/", Line: 15, Column: 14},
-		{Type: token.BlockStart, Literal: "{", Line: 15, Column: 16},
-		{Type: token.EndOfLine, Literal: "\n", Line: 15, Column: 17},
-		{Type: token.Keyword, Literal: "proxy_pass", Line: 16, Column: 7},
-		{Type: token.Keyword, Literal: "http://127.0.0.1:8080", Line: 16, Column: 23},
-		{Type: token.Semicolon, Literal: ";", Line: 16, Column: 44},
-		{Type: token.EndOfLine, Literal: "\n", Line: 16, Column: 45},
-		{Type: token.Keyword, Literal: "proxy_set_header", Line: 17, Column: 7},
-		{Type: token.Keyword, Literal: "X-Real-IP", Line: 17, Column: 26},
-		{Type: token.Keyword, Literal: "$remote_addr", Line: 17, Column: 43},
-		{Type: token.Semicolon, Literal: ";", Line: 17, Column: 55},
-		{Type: token.EndOfLine, Literal: "\n", Line: 17, Column: 56},
-		{Type: token.BlockEnd, Literal: "}", Line: 18, Column: 5},
-		{Type: token.EndOfLine, Literal: "\n", Line: 18, Column: 6},
-		{Type: token.BlockEnd, Literal: "}", Line: 19, Column: 3},
-		{Type: token.EndOfLine, Literal: "\n", Line: 19, Column: 4},
-		{Type: token.Keyword, Literal: "include", Line: 20, Column: 1},
-		{Type: token.Keyword, Literal: "/etc/nginx/conf.d/*.conf", Line: 20, Column: 9},
-		{Type: token.Semicolon, Literal: ";", Line: 20, Column: 33},
-		{Type: token.EndOfLine, Literal: "\n", Line: 20, Column: 34},
-		{Type: token.Keyword, Literal: "directive", Line: 21, Column: 1},
-		{Type: token.QuotedString, Literal: "\"with a quoted string\\t \\r\\n \\\\ with some escaped thing s\\\" good.\"", Line: 21, Column: 11},
-		{Type: token.Semicolon, Literal: ";", Line: 21, Column: 77},
-		{Type: token.EndOfLine, Literal: "\n", Line: 21, Column: 78},
-		{Type: token.Comment, Literal: "#also cmment right before eof", Line: 22, Column: 1},
+		{Type: token.EndOfLine, Literal: "\n", Line: 1, Column: 1, Offset: 0},
+		{Type: token.Keyword, Literal: "server", Line: 2, Column: 1, Offset: 1},
+		{Type: token.BlockStart, Literal: "{", Line: 2, Column: 8, Offset: 8},
+		{Type: token.Comment, Literal: "# simple reverse-proxy", Line: 2, Column: 10, Offset: 10},
+		{Type: token.EndOfLine, Literal: "\n", Line: 2, Column: 32, Offset: 32},
+		{Type: token.Keyword, Literal: "listen", Line: 3, Column: 5, Offset: 37},
+		{Type: token.Keyword, Literal: "80", Line: 3, Column: 18, Offset: 50},
+		{Type: token.Semicolon, Literal: ";", Line: 3, Column: 20, Offset: 52},
+		{Type: token.EndOfLine, Literal: "\n", Line: 3, Column: 21, Offset: 53},
+		{Type: token.Keyword, Literal: "server_name", Line: 4, Column: 5, Offset: 58},
+		{Type: token.Keyword, Literal: "gonginx.com", Line: 4, Column: 18, Offset: 71},
+		{Type: token.Keyword, Literal: "www.gonginx.com", Line: 4, Column: 30, Offset: 83},
+		{Type: token.Semicolon, Literal: ";", Line: 4, Column: 45, Offset: 98},
+		{Type: token.EndOfLine, Literal: "\n", Line: 4, Column: 46, Offset: 99},
+		{Type: token.Keyword, Literal: "access_log", Line: 5, Column: 5, Offset: 104},
+		{Type: token.Keyword, Literal: "logs/gonginx.access.log", Line: 5, Column: 18, Offset: 117},
+		{Type: token.Keyword, Literal: "main", Line: 5, Column: 43, Offset: 142},
+		{Type: token.Semicolon, Literal: ";", Line: 5, Column: 47, Offset: 146},
+		{Type: token.EndOfLine, Literal: "\n", Line: 5, Column: 48, Offset: 147},
+		{Type: token.EndOfLine, Literal: "\n", Line: 6, Column: 1, Offset: 148},
+		{Type: token.Comment, Literal: "# serve static files", Line: 7, Column: 5, Offset: 153},
+		{Type: token.EndOfLine, Literal: "\n", Line: 7, Column: 25, Offset: 173},
+		{Type: token.Keyword, Literal: "location", Line: 8, Column: 5, Offset: 178},
+		{Type: token.Keyword, Literal: "~", Line: 8, Column: 14, Offset: 187},
+		{Type: token.Keyword, Literal: "^/(images|javascript|js|css|flash|media|static)/", Line: 8, Column: 16, Offset: 189},
+		{Type: token.BlockStart, Literal: "{", Line: 8, Column: 66, Offset: 239},
+		{Type: token.EndOfLine, Literal: "\n", Line: 8, Column: 67, Offset: 240},
+		{Type: token.Keyword, Literal: "root", Line: 9, Column: 4, Offset: 244},
+		{Type: token.Keyword, Literal: "/var/www/virtual/gonginx/", Line: 9, Column: 12, Offset: 252},
+		{Type: token.Semicolon, Literal: ";", Line: 9, Column: 37, Offset: 277},
+		{Type: token.EndOfLine, Literal: "\n", Line: 9, Column: 38, Offset: 278},
+		{Type: token.Keyword, Literal: "fastcgi_param", Line: 10, Column: 4, Offset: 282},
+		{Type: token.Keyword, Literal: "SERVER_SOFTWARE", Line: 10, Column: 19, Offset: 297},
+		{Type: token.Keyword, Literal: "nginx/$nginx_version/$server_name", Line: 10, Column: 38, Offset: 316},
+		{Type: token.Semicolon, Literal: ";", Line: 10, Column: 71, Offset: 349},
+		{Type: token.EndOfLine, Literal: "\n", Line: 10, Column: 72, Offset: 350},
+		{Type: token.Keyword, Literal: "expires", Line: 11, Column: 7, Offset: 357},
+		{Type: token.Keyword, Literal: "30d", Line: 11, Column: 15, Offset: 365},
+		{Type: token.Semicolon, Literal: ";", Line: 11, Column: 18, Offset: 368},
+		{Type: token.EndOfLine, Literal: "\n", Line: 11, Column: 19, Offset: 369},
+		{Type: token.BlockEnd, Literal: "}", Line: 12, Column: 5, Offset: 374},
+		{Type: token.EndOfLine, Literal: "\n", Line: 12, Column: 6, Offset: 375},
+		{Type: token.EndOfLine, Literal: "\n", Line: 13, Column: 1, Offset: 376},
+		{Type: token.Comment, Literal: "# pass requests for dynamic content", Line: 14, Column: 5, Offset: 381},
+		{Type: token.EndOfLine, Literal: "\n", Line: 14, Column: 40, Offset: 416},
+		{Type: token.Keyword, Literal: "location", Line: 15, Column: 5, Offset: 421},
+		{Type: token.Keyword, Literal: "/", Line: 15, Column: 14, Offset: 430},
+		{Type: token.BlockStart, Literal: "{", Line: 15, Column: 16, Offset: 432},
+		{Type: token.EndOfLine, Literal: "\n", Line: 15, Column: 17, Offset: 433},
+		{Type: token.Keyword, Literal: "proxy_pass", Line: 16, Column: 7, Offset: 440},
+		{Type: token.Keyword, Literal: "http://127.0.0.1:8080", Line: 16, Column: 23, Offset: 456},
+		{Type: token.Semicolon, Literal: ";", Line: 16, Column: 44, Offset: 477},
+		{Type: token.EndOfLine, Literal: "\n", Line: 16, Column: 45, Offset: 478},
+		{Type: token.Keyword, Literal: "proxy_set_header", Line: 17, Column: 7, Offset: 485},
+		{Type: token.Keyword, Literal: "X-Real-IP", Line: 17, Column: 26, Offset: 504},
+		{Type: token.Keyword, Literal: "$remote_addr", Line: 17, Column: 43, Offset: 521},
+		{Type: token.Semicolon, Literal: ";", Line: 17, Column: 55, Offset: 533},
+		{Type: token.EndOfLine, Literal: "\n", Line: 17, Column: 56, Offset: 534},
+		{Type: token.BlockEnd, Literal: "}", Line: 18, Column: 5, Offset: 539},
+		{Type: token.EndOfLine, Literal: "\n", Line: 18, Column: 6, Offset: 540},
+		{Type: token.BlockEnd, Literal: "}", Line: 19, Column: 3, Offset: 543},
+		{Type: token.EndOfLine, Literal: "\n", Line: 19, Column: 4, Offset: 544},
+		{Type: token.Keyword, Literal: "include", Line: 20, Column: 1, Offset: 545},
+		{Type: token.Keyword, Literal: "/etc/nginx/conf.d/*.conf", Line: 20, Column: 9, Offset: 553},
+		{Type: token.Semicolon, Literal: ";", Line: 20, Column: 33, Offset: 577},
+		{Type: token.EndOfLine, Literal: "\n", Line: 20, Column: 34, Offset: 578},
+		{Type: token.Keyword, Literal: "directive", Line: 21, Column: 1, Offset: 579},
+		{Type: token.QuotedString, Literal: "\"with a quoted string\\t \\r\\n \\\\ with some escaped thing s\\\" good.\"", Line: 21, Column: 11, Offset: 589},
+		{Type: token.Semicolon, Literal: ";", Line: 21, Column: 77, Offset: 655},
+		{Type: token.EndOfLine, Literal: "\n", Line: 21, Column: 78, Offset: 656},
+		{Type: token.Comment, Literal: "#also cmment right before eof", Line: 22, Column: 1, Offset: 657},
 	}
 	//assert.Equal(t, actual, 1)
 	tokenString, err := json.Marshal(actual)
@@ -122,18 +122,26 @@ directive "with a quoted string\t \r\n \\ with some escaped thing s\" good.";
 	assert.Equal(t, len(actual), len(expect))
 }
 
-func TestScanner_LexPanicUnclosedQuote(t *testing.T) {
+func TestScanner_LexUnclosedQuote(t *testing.T) {
 	t.Parallel()
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("The code did not panic")
-		}
-	}()
-
-	lex(`
+	tokens := lex(`
 	server { 
 	directive "with an unclosed quote \t \r\n \\ with some escaped thing s\" good.;
 	`).all()
+
+	last := tokens[len(tokens)-1]
+	assert.Equal(t, last.Type, token.Illegal)
+	assert.Equal(t, last.Line, 3)
+}
+
+func TestScanner_LexUnclosedLuaCode(t *testing.T) {
+	t.Parallel()
+	l := lex(`content_by_lua_block { local t = {`)
+	tokens := l.all()
+
+	last := tokens[len(tokens)-1]
+	assert.Equal(t, last.Type, token.Illegal)
+	assert.Equal(t, l.illegalKind, UnclosedLuaCode)
 }
 
 func TestScanner_LexLuaCode(t *testing.T) {
@@ -151,29 +159,29 @@ server {
 }`
 	actual := lex(conf).all()
 	var expect = token.Tokens{
-		{Type: token.EndOfLine, Literal: "\n", Line: 1, Column: 0},
-		{Type: token.Keyword, Literal: "server", Line: 2, Column: 1},
-		{Type: token.BlockStart, Literal: "{", Line: 2, Column: 8},
-		{Type: token.EndOfLine, Literal: "\n", Line: 2, Column: 9},
-		{Type: token.Keyword, Literal: "location", Line: 3, Column: 3},
-		{Type: token.Keyword, Literal: "=", Line: 3, Column: 12},
-		{Type: token.Keyword, Literal: "/foo", Line: 3, Column: 14},
-		{Type: token.BlockStart, Literal: "{", Line: 3, Column: 19},
-		{Type: token.EndOfLine, Literal: "\n", Line: 3, Column: 20},
-		{Type: token.Keyword, Literal: "rewrite_by_lua_block", Line: 4, Column: 5},
-		{Type: token.BlockStart, Literal: "{", Line: 4, Column: 26},
+		{Type: token.EndOfLine, Literal: "\n", Line: 1, Column: 1, Offset: 0},
+		{Type: token.Keyword, Literal: "server", Line: 2, Column: 1, Offset: 1},
+		{Type: token.BlockStart, Literal: "{", Line: 2, Column: 8, Offset: 8},
+		{Type: token.EndOfLine, Literal: "\n", Line: 2, Column: 9, Offset: 9},
+		{Type: token.Keyword, Literal: "location", Line: 3, Column: 3, Offset: 12},
+		{Type: token.Keyword, Literal: "=", Line: 3, Column: 12, Offset: 21},
+		{Type: token.Keyword, Literal: "/foo", Line: 3, Column: 14, Offset: 23},
+		{Type: token.BlockStart, Literal: "{", Line: 3, Column: 19, Offset: 28},
+		{Type: token.EndOfLine, Literal: "\n", Line: 3, Column: 20, Offset: 29},
+		{Type: token.Keyword, Literal: "rewrite_by_lua_block", Line: 4, Column: 5, Offset: 34},
+		{Type: token.BlockStart, Literal: "{", Line: 4, Column: 26, Offset: 55},
 		{Type: token.LuaCode, Literal: `
       res = ngx.location.capture("/memc",
         { args = { cmd = "incr", key = ngx.var.uri } } # comment contained unexpect '{'
          # comment contained unexpect '}' 
       )
       t = { key="foo", val="bar" }
-    `, Line: 4, Column: 27},
-		{Type: token.BlockEnd, Literal: "}", Line: 10, Column: 6},
-		{Type: token.EndOfLine, Literal: "\n", Line: 10, Column: 7},
-		{Type: token.BlockEnd, Literal: "}", Line: 11, Column: 3},
-		{Type: token.EndOfLine, Literal: "\n", Line: 11, Column: 4},
-		{Type: token.BlockEnd, Literal: "}", Line: 12, Column: 1},
+    `, Line: 4, Column: 27, Offset: 56},
+		{Type: token.BlockEnd, Literal: "}", Line: 10, Column: 6, Offset: 277},
+		{Type: token.EndOfLine, Literal: "\n", Line: 10, Column: 7, Offset: 278},
+		{Type: token.BlockEnd, Literal: "}", Line: 11, Column: 3, Offset: 281},
+		{Type: token.EndOfLine, Literal: "\n", Line: 11, Column: 4, Offset: 282},
+		{Type: token.BlockEnd, Literal: "}", Line: 12, Column: 1, Offset: 283},
 	}
 	tokenString, err := json.Marshal(actual)
 	assert.NilError(t, err)
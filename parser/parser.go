@@ -15,6 +15,13 @@ import (
 // Option parsing option
 type Option func(*Parser)
 
+// Wrapper turns a parsed directive into its typed form, like config.NewServer
+type Wrapper func(*config.Directive) (config.IDirective, error)
+
+// StatementParser parses a whole statement by itself, it is called with the
+// directive name as the current token and must stop on the ';' or '}' ending the statement
+type StatementParser func(p *Parser) (config.IDirective, error)
+
 type options struct {
 	parseInclude               bool
 	skipIncludeParsingErr      bool
@@ -22,6 +29,14 @@ type options struct {
 	customDirectives           map[string]string
 	skipValidSubDirectiveBlock map[string]struct{}
 	skipValidDirectivesErr     bool
+	lossless                   bool
+	errorRecovery              bool
+	validateDirectives         bool
+	rootContext                Context
+	blockWrappers              map[string]Wrapper
+	directiveWrappers          map[string]Wrapper
+	includeWrappers            map[string]Wrapper
+	statementParsers           map[string]StatementParser
 }
 
 func defaultOptions() options {
@@ -32,6 +47,7 @@ func defaultOptions() options {
 		customDirectives:           map[string]string{},
 		skipValidSubDirectiveBlock: map[string]struct{}{},
 		skipValidDirectivesErr:     false,
+		rootContext:                MainContext,
 	}
 }
 
@@ -43,13 +59,21 @@ type Parser struct {
 	currentToken      token.Token
 	followingToken    token.Token
 	parsedIncludes    map[*config.Include]*config.Config
-	statementParsers  map[string]func() (config.IDirective, error)
-	blockWrappers     map[string]func(*config.Directive) (config.IDirective, error)
-	directiveWrappers map[string]func(*config.Directive) (config.IDirective, error)
-	includeWrappers   map[string]func(*config.Directive) (config.IDirective, error)
+	statementParsers  map[string]StatementParser
+	blockWrappers     map[string]Wrapper
+	directiveWrappers map[string]Wrapper
+	includeWrappers   map[string]Wrapper
 
+	context       Context // context of the block being parsed, 0 if unknown
 	commentBuffer []string
 	file          *os.File
+	errors        ErrorList
+
+	// source offsets kept for lossless parsing
+	commentOffset int
+	blockEnd      int
+	headerEnd     int
+	closing       string
 }
 
 // WithSameOptions copy options from another parser
@@ -124,6 +148,102 @@ func WithSkipValidDirectivesErr() Option {
 	}
 }
 
+// WithLossless keeps the original whitespace, blank lines, comments and quoting
+// of every directive, so a lossless dump only re-renders the modified parts
+func WithLossless() Option {
+	return func(p *Parser) {
+		p.opts.lossless = true
+	}
+}
+
+// WithErrorRecovery keeps parsing after a syntax error, the parser skips to the next
+// ';' or '}' and Parse returns the best-effort config along with an ErrorList
+func WithErrorRecovery() Option {
+	return func(p *Parser) {
+		p.opts.errorRecovery = true
+	}
+}
+
+// WithDirectiveValidation rejects directives used outside of their allowed contexts,
+// with a wrong number of arguments or a missing block
+func WithDirectiveValidation() Option {
+	return func(p *Parser) {
+		p.opts.validateDirectives = true
+	}
+}
+
+// WithRootContext sets the context of the top level directives for the validation,
+// like ServerContext for a file included in a server block. Default is MainContext
+func WithRootContext(ctx Context) Option {
+	return func(p *Parser) {
+		p.opts.rootContext = ctx
+	}
+}
+
+// WithBlockWrapper wraps the named block directives with the given wrapper for this
+// parser only, instead of the one registered in config.BlockWrappers. A nil wrapper
+// keeps the directive as a plain *config.Directive
+func WithBlockWrapper(name string, wrapper Wrapper) Option {
+	return func(p *Parser) {
+		p.opts.blockWrappers = setWrapper(p.opts.blockWrappers, name, wrapper)
+	}
+}
+
+// WithDirectiveWrapper wraps the named directives ending with ';' with the given wrapper
+// for this parser only, instead of the one registered in config.DirectiveWrappers
+func WithDirectiveWrapper(name string, wrapper Wrapper) Option {
+	return func(p *Parser) {
+		p.opts.directiveWrappers = setWrapper(p.opts.directiveWrappers, name, wrapper)
+	}
+}
+
+// WithIncludeWrapper makes the named directive an include for this parser only,
+// the wrapper must return an *config.Include
+func WithIncludeWrapper(name string, wrapper Wrapper) Option {
+	return func(p *Parser) {
+		p.opts.includeWrappers = setWrapper(p.opts.includeWrappers, name, wrapper)
+	}
+}
+
+// WithStatementParser parses the named directives with your own statement parser
+func WithStatementParser(name string, sp StatementParser) Option {
+	return func(p *Parser) {
+		parsers := make(map[string]StatementParser, len(p.opts.statementParsers)+1)
+		for k, v := range p.opts.statementParsers {
+			parsers[k] = v
+		}
+		parsers[name] = sp
+		p.opts.statementParsers = parsers
+	}
+}
+
+// setWrapper returns a copy of the wrappers with the given one set,
+// so parsers sharing their options are not affected
+func setWrapper(wrappers map[string]Wrapper, name string, wrapper Wrapper) map[string]Wrapper {
+	copied := make(map[string]Wrapper, len(wrappers)+1)
+	for k, v := range wrappers {
+		copied[k] = v
+	}
+	copied[name] = wrapper
+	return copied
+}
+
+// mergeWrappers copies the global wrappers and applies the ones set by the options
+func mergeWrappers(global map[string]func(*config.Directive) (config.IDirective, error), local map[string]Wrapper) map[string]Wrapper {
+	wrappers := make(map[string]Wrapper, len(global)+len(local))
+	for name, w := range global {
+		wrappers[name] = w
+	}
+	for name, w := range local {
+		if w == nil {
+			delete(wrappers, name)
+			continue
+		}
+		wrappers[name] = w
+	}
+	return wrappers
+}
+
 // NewStringParser parses nginx conf from string
 func NewStringParser(str string, opts ...Option) *Parser {
 	return NewParserFromLexer(lex(str), opts...)
@@ -156,15 +276,36 @@ func NewParserFromLexer(lexer *lexer, opts ...Option) *Parser {
 		o(parser)
 	}
 
+	if parser.opts.lossless {
+		lexer.keepSource()
+	}
+	parser.context = parser.opts.rootContext
+
+	parser.blockWrappers = mergeWrappers(config.BlockWrappers, parser.opts.blockWrappers)
+	parser.directiveWrappers = mergeWrappers(config.DirectiveWrappers, parser.opts.directiveWrappers)
+	parser.includeWrappers = mergeWrappers(config.IncludeWrappers, parser.opts.includeWrappers)
+	parser.statementParsers = parser.opts.statementParsers
+
 	parser.nextToken()
 	parser.nextToken()
-
-	parser.blockWrappers = config.BlockWrappers
-	parser.directiveWrappers = config.DirectiveWrappers
-	parser.includeWrappers = config.IncludeWrappers
 	return parser
 }
 
+// CurrentToken returns the token being parsed, for statement parsers
+func (p *Parser) CurrentToken() token.Token {
+	return p.currentToken
+}
+
+// FollowingToken returns the token after the current one, for statement parsers
+func (p *Parser) FollowingToken() token.Token {
+	return p.followingToken
+}
+
+// NextToken moves to the following token, for statement parsers
+func (p *Parser) NextToken() {
+	p.nextToken()
+}
+
 func (p *Parser) nextToken() {
 	p.currentToken = p.followingToken
 	p.followingToken = p.lexer.scan()
@@ -182,13 +323,25 @@ func (p *Parser) followingTokenIs(t token.Type) bool {
 func (p *Parser) Parse() (*config.Config, error) {
 	parsedBlock, err := p.parseBlock(false, false)
 	if err != nil {
+		_ = p.Close()
 		return nil, err
 	}
 	c := &config.Config{
 		FilePath: p.lexer.file, //TODO: set filepath here,
 		Block:    parsedBlock,
 	}
+	if len(p.commentBuffer) > 0 {
+		// comments after the last directive of the file, they have no directive to belong to
+		c.ClosingComment = p.commentBuffer
+		p.commentBuffer = make([]string, 0)
+	}
+	if p.opts.lossless {
+		c.SetTrivia(&config.Trivia{Closing: p.lexer.text(p.blockEnd, p.lexer.offset)})
+	}
 	err = p.Close()
+	if err == nil && len(p.errors) > 0 {
+		err = p.errors
+	}
 	return c, err
 }
 
@@ -200,13 +353,25 @@ func (p *Parser) parseBlock(inBlock bool, isSkipValidDirective bool) (*config.Bl
 	}
 	var s config.IDirective
 	var err error
-	var line int
+	prevEnd := 0 // where the previous directive ends in the source
+	if inBlock {
+		prevEnd = p.currentToken.Offset + len(p.currentToken.Literal)
+	}
 parsingLoop:
 	for {
 		switch {
 		case p.curTokenIs(token.EOF):
 			if inBlock {
-				return nil, errors.New("unexpected eof in block")
+				err = p.newError(UnexpectedEOF, p.currentToken, "unexpected eof in block", nil)
+				if !p.recover(err) {
+					return nil, err
+				}
+			}
+			break parsingLoop
+		case p.curTokenIs(token.Illegal):
+			err = p.illegalTokenError()
+			if !p.recover(err) {
+				return nil, err
 			}
 			break parsingLoop
 		case p.curTokenIs(token.LuaCode):
@@ -215,9 +380,20 @@ parsingLoop:
 		case p.curTokenIs(token.BlockEnd):
 			break parsingLoop
 		case p.curTokenIs(token.Keyword) || p.curTokenIs(token.QuotedString):
+			start := p.currentToken.Offset
+			if len(p.commentBuffer) > 0 && p.commentOffset >= prevEnd {
+				start = p.commentOffset
+			}
+			line, column := p.currentToken.Line, p.currentToken.Column // the position the directive starts at
 			s, err = p.parseStatement(isSkipValidDirective)
 			if err != nil {
-				return nil, err
+				if !p.recover(err) {
+					return nil, err
+				}
+				if !p.synchronize() {
+					continue parsingLoop
+				}
+				break
 			}
 			if s.GetBlock() == nil {
 				s.SetParent(s)
@@ -225,43 +401,92 @@ parsingLoop:
 				// each directive should have a parent directive, not a block
 				// find each directive in the block and set the parent directive
 				b := s.GetBlock()
+				if block, ok := b.(*config.Block); ok {
+					block.SetParent(s)
+				}
 				for _, dir := range b.GetDirectives() {
 					dir.SetParent(s)
 				}
 			}
-			line = p.currentToken.Line
 			s.SetLine(line)
+			if holder, ok := s.(config.ColumnHolder); ok {
+				holder.SetColumn(column)
+			}
+			if p.opts.lossless {
+				prevEnd = p.setTrivia(s, prevEnd, start)
+			}
 			context.Directives = append(context.Directives, s)
 		case p.curTokenIs(token.Comment):
 			if p.opts.skipComments {
 				break
 			}
+			if len(p.commentBuffer) == 0 {
+				p.commentOffset = p.currentToken.Offset
+			}
 			// outline comment
 			p.commentBuffer = append(p.commentBuffer, p.currentToken.Literal)
 		}
 		p.nextToken()
 	}
 
+	p.blockEnd = prevEnd
 	return context, nil
 }
 
+// setTrivia attaches the original source text of the statement that has just
+// been parsed, and returns the offset where the statement ends.
+func (p *Parser) setTrivia(s config.IDirective, prevEnd, start int) int {
+	end := p.currentToken.Offset + len(p.currentToken.Literal)
+	holder, ok := s.(config.TriviaHolder)
+	if !ok {
+		return end
+	}
+	trivia := &config.Trivia{
+		Offset:  start,
+		Leading: p.lexer.text(prevEnd, start),
+		Text:    p.lexer.text(start, end),
+	}
+	if p.headerEnd > 0 {
+		trivia.Text = p.lexer.text(start, p.headerEnd)
+		trivia.Closing = p.closing
+	} else if p.curTokenIs(token.Comment) {
+		trivia.CommentAt = p.currentToken.Offset - start
+	}
+	trivia.Snapshot(s)
+	holder.SetTrivia(trivia)
+	return end
+}
+
 func (p *Parser) parseStatement(isSkipValidDirective bool) (config.IDirective, error) {
 	d := &config.Directive{
 		Name: p.currentToken.Literal,
 	}
+	nameToken := p.currentToken
+	p.headerEnd, p.closing = 0, ""
 
+	unknown := false // the directive is reported as unknown
 	if !p.opts.skipValidDirectivesErr && !isSkipValidDirective {
 		_, ok := ValidDirectives[d.Name]
 		_, ok2 := p.opts.customDirectives[d.Name]
 
 		if !ok && !ok2 {
-			return nil, fmt.Errorf("unknown directive '%s' on line %d, column %d", d.Name, p.currentToken.Line, p.currentToken.Column)
+			// keep parsing an unknown directive as a generic one when recovering
+			err := p.newError(UnknownDirective, p.currentToken, fmt.Sprintf("unknown directive '%s'", d.Name), nil)
+			if !p.recover(err) {
+				return nil, err
+			}
+			unknown = true
 		}
 	}
 
 	//if we have a special parser for the directive, we use it.
 	if sp, ok := p.statementParsers[d.Name]; ok {
-		return sp()
+		directive, err := sp(p)
+		var parseErr *ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			err = p.newError(InvalidDirective, nameToken, err.Error(), err)
+		}
+		return directive, err
 	}
 
 	// set outline comment
@@ -293,14 +518,33 @@ func (p *Parser) parseStatement(isSkipValidDirective bool) (config.IDirective, e
 					})
 				}
 			}
+			if err := p.validate(d, nameToken, false); err != nil {
+				if !p.recover(err) {
+					return nil, err
+				}
+				// one error per statement, keep it unwrapped
+				return d, nil
+			}
 			if iw, ok := p.includeWrappers[d.Name]; ok {
 				include, err := iw(d)
 				if err != nil {
+					err = p.newError(InvalidDirective, nameToken, err.Error(), err)
+					if p.recover(err) {
+						return d, nil
+					}
 					return nil, err
 				}
-				return p.ParseInclude(include.(*config.Include))
+				i, ok := include.(*config.Include)
+				if !ok {
+					return nil, p.newError(InvalidDirective, nameToken, fmt.Sprintf("include wrapper of '%s' must return an *config.Include", d.Name), nil)
+				}
+				directive, err := p.parseInclude(i, nameToken, isSkipValidDirective)
+				if err != nil && p.recover(err) {
+					return i, nil
+				}
+				return directive, err
 			} else if dw, ok := p.directiveWrappers[d.Name]; ok {
-				return dw(d)
+				return p.wrap(dw, d, nameToken)
 			}
 			return d, nil
 		} else if p.curTokenIs(token.Comment) {
@@ -312,7 +556,13 @@ func (p *Parser) parseStatement(isSkipValidDirective bool) (config.IDirective, e
 		} else if p.curTokenIs(token.BlockStart) {
 			_, blockSkip1 := SkipValidBlocks[d.Name]
 			_, blockSkip2 := p.opts.skipValidSubDirectiveBlock[d.Name]
-			isSkipBlockSubDirective := blockSkip1 || blockSkip2 || isSkipValidDirective
+			// the directives of an unknown block are not checked, it was reported already
+			isSkipBlockSubDirective := blockSkip1 || blockSkip2 || isSkipValidDirective || unknown
+			err := p.validate(d, nameToken, true)
+			if err != nil && !p.recover(err) {
+				return nil, err
+			}
+			invalid := err != nil
 
 			// Special handling for *_by_lua_block directives
 			if strings.HasSuffix(d.Name, "_by_lua_block") {
@@ -332,6 +582,9 @@ func (p *Parser) parseStatement(isSkipValidDirective bool) (config.IDirective, e
 				var luaCode strings.Builder
 
 				for braceCount > 0 && !p.curTokenIs(token.EOF) {
+					if p.curTokenIs(token.Illegal) {
+						return nil, p.illegalTokenError()
+					}
 					if p.curTokenIs(token.BlockStart) {
 						braceCount++
 					} else if p.curTokenIs(token.BlockEnd) {
@@ -356,36 +609,68 @@ func (p *Parser) parseStatement(isSkipValidDirective bool) (config.IDirective, e
 					p.nextToken()
 				}
 
+				if p.curTokenIs(token.EOF) {
+					return nil, p.newError(UnexpectedEOF, p.currentToken, "unexpected eof in lua block", nil)
+				}
+
 				b.LiteralCode = strings.TrimSpace(luaCode.String())
 				d.Block = b
 
 				// Use the appropriate wrapper based on the directive name
-				if strings.HasSuffix(d.Name, "_by_lua_block") {
-					return p.blockWrappers["_by_lua_block"](d)
+				if lw, ok := p.blockWrappers["_by_lua_block"]; ok {
+					return p.wrap(lw, d, nameToken)
 				}
 				return d, nil
 			}
 
+			headerEnd := p.currentToken.Offset + len(p.currentToken.Literal)
+			parent := p.context
+			p.context = ChildContext(parent, d.Name)
 			b, err := p.parseBlock(true, isSkipBlockSubDirective)
+			p.context = parent
 			if err != nil {
 				return nil, err
 			}
 			d.Block = b
+			p.headerEnd = headerEnd
+			p.closing = p.lexer.text(p.blockEnd, p.currentToken.Offset+len(p.currentToken.Literal))
 
-			if bw, ok := p.blockWrappers[d.Name]; ok {
-				return bw(d)
+			if bw, ok := p.blockWrappers[d.Name]; ok && !invalid {
+				return p.wrap(bw, d, nameToken)
 			}
 			return d, nil
 		} else if p.currentToken.Is(token.EndOfLine) {
 			continue
+		} else if p.curTokenIs(token.Illegal) {
+			return nil, p.illegalTokenError()
 		} else {
-			return nil, fmt.Errorf("unexpected token %s (%s) on line %d, column %d", p.currentToken.Type.String(), p.currentToken.Literal, p.currentToken.Line, p.currentToken.Column)
+			return nil, p.newError(UnexpectedToken, p.currentToken, fmt.Sprintf("unexpected token %s (%s)", p.currentToken.Type.String(), p.currentToken.Literal), nil)
 		}
 	}
 }
 
+// wrap turns a directive into its typed wrapper, errors are reported at the directive name
+func (p *Parser) wrap(wrapper Wrapper, d *config.Directive, nameToken token.Token) (config.IDirective, error) {
+	directive, err := wrapper(d)
+	if err != nil {
+		err = p.newError(InvalidDirective, nameToken, err.Error(), err)
+		if p.recover(err) {
+			// keep the directive unwrapped
+			return d, nil
+		}
+		return nil, err
+	}
+	return directive, nil
+}
+
 // ParseInclude just parse include confs
 func (p *Parser) ParseInclude(include *config.Include) (config.IDirective, error) {
+	return p.parseInclude(include, token.Token{Type: token.Keyword, Literal: include.GetName(), Line: include.GetLine()}, false)
+}
+
+// parseInclude parses the included files, skipValid is set when the include is in a block whose
+// directives are not checked, like the entries of a map
+func (p *Parser) parseInclude(include *config.Include, nameToken token.Token, skipValid bool) (config.IDirective, error) {
 	if p.opts.parseInclude {
 		includePath := include.IncludePath
 		if !filepath.IsAbs(includePath) {
@@ -393,7 +678,7 @@ func (p *Parser) ParseInclude(include *config.Include) (config.IDirective, error
 		}
 		includePaths, err := filepath.Glob(includePath)
 		if err != nil && !p.opts.skipIncludeParsingErr {
-			return nil, err
+			return nil, p.newError(IncludeFailure, nameToken, fmt.Sprintf("failed to include '%s'", include.IncludePath), err)
 		}
 		for _, includePath := range includePaths {
 			if conf, ok := p.parsedIncludes[include]; ok {
@@ -405,21 +690,26 @@ func (p *Parser) ParseInclude(include *config.Include) (config.IDirective, error
 				p.parsedIncludes[include] = nil
 			}
 
-			parser, err := NewParser(includePath,
+			opts := []Option{
 				WithSameOptions(p),
+				WithRootContext(p.context),
 				withParsedIncludes(p.parsedIncludes),
 				withConfigRoot(p.configRoot),
-			)
+			}
+			if skipValid {
+				opts = append(opts, WithSkipValidDirectivesErr())
+			}
+			parser, err := NewParser(includePath, opts...)
 
 			if err != nil {
 				if p.opts.skipIncludeParsingErr {
 					continue
 				}
-				return nil, err
+				return nil, p.newError(IncludeFailure, nameToken, fmt.Sprintf("failed to include '%s'", includePath), err)
 			}
 
 			config, err := parser.Parse()
-			if err != nil {
+			if err != nil && (config == nil || !p.recover(err)) {
 				return nil, err
 			}
 			//TODO: link parent config or include direcitve?
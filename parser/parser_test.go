@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/tufanbarisyildirim/gonginx/config"
@@ -59,7 +61,7 @@ func TestParser_LocationNoParam(t *testing.T) {
 	location  {} #location with no param
 	`)).Parse()
 
-	assert.Error(t, err, "no enough parameter for location")
+	assert.Error(t, err, "no enough parameter for location on line 3, column 2")
 }
 
 func TestParser_LocationTooManyParam(t *testing.T) {
@@ -69,7 +71,7 @@ func TestParser_LocationTooManyParam(t *testing.T) {
 	server { 
 	location one two three four {} #location with too many arguments
 	`)).Parse()
-	assert.Error(t, err, "too many arguments for location directive")
+	assert.Error(t, err, "too many arguments for location directive on line 3, column 2")
 }
 
 func TestParser_ParseValidLocations(t *testing.T) {
@@ -161,12 +163,12 @@ func TestParser_VariableAsParameter(t *testing.T) {
 
 	assert.NilError(t, err, "no error expected here")
 
-	d, ok := c.Directives[0].(*config.Directive)
-	assert.Assert(t, ok, "expecting a directive(http) as first statement")
-	assert.Equal(t, d.Name, "map", "first directive needs to be ")
-	assert.Equal(t, len(d.Parameters), 2, "map must have 2 parameters here")
-	assert.Equal(t, d.Parameters[0].GetValue(), "$host", "invalid first parameter")
-	assert.Equal(t, d.Parameters[1].GetValue(), "$clientname", "invalid second parameter")
+	d, ok := c.Directives[0].(*config.Map)
+	assert.Assert(t, ok, "expecting a map as first statement")
+	assert.Equal(t, d.GetName(), "map", "first directive needs to be ")
+	assert.Equal(t, len(d.GetParameters()), 2, "map must have 2 parameters here")
+	assert.Equal(t, d.GetParameters()[0].GetValue(), "$host", "invalid first parameter")
+	assert.Equal(t, d.GetParameters()[1].GetValue(), "$clientname", "invalid second parameter")
 }
 
 func TestParser_UnendedMultiParams(t *testing.T) {
@@ -717,3 +719,178 @@ local foo = if -- comment
     }
 }`, s)
 }
+
+type rateLimit struct {
+	*config.Directive
+}
+
+func TestParser_Wrappers(t *testing.T) {
+	t.Parallel()
+	conf := `http {
+	server {
+		listen 80;
+		limit_req zone=one burst=5;
+	}
+}`
+	c, err := NewStringParser(conf,
+		WithBlockWrapper("server", nil),
+		WithDirectiveWrapper("limit_req", func(d *config.Directive) (config.IDirective, error) {
+			return &rateLimit{Directive: d}, nil
+		}),
+	).Parse()
+	assert.NilError(t, err)
+	_, ok := c.FindDirectives("server")[0].(*config.Directive)
+	assert.Assert(t, ok, "server must not be wrapped")
+	_, ok = c.FindDirectives("limit_req")[0].(*rateLimit)
+	assert.Assert(t, ok, "limit_req must be wrapped")
+
+	// other parsers are not affected
+	c, err = NewStringParser(conf).Parse()
+	assert.NilError(t, err)
+	_, ok = c.FindDirectives("server")[0].(*config.Server)
+	assert.Assert(t, ok)
+	_, ok = c.FindDirectives("limit_req")[0].(*config.Directive)
+	assert.Assert(t, ok)
+	_, ok = config.BlockWrappers["server"]
+	assert.Assert(t, ok)
+
+	// wrapper errors are parse errors
+	_, err = NewStringParser(conf, WithDirectiveWrapper("listen", func(d *config.Directive) (config.IDirective, error) {
+		return nil, errors.New("no listen")
+	})).Parse()
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, InvalidDirective)
+	assert.Equal(t, parseErr.Line, 3)
+}
+
+func TestParser_WrappersInIncludes(t *testing.T) {
+	t.Parallel()
+	p, err := NewParser("../testdata/include-glob/nginx.conf", WithIncludeParsing(), WithBlockWrapper("server", nil))
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	servers := c.FindDirectives("server")
+	assert.Assert(t, len(servers) > 0)
+	for _, server := range servers {
+		_, ok := server.(*config.Directive)
+		assert.Assert(t, ok, "included servers must not be wrapped")
+	}
+}
+
+func TestParser_StatementParser(t *testing.T) {
+	t.Parallel()
+	// keeps every token of the statement as a parameter, up to the closing brace
+	raw := func(p *Parser) (config.IDirective, error) {
+		d := &config.Directive{Name: p.CurrentToken().Literal}
+		for p.NextToken(); !p.CurrentToken().Is(token.BlockEnd); p.NextToken() {
+			if p.CurrentToken().Is(token.EOF) {
+				return nil, errors.New("unclosed raw block")
+			}
+			if !p.CurrentToken().Is(token.BlockStart) {
+				d.Parameters = append(d.Parameters, config.Parameter{Value: p.CurrentToken().Literal})
+			}
+		}
+		return d, nil
+	}
+	c, err := NewStringParser("raw { a b; c }\nuser www;", WithCustomDirectives("raw"), WithStatementParser("raw", raw)).Parse()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, c.FindDirectives("raw")[0].GetParameters(), []config.Parameter{{Value: "a"}, {Value: "b"}, {Value: ";"}, {Value: "c"}})
+	assert.Equal(t, len(c.FindDirectives("user")), 1)
+
+	_, err = NewStringParser("raw { a b", WithCustomDirectives("raw"), WithStatementParser("raw", raw)).Parse()
+	var parseErr *ParseError
+	assert.Assert(t, errors.As(err, &parseErr))
+	assert.Equal(t, parseErr.Kind, InvalidDirective)
+	assert.Equal(t, parseErr.Message, "unclosed raw block")
+}
+
+func TestParser_WrappersConcurrency(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(wrap bool) {
+			defer wg.Done()
+			var opts []Option
+			if !wrap {
+				opts = append(opts, WithBlockWrapper("location", nil))
+			}
+			c, err := NewStringParser("server {\n\tlocation / {}\n}", opts...).Parse()
+			assert.NilError(t, err)
+			_, ok := c.FindDirectives("location")[0].(*config.Location)
+			assert.Equal(t, ok, wrap)
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
+
+func TestParser_Stream(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser(`http {
+	upstream web {
+		server 127.0.0.1:8080;
+	}
+}
+stream {
+	upstream dns {
+		server 127.0.0.1:53 max_fails=3;
+	}
+	server {
+		listen 53 udp;
+		proxy_pass dns;
+	}
+}`, WithDirectiveValidation()).Parse()
+	assert.NilError(t, err)
+
+	streams := c.FindStreams()
+	assert.Equal(t, len(streams), 1)
+	servers := streams[0].GetServers()
+	assert.Equal(t, len(servers), 1)
+	assert.Equal(t, servers[0].GetProxyPass(), "dns")
+	assert.Equal(t, servers[0].GetListens()[0].GetParameters()[1].GetValue(), "udp")
+
+	// http and stream upstreams are told apart
+	upstreams := c.FindUpstreams()
+	assert.Equal(t, len(upstreams), 1)
+	assert.Equal(t, upstreams[0].UpstreamName, "web")
+	streamUpstreams := c.FindStreamUpstreams()
+	assert.Equal(t, len(streamUpstreams), 1)
+	assert.Equal(t, streamUpstreams[0].UpstreamName, "dns")
+	assert.Equal(t, streamUpstreams[0].UpstreamServers[0].Parameters["max_fails"], "3")
+	assert.Equal(t, streams[0].FindUpstream("dns"), streamUpstreams[0])
+
+	assert.Equal(t, dumper.DumpConfig(c, dumper.IndentedStyle), `http {
+    upstream web {
+        server 127.0.0.1:8080;
+    }
+}
+stream {
+    upstream dns {
+        server 127.0.0.1:53 max_fails=3;
+    }
+    server {
+        listen 53 udp;
+        proxy_pass dns;
+    }
+}`)
+}
+
+func TestParser_Columns(t *testing.T) {
+	t.Parallel()
+	c, err := NewStringParser("user nginx;\nhttp {\n  upstream web {\n    server 127.0.0.1:8080;\n  }\n  server { listen 80; }\n}\n").Parse()
+	assert.NilError(t, err)
+	column := func(d config.IDirective) int {
+		holder, ok := d.(config.ColumnHolder)
+		assert.Assert(t, ok, d.GetName())
+		return holder.GetColumn()
+	}
+	assert.Equal(t, column(c.FindDirectives("user")[0]), 1)
+	assert.Equal(t, column(c.FindDirectives("http")[0]), 1)
+	upstream := c.FindUpstreams()[0]
+	assert.Equal(t, column(upstream), 3)
+	assert.Equal(t, column(upstream.UpstreamServers[0]), 5)
+	listen := c.FindDirectives("listen")[0]
+	assert.Equal(t, listen.GetLine(), 6)
+	assert.Equal(t, column(listen), 12)
+}
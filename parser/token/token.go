@@ -49,6 +49,7 @@ var (
 		EndOfLine:    "EndOfLine",
 		Illegal:      "Illegal",
 		Regex:        "Regex",
+		LuaCode:      "LuaCode",
 	}
 )
 
@@ -63,6 +64,7 @@ type Token struct {
 	Literal string
 	Line    int
 	Column  int
+	Offset  int // byte offset of the first character in the source
 }
 
 func (t Token) String() string {
@@ -0,0 +1,259 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"github.com/tufanbarisyildirim/gonginx/query"
+)
+
+// Apply applies the operations in order to a copy of the config and returns it, the config
+// itself is not changed. It returns Conflicts and no config if an operation cannot be applied:
+// the selector matches no directive or not the expected number, an added directive is already
+// in the block, the parameters of a directive are not the expected ones or are set twice.
+func (p *Patch) Apply(cfg *config.Config) (*config.Config, error) {
+	a := &applier{cfg: cfg.Clone(), set: map[config.IDirective]int{}}
+	for i, op := range p.Operations {
+		if reason := a.apply(i, op); reason != "" {
+			a.conflicts = append(a.conflicts, &Conflict{Index: i, Operation: op, Reason: reason})
+		}
+	}
+	if len(a.conflicts) > 0 {
+		return nil, a.conflicts
+	}
+	return a.cfg, nil
+}
+
+// DryRun applies the patch like Apply and returns the dump of the resulting config
+func (p *Patch) DryRun(cfg *config.Config, style *dumper.Style) (string, error) {
+	patched, err := p.Apply(cfg)
+	if err != nil {
+		return "", err
+	}
+	return dumper.DumpConfig(patched, style), nil
+}
+
+type applier struct {
+	cfg       *config.Config
+	set       map[config.IDirective]int // the directives whose parameters are set, by operation
+	conflicts Conflicts
+}
+
+// apply applies an operation, it returns the reason why it cannot be applied
+func (a *applier) apply(i int, op Operation) string {
+	if op.Op == Add && op.Select == "" {
+		return a.add(op, a.cfg.Block, nil)
+	}
+	if op.Select == "" {
+		return "missing selector"
+	}
+	matches, err := query.Find(a.cfg, op.Select)
+	switch {
+	case err != nil:
+		return err.Error()
+	case len(matches) == 0:
+		return "no directive matches"
+	case op.Expect > 0 && len(matches) != op.Expect:
+		return fmt.Sprintf("%d directives match, %d expected", len(matches), op.Expect)
+	}
+
+	for _, m := range matches {
+		var reason string
+		switch op.Op {
+		case Add:
+			switch op.Where {
+			case "":
+				if m.Directive.GetBlock() == nil {
+					return fmt.Sprintf("%s has no block", m)
+				}
+				reason = a.add(op, m.Directive.GetBlock(), nil)
+			case "before", "after":
+				reason = a.add(op, m.Block, m.Directive)
+			default:
+				return fmt.Sprintf("invalid where %q, it must be before or after", op.Where)
+			}
+		case Remove:
+			if block, ok := m.Block.(config.MutableBlock); !ok {
+				reason = config.ErrImmutableBlock.Error()
+			} else if err := block.RemoveDirective(m.Directive); err != nil {
+				reason = err.Error()
+			}
+		case Replace:
+			reason = a.replace(op, m)
+		case Set:
+			reason = a.setArgs(i, op, m)
+		default:
+			return fmt.Sprintf("invalid op %q", op.Op)
+		}
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// add adds the directives of the operation at the end of the block, or next to ref
+func (a *applier) add(op Operation, b config.IBlock, ref config.IDirective) string {
+	block, ok := b.(config.MutableBlock)
+	if !ok {
+		return config.ErrImmutableBlock.Error()
+	}
+	directives, err := parse(op.Value)
+	if err != nil {
+		return err.Error()
+	}
+	for _, d := range directives {
+		d = convert(block, d)
+		for _, existing := range block.GetDirectives() {
+			if config.Equal(existing, d, config.EqualOptions{IgnoreComments: true, IgnoreLines: true}) {
+				return fmt.Sprintf("%s is already in the block", head(d))
+			}
+		}
+		switch {
+		case ref == nil:
+			block.AddDirective(d)
+		case op.Where == "before":
+			err = block.InsertBefore(ref, d)
+		default:
+			err = block.InsertAfter(ref, d)
+			ref = d
+		}
+		if err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+func (a *applier) replace(op Operation, m query.Match) string {
+	directives, err := parse(op.Value)
+	if err != nil {
+		return err.Error()
+	}
+	if len(directives) != 1 {
+		return fmt.Sprintf("value must be one directive, got %d", len(directives))
+	}
+	block, ok := m.Block.(config.MutableBlock)
+	if !ok {
+		return config.ErrImmutableBlock.Error()
+	}
+	if err := block.ReplaceDirective(m.Directive, convert(m.Block, directives[0])); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func (a *applier) setArgs(i int, op Operation, m query.Match) string {
+	if prev, ok := a.set[m.Directive]; ok {
+		return fmt.Sprintf("%s is already set by operation %d", m, prev)
+	}
+	if len(op.From) > 0 && strings.Join(config.Values(m.Directive.GetParameters()), " ") != strings.Join(op.From, " ") {
+		return fmt.Sprintf("%s does not have the expected parameters %q", m, strings.Join(op.From, " "))
+	}
+	if err := setParameters(m.Directive, op.Args); err != nil {
+		return err.Error()
+	}
+	a.set[m.Directive] = i
+	return ""
+}
+
+// parse parses the nginx config text of an operation
+func parse(value string) ([]config.IDirective, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+	c, err := parser.NewStringParser(value, parser.WithSkipValidDirectivesErr()).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	return c.Directives, nil
+}
+
+// convert wraps a directive added to a block like the parser does in its context: a server
+// of an upstream is an upstream server, a server or an upstream block of a stream is a
+// stream server or a stream upstream
+func convert(block config.IBlock, d config.IDirective) config.IDirective {
+	switch block.(type) {
+	case *config.Stream:
+		stream, err := config.NewStream(&config.Directive{Name: "stream", Block: &config.Block{Directives: []config.IDirective{d}}})
+		if err == nil {
+			return stream.Directives[0]
+		}
+	case *config.Upstream, *config.StreamUpstream:
+		if d.GetName() == "server" && d.GetBlock() == nil {
+			if uss, err := config.NewUpstreamServer(d); err == nil {
+				return uss
+			}
+		}
+	}
+	return d
+}
+
+// setParameters sets the parameters of the directive types that have settable parameters
+func setParameters(d config.IDirective, args []string) error {
+	parameters := make([]config.Parameter, 0, len(args))
+	for _, arg := range args {
+		parameters = append(parameters, config.Parameter{Value: arg})
+	}
+	if su, ok := d.(*config.StreamUpstream); ok {
+		d = su.Upstream
+	}
+	switch d := d.(type) {
+	case *config.Directive:
+		d.Parameters = parameters
+	case *config.Location:
+		l, err := config.NewLocation(&config.Directive{Name: d.Name, Parameters: parameters})
+		if err != nil {
+			return err
+		}
+		d.Directive.Parameters = parameters
+		d.Modifier, d.Match = l.Modifier, l.Match
+	case *config.UpstreamServer:
+		if len(args) == 0 {
+			return fmt.Errorf("an upstream server needs an address")
+		}
+		uss, _ := config.NewUpstreamServer(&config.Directive{Name: "server", Parameters: parameters})
+		d.Address, d.Flags, d.Parameters = uss.Address, uss.Flags, uss.Parameters
+	case *config.Upstream:
+		if len(args) != 1 {
+			return fmt.Errorf("an upstream has one parameter, its name")
+		}
+		d.UpstreamName = args[0]
+	case *config.Map:
+		if len(args) != 2 {
+			return fmt.Errorf("a map has two parameters, its source and its variable")
+		}
+		d.Source, d.Variable = args[0], args[1]
+	case *config.SplitClients:
+		if len(args) != 2 {
+			return fmt.Errorf("a split_clients has two parameters, its source and its variable")
+		}
+		d.Source, d.Variable = args[0], args[1]
+	case *config.Geo:
+		if len(args) == 0 || len(args) > 2 {
+			return fmt.Errorf("a geo has a variable and an optional address")
+		}
+		d.Address, d.Variable = "", args[len(args)-1]
+		if len(args) == 2 {
+			d.Address = args[0]
+		}
+	case *config.MapEntry:
+		if d.Include != nil || len(args) > 1 {
+			return fmt.Errorf("the entry %s has one value", d.Key)
+		}
+		d.Value = ""
+		if len(args) == 1 {
+			d.Value = args[0]
+		}
+	default:
+		return fmt.Errorf("the parameters of %s cannot be set", d.GetName())
+	}
+	return nil
+}
+
+func head(d config.IDirective) string {
+	return strings.Join(append([]string{d.GetName()}, config.Values(d.GetParameters())...), " ")
+}
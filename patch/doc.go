@@ -0,0 +1,3 @@
+// Package patch applies declarative patch documents to a config. Each operation of a
+// patch addresses directives with a query selector, like an overlay per environment.
+package patch
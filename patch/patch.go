@@ -0,0 +1,92 @@
+package patch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Op is the kind of an operation
+type Op string
+
+const (
+	// Add parses Value and adds the directives in the block of each matched directive,
+	// or before or after it with Where, in the main context when Select is empty
+	Add Op = "add"
+	// Remove removes the matched directives
+	Remove Op = "remove"
+	// Replace puts the directive parsed from Value in place of each matched directive
+	Replace Op = "replace"
+	// Set sets the parameters of the matched directives to Args
+	Set Op = "set"
+)
+
+// Operation is a change of a patch
+type Operation struct {
+	Op     Op     `json:"op" yaml:"op"`
+	Select string `json:"select,omitempty" yaml:"select,omitempty"` // a query selector, like "http > upstream[args=api]"
+	// Value is nginx config text for add and replace, like "server 10.0.0.5:8080 weight=3;"
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Where adds the directives "before" or "after" the matched directive instead of in its block
+	Where string `json:"where,omitempty" yaml:"where,omitempty"`
+	// Args are the new parameters for set
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// From are the parameters the directive must have for set, not checked when empty
+	From []string `json:"from,omitempty" yaml:"from,omitempty"`
+	// Expect is the number of directives the selector must match, at least one when zero
+	Expect int `json:"expect,omitempty" yaml:"expect,omitempty"`
+}
+
+// Patch is a list of operations applied in order
+type Patch struct {
+	Operations []Operation `json:"operations" yaml:"operations"`
+}
+
+// Decode reads a JSON or YAML patch document, unknown fields are errors
+func Decode(data []byte) (*Patch, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	p := &Patch{}
+	if err := dec.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	return p, nil
+}
+
+// Conflict is an operation that cannot be applied
+type Conflict struct {
+	Index     int // the position of the operation in the patch, from 0
+	Operation Operation
+	Reason    string
+}
+
+// Error returns the operation and the reason of the conflict
+func (c *Conflict) Error() string {
+	return fmt.Sprintf("operation %d (%s %q): %s", c.Index, c.Operation.Op, c.Operation.Select, c.Reason)
+}
+
+// Conflicts is the list of conflicts returned by Apply
+type Conflicts []*Conflict
+
+// Error returns the first conflict and the number of other conflicts
+func (l Conflicts) Error() string {
+	switch len(l) {
+	case 0:
+		return "no conflicts"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more conflicts)", l[0], len(l)-1)
+}
+
+// Unwrap returns the conflicts in the list
+func (l Conflicts) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
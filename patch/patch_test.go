@@ -0,0 +1,237 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+const conf = `worker_processes 2;
+events {
+    worker_connections 1024;
+}
+http {
+    upstream api {
+        server 10.0.0.3:8080;
+        keepalive 16;
+    }
+    server {
+        listen 80;
+        server_name example.com;
+        location /api {
+            proxy_pass http://api;
+        }
+    }
+}
+`
+
+func parseConf(t *testing.T) *config.Config {
+	t.Helper()
+	c, err := parser.NewStringParser(conf, parser.WithLossless()).Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+func TestPatch_Apply(t *testing.T) {
+	t.Parallel()
+	p, err := Decode([]byte(`
+operations:
+  - op: set
+    select: events > worker_connections
+    from: ["1024"]
+    args: ["4096"]
+  - op: add
+    select: http > upstream[args=api]
+    value: server 10.0.0.5:8080 weight=3;
+  - op: add
+    select: location[match=/api] > proxy_pass
+    where: before
+    value: |
+      proxy_set_header Host $host;
+      proxy_set_header X-Real-IP $remote_addr;
+  - op: replace
+    select: server[server_name=example.com] > listen
+    value: listen 443 ssl;
+  - op: remove
+    select: keepalive
+  - op: add
+    value: pid /run/nginx.pid;
+`))
+	assert.NilError(t, err)
+
+	c := parseConf(t)
+	original := dumper.DumpConfig(c, dumper.LosslessStyle)
+	patched, err := p.Apply(c)
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.LosslessStyle), original)
+	assert.Equal(t, dumper.DumpConfig(patched, dumper.LosslessStyle), `worker_processes 2;
+events {
+    worker_connections 4096;
+}
+http {
+    upstream api {
+        server 10.0.0.3:8080;
+        server 10.0.0.5:8080 weight=3;
+    }
+    server {
+        listen 443 ssl;
+        server_name example.com;
+        location /api {
+            proxy_set_header Host $host;
+            proxy_set_header X-Real-IP $remote_addr;
+            proxy_pass http://api;
+        }
+    }
+}
+pid /run/nginx.pid;
+`)
+	upstream := patched.FindUpstreams()[0]
+	assert.Equal(t, len(upstream.UpstreamServers), 2)
+	assert.Equal(t, upstream.UpstreamServers[1].Parameters["weight"], "3")
+}
+
+func TestPatch_Conflicts(t *testing.T) {
+	t.Parallel()
+	p, err := Decode([]byte(`{
+  "operations": [
+    {"op": "set", "select": "worker_connections", "from": ["512"], "args": ["4096"]},
+    {"op": "add", "select": "upstream", "value": "server 10.0.0.3:8080;"},
+    {"op": "remove", "select": "server[server_name=www.example.com]"},
+    {"op": "set", "select": "listen", "args": ["81"]},
+    {"op": "set", "select": "listen", "args": ["82"]},
+    {"op": "remove", "select": "location", "expect": 2},
+    {"op": "add", "select": "proxy_pass", "value": "x;"},
+    {"op": "move", "select": "listen"}
+  ]
+}`))
+	assert.NilError(t, err)
+	patched, err := p.Apply(parseConf(t))
+	assert.Assert(t, patched == nil)
+
+	var conflicts Conflicts
+	assert.Assert(t, errors.As(err, &conflicts))
+	reasons := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		reasons = append(reasons, c.Error())
+	}
+	assert.DeepEqual(t, reasons, []string{
+		`operation 0 (set "worker_connections"): events > worker_connections 1024 does not have the expected parameters "512"`,
+		`operation 1 (add "upstream"): server 10.0.0.3:8080 is already in the block`,
+		`operation 2 (remove "server[server_name=www.example.com]"): no directive matches`,
+		`operation 4 (set "listen"): http > server > listen 81 is already set by operation 3`,
+		`operation 5 (remove "location"): 1 directives match, 2 expected`,
+		`operation 6 (add "proxy_pass"): http > server > location /api > proxy_pass http://api has no block`,
+		`operation 7 (move "listen"): invalid op "move"`,
+	})
+	assert.ErrorContains(t, err, "(and 6 more conflicts)")
+}
+
+func TestPatch_Stream(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser("stream {\n    upstream dns {\n        server 10.0.0.1:53;\n    }\n}\n").Parse()
+	assert.NilError(t, err)
+	p, err := Decode([]byte(`
+operations:
+  - op: add
+    select: stream
+    value: |
+      upstream db {
+          server 10.0.0.2:5432;
+      }
+      server {
+          listen 5432;
+          proxy_pass db;
+      }
+  - op: add
+    select: stream > upstream[args=dns]
+    value: server 10.0.0.3:53;
+`))
+	assert.NilError(t, err)
+	patched, err := p.Apply(c)
+	assert.NilError(t, err)
+
+	stream := patched.FindDirectives("stream")[0].(*config.Stream)
+	upstream, ok := stream.Directives[1].(*config.StreamUpstream)
+	assert.Assert(t, ok, "%T", stream.Directives[1])
+	assert.Equal(t, upstream.GetParent(), config.IDirective(stream))
+	assert.Equal(t, len(upstream.UpstreamServers), 1)
+	server, ok := stream.Directives[2].(*config.StreamServer)
+	assert.Assert(t, ok, "%T", stream.Directives[2])
+	assert.Equal(t, server.GetParent(), config.IDirective(stream))
+	assert.Equal(t, len(stream.Directives[0].(*config.StreamUpstream).UpstreamServers), 2)
+}
+
+func TestPatch_SetTypedDirectives(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser(`stream {
+    upstream dns {
+        server 10.0.0.1:53;
+    }
+}
+http {
+    map $http_host $backend {
+        default app;
+    }
+    geo $office {
+        default 0;
+    }
+}
+`).Parse()
+	assert.NilError(t, err)
+	p, err := Decode([]byte(`
+operations:
+  - op: set
+    select: stream > upstream[args=dns]
+    args: [resolver]
+  - op: set
+    select: http > map
+    args: [$host, $upstream]
+  - op: set
+    select: http > map > default
+    args: [web]
+  - op: set
+    select: http > geo
+    args: [$remote_addr, $office]
+`))
+	assert.NilError(t, err)
+	patched, err := p.Apply(c)
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(patched, dumper.IndentedStyle), `stream {
+    upstream resolver {
+        server 10.0.0.1:53;
+    }
+}
+http {
+    map $host $upstream {
+        default web;
+    }
+    geo $remote_addr $office {
+        default 0;
+    }
+}`)
+
+	p = &Patch{Operations: []Operation{{Op: Set, Select: "http > map", Args: []string{"$host"}}}}
+	_, err = p.Apply(c)
+	assert.ErrorContains(t, err, "a map has two parameters")
+}
+
+func TestPatch_DryRun(t *testing.T) {
+	t.Parallel()
+	p := &Patch{Operations: []Operation{{Op: Set, Select: "worker_processes", Args: []string{"auto"}}}}
+	out, err := p.DryRun(parseConf(t), dumper.LosslessStyle)
+	assert.NilError(t, err)
+	assert.Equal(t, out[:len("worker_processes auto;\n")], "worker_processes auto;\n")
+}
+
+func TestDecode_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := Decode([]byte("operations:\n  - op: add\n    selector: http\n"))
+	assert.ErrorContains(t, err, "field selector not found")
+	p, err := Decode(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(p.Operations), 0)
+}
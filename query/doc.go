@@ -0,0 +1,3 @@
+// Package query finds directives in a config with CSS-like selectors such as
+// "http > server[server_name=example.com] > location[match=/api] > proxy_pass".
+package query
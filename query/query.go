@@ -0,0 +1,196 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Query is a compiled selector.
+//
+// A selector is a list of directive names, "*" matching any directive. Names separated by
+// spaces match a directive at any depth in the previous one, '>' matches a directive directly
+// in the block of the previous one. A leading '>' anchors the first name to the top level.
+//
+// Each name can be followed by predicates on attributes of the directive:
+//
+//	[attr]            the attribute exists
+//	[attr=value]      a value of the attribute is value
+//	[attr!=value]     no value of the attribute is value
+//	[attr~regexp]     a value of the attribute matches the regular expression
+//	[attr!~regexp]    no value of the attribute matches the regular expression
+//
+// The attributes are:
+//
+//	args              the parameters of the directive, joined by a space
+//	arg1, arg2...     a parameter of the directive by position
+//	match             the last parameter, like the URI of a location
+//	any other name    the parameters of the sub directives with that name, like server_name
+//
+// Values can be quoted with ' or ", quotes around parameters are ignored. Included files
+// are transparent, their directives are in the block of the include directive.
+type Query struct {
+	src      string
+	steps    []step
+	anchored bool
+}
+
+// Match is a directive found by a query
+type Match struct {
+	Directive config.IDirective
+	Path      []config.IDirective // the enclosing directives from the top level one, the directive is the last one
+	Block     config.IBlock       // the block the directive is in, the one of the included config for included directives
+	File      string              // the file the directive is in, empty when parsed from a string
+}
+
+// String returns the path of the directive, like "http > server > location /api > proxy_pass http://api"
+func (m Match) String() string {
+	steps := make([]string, 0, len(m.Path))
+	for _, d := range m.Path {
+		steps = append(steps, strings.Join(append([]string{d.GetName()}, config.UnquotedValues(d.GetParameters())...), " "))
+	}
+	return strings.Join(steps, " > ")
+}
+
+// Compile parses a selector
+func Compile(selector string) (*Query, error) {
+	c := &compiler{src: selector}
+	steps, anchored, err := c.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &Query{src: selector, steps: steps, anchored: anchored}, nil
+}
+
+// MustCompile is like Compile but panics if the selector is invalid
+func MustCompile(selector string) *Query {
+	q, err := Compile(selector)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Find returns the directives of the config matching the selector in config order
+func Find(cfg *config.Config, selector string) ([]Match, error) {
+	q, err := Compile(selector)
+	if err != nil {
+		return nil, err
+	}
+	return q.Find(cfg), nil
+}
+
+// String returns the selector
+func (q *Query) String() string {
+	return q.src
+}
+
+// Find returns the directives of the config matching the query in config order
+func (q *Query) Find(cfg *config.Config) []Match {
+	matches := make([]Match, 0)
+	_ = config.Inspect(cfg, func(c *config.Cursor) error {
+		path := append(c.Path, c.Directive)
+		if q.matches(path) {
+			matches = append(matches, Match{Directive: c.Directive, Path: path, Block: c.Block, File: c.File})
+		}
+		return nil
+	})
+	return matches
+}
+
+// matches reports whether the last directive of the path matches the query
+func (q *Query) matches(path []config.IDirective) bool {
+	return q.matchStep(len(q.steps)-1, path)
+}
+
+// matchStep reports whether the last directive of the path matches the step i and its
+// ancestors match the steps before
+func (q *Query) matchStep(i int, path []config.IDirective) bool {
+	if !q.steps[i].match(path[len(path)-1]) {
+		return false
+	}
+	parents := path[:len(path)-1]
+	if i == 0 {
+		return !q.anchored || len(parents) == 0
+	}
+	if q.steps[i].axis == child {
+		return len(parents) > 0 && q.matchStep(i-1, parents)
+	}
+	for n := len(parents); n > 0; n-- {
+		if q.matchStep(i-1, parents[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether the directive matches the name and the predicates of the step
+func (s step) match(d config.IDirective) bool {
+	if s.name != "*" && s.name != d.GetName() {
+		return false
+	}
+	for _, p := range s.predicates {
+		if !p.match(d) {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether the attribute of the directive fulfills the predicate
+func (p predicate) match(d config.IDirective) bool {
+	values, ok := attribute(d, p.attr)
+	switch p.op {
+	case "":
+		return ok
+	case "!=", "!~":
+		return !p.matchAny(values)
+	}
+	return p.matchAny(values)
+}
+
+func (p predicate) matchAny(values []string) bool {
+	for _, v := range values {
+		if p.re != nil && p.re.MatchString(v) || p.re == nil && v == p.value {
+			return true
+		}
+	}
+	return false
+}
+
+// attribute returns the values of an attribute of the directive, false if it does not exist
+func attribute(d config.IDirective, attr string) ([]string, bool) {
+	params := config.UnquotedValues(d.GetParameters())
+	switch {
+	case attr == "args":
+		return []string{strings.Join(params, " ")}, true
+	case attr == "match":
+		if len(params) == 0 {
+			return nil, false
+		}
+		return params[len(params)-1:], true
+	case strings.HasPrefix(attr, "arg"):
+		if n, err := strconv.Atoi(attr[3:]); err == nil && n > 0 {
+			if n > len(params) {
+				return nil, false
+			}
+			return params[n-1 : n], true
+		}
+	}
+
+	values := make([]string, 0)
+	found := false
+	for _, sub := range config.Flatten(d.GetBlock()) {
+		if sub.GetName() != attr {
+			continue
+		}
+		found = true
+		subParams := config.UnquotedValues(sub.GetParameters())
+		values = append(values, subParams...)
+		if len(subParams) > 1 {
+			values = append(values, strings.Join(subParams, " "))
+		}
+	}
+	return values, found
+}
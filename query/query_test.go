@@ -0,0 +1,139 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func parse(t *testing.T) *config.Config {
+	t.Helper()
+	p, err := parser.NewParser("../testdata/query/nginx.conf", parser.WithIncludeParsing())
+	assert.NilError(t, err)
+	c, err := p.Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+func paths(matches []Match) []string {
+	p := make([]string, 0, len(matches))
+	for _, m := range matches {
+		p = append(p, m.String())
+	}
+	return p
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	c := parse(t)
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{
+			selector: "http > server[server_name=example.com] > location[match=/api] > proxy_pass",
+			want:     []string{"http > server > location /api > proxy_pass http://api"},
+		},
+		{
+			selector: "proxy_pass",
+			want: []string{
+				"http > server > location /api > proxy_pass http://api",
+				"http > server > location ~ ^/api/v[0-9]+ > proxy_pass http://admin",
+			},
+		},
+		{
+			selector: "server[server_name~'^admin\\.'] location",
+			want: []string{
+				"http > server > location ~ ^/api/v[0-9]+",
+				"http > server > location /static",
+			},
+		},
+		{
+			selector: "server[listen='443 ssl'] return",
+			want:     []string{"http > server > location /static > if ($http_referer) > return 403"},
+		},
+		{
+			selector: "server > return",
+			want:     []string{},
+		},
+		{
+			selector: "location[arg1=~][arg2~/api]",
+			want:     []string{"http > server > location ~ ^/api/v[0-9]+"},
+		},
+		{
+			selector: "server[server_name!=example.com] > listen",
+			want:     []string{"http > server > listen 443 ssl"},
+		},
+		{
+			selector: "upstream[args=api] > *",
+			want:     []string{"http > upstream api > server 127.0.0.1:8080"},
+		},
+		{
+			selector: "> server",
+			want:     []string{},
+		},
+		{
+			selector: "> user",
+			want:     []string{"user www"},
+		},
+		{
+			selector: "location[proxy_set_header='Host $host']",
+			want:     []string{"http > server > location /api"},
+		},
+		{
+			selector: "http include",
+			want:     []string{"http > include sites/*.conf"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			matches, err := Find(c, tt.selector)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, paths(matches), tt.want)
+		})
+	}
+}
+
+func TestFind_Match(t *testing.T) {
+	t.Parallel()
+	c := parse(t)
+	matches := MustCompile("location[match~/api] proxy_pass").Find(c)
+	assert.Equal(t, len(matches), 2)
+
+	assert.Equal(t, matches[0].File, "../testdata/query/nginx.conf")
+	assert.Equal(t, matches[0].Directive.GetLine(), 17)
+	assert.Equal(t, len(matches[0].Path), 4)
+	_, ok := matches[0].Path[1].(*config.Server)
+	assert.Assert(t, ok)
+	assert.Equal(t, matches[0].Path[3], matches[0].Directive)
+
+	// included directives are found in the block of the include
+	assert.Equal(t, matches[1].File, "../testdata/query/sites/admin.conf")
+	assert.Equal(t, matches[1].Directive.GetLine(), 6)
+	assert.Equal(t, matches[1].Path[0].GetName(), "http")
+	assert.Equal(t, matches[1].Block, matches[1].Path[2].GetBlock())
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		selector string
+		err      string
+	}{
+		{selector: "", err: `invalid selector "": missing directive name at position 0`},
+		{selector: "server >", err: `invalid selector "server >": missing directive name at position 8`},
+		{selector: "server[", err: `invalid selector "server[": missing attribute name at position 7`},
+		{selector: "server[listen=80", err: `invalid selector "server[listen=80": missing ']' at position 16`},
+		{selector: "server[listen='80]", err: `invalid selector "server[listen='80]": unterminated string at position 18`},
+		{selector: "server[listen~(]", err: `invalid selector "server[listen~(]": invalid regular expression "(" at position 15`},
+		{selector: "server]", err: `invalid selector "server]": unexpected ']' at position 6`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			_, err := Compile(tt.selector)
+			assert.Error(t, err, tt.err)
+		})
+	}
+}
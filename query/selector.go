@@ -0,0 +1,199 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// axis is the relation between a step and the previous one
+type axis int
+
+const (
+	descendant axis = iota // separated by spaces, at any depth
+	child                  // separated by '>', directly in the block
+)
+
+// step matches a directive by name and predicates
+type step struct {
+	axis       axis
+	name       string // "*" for any directive
+	predicates []predicate
+}
+
+// predicate is a condition on an attribute of the directive, like [server_name=example.com]
+type predicate struct {
+	attr  string
+	op    string // "" when the attribute only has to exist, "=", "!=", "~" or "!~"
+	value string
+	re    *regexp.Regexp
+}
+
+// compiler reads a selector
+type compiler struct {
+	src string
+	pos int
+}
+
+func (c *compiler) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid selector %q: %s at position %d", c.src, fmt.Sprintf(format, args...), c.pos)
+}
+
+func (c *compiler) eof() bool {
+	return c.pos >= len(c.src)
+}
+
+func (c *compiler) peek() byte {
+	return c.src[c.pos]
+}
+
+// skipSpaces skips the spaces and reports whether there were any
+func (c *compiler) skipSpaces() bool {
+	start := c.pos
+	for !c.eof() && isSpace(c.peek()) {
+		c.pos++
+	}
+	return c.pos > start
+}
+
+// compile reads the steps of the selector, a leading '>' anchors the first step to the top level
+func (c *compiler) compile() ([]step, bool, error) {
+	var steps []step
+	anchored := false
+	c.skipSpaces()
+	if !c.eof() && c.peek() == '>' {
+		anchored = true
+		c.pos++
+		c.skipSpaces()
+	}
+	next := descendant
+	for {
+		if c.eof() {
+			return nil, false, c.errorf("missing directive name")
+		}
+		s, err := c.step()
+		if err != nil {
+			return nil, false, err
+		}
+		s.axis = next
+		steps = append(steps, s)
+
+		spaces := c.skipSpaces()
+		switch {
+		case c.eof():
+			return steps, anchored, nil
+		case c.peek() == '>':
+			next = child
+			c.pos++
+			c.skipSpaces()
+		case spaces:
+			next = descendant
+		default:
+			return nil, false, c.errorf("unexpected %q", c.peek())
+		}
+	}
+}
+
+// step reads a name and its predicates
+func (c *compiler) step() (step, error) {
+	start := c.pos
+	for !c.eof() && isNameChar(c.peek()) {
+		c.pos++
+	}
+	s := step{name: c.src[start:c.pos]}
+	if s.name == "" {
+		return s, c.errorf("missing directive name")
+	}
+	for !c.eof() && c.peek() == '[' {
+		c.pos++
+		p, err := c.predicate()
+		if err != nil {
+			return s, err
+		}
+		s.predicates = append(s.predicates, p)
+	}
+	return s, nil
+}
+
+// predicate reads the content of [...] and the closing bracket
+func (c *compiler) predicate() (predicate, error) {
+	c.skipSpaces()
+	start := c.pos
+	for !c.eof() && isAttrChar(c.peek()) {
+		c.pos++
+	}
+	p := predicate{attr: c.src[start:c.pos]}
+	if p.attr == "" {
+		return p, c.errorf("missing attribute name")
+	}
+	c.skipSpaces()
+	for _, op := range []string{"!=", "!~", "=", "~"} {
+		if strings.HasPrefix(c.src[c.pos:], op) {
+			p.op = op
+			c.pos += len(op)
+			break
+		}
+	}
+	if p.op != "" {
+		c.skipSpaces()
+		value, err := c.value()
+		if err != nil {
+			return p, err
+		}
+		p.value = value
+		if p.op == "~" || p.op == "!~" {
+			if p.re, err = regexp.Compile(value); err != nil {
+				return p, c.errorf("invalid regular expression %q", value)
+			}
+		}
+		c.skipSpaces()
+	}
+	if c.eof() || c.peek() != ']' {
+		return p, c.errorf("missing ']'")
+	}
+	c.pos++
+	return p, nil
+}
+
+// value reads a quoted or a bare value, a bare value ends at ']'
+func (c *compiler) value() (string, error) {
+	if c.eof() {
+		return "", c.errorf("missing value")
+	}
+	quote := c.peek()
+	if quote != '"' && quote != '\'' {
+		start := c.pos
+		for !c.eof() && c.peek() != ']' {
+			c.pos++
+		}
+		return strings.TrimRight(c.src[start:c.pos], " \t\r\n"), nil
+	}
+	c.pos++
+	var b strings.Builder
+	for !c.eof() {
+		ch := c.peek()
+		c.pos++
+		switch {
+		case ch == quote:
+			return b.String(), nil
+		case ch == '\\' && !c.eof() && (c.peek() == quote || c.peek() == '\\'):
+			b.WriteByte(c.peek())
+			c.pos++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", c.errorf("unterminated string")
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func isNameChar(ch byte) bool {
+	return !isSpace(ch) && ch != '[' && ch != ']' && ch != '>' && ch != '"' && ch != '\''
+}
+
+func isAttrChar(ch byte) bool {
+	return ch == '_' || ch == '-' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
@@ -0,0 +1,3 @@
+// Package report writes parse errors and lint findings in the formats CI tools read,
+// SARIF 2.1.0 for GitHub code scanning and JUnit XML for Jenkins.
+package report
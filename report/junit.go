@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML with a test suite for each file and a failed
+// test case for each problem, named after its position in the class of its rule. Parse
+// errors are errors of their kind, findings are failures of their severity.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suites := &junitTestSuites{Name: toolName, Suites: []*junitTestSuite{}}
+	byFile := map[string]*junitTestSuite{}
+	for _, p := range r.problems() {
+		name := p.file
+		if name == "" {
+			name = "config"
+		}
+		suite, ok := byFile[name]
+		if !ok {
+			suite = &junitTestSuite{Name: name}
+			byFile[name] = suite
+			suites.Suites = append(suites.Suites, suite)
+		}
+
+		position := name
+		if p.line > 0 {
+			position += fmt.Sprintf(":%d", p.line)
+		}
+		if p.line > 0 && p.column > 0 {
+			position += fmt.Sprintf(":%d", p.column)
+		}
+		text := []string{position + ": " + p.message}
+		if p.fix != "" {
+			text = append(text, "fix: "+p.fix)
+		}
+		failure := &junitFailure{Message: p.message, Text: strings.Join(text, "\n")}
+		tc := junitTestCase{Name: position, Classname: p.rule}
+		suite.Tests++
+		suites.Tests++
+		if p.kind != "" {
+			failure.Type = p.kind
+			tc.Error = failure
+			suite.Errors++
+			suites.Errors++
+		} else {
+			failure.Type = p.severity.String()
+			tc.Failure = failure
+			suite.Failures++
+			suites.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
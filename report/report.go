@@ -0,0 +1,90 @@
+package report
+
+import (
+	"errors"
+
+	"github.com/tufanbarisyildirim/gonginx/lint"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+)
+
+// ParseErrorRule is the rule ID of parse errors in reports
+const ParseErrorRule = "parse-error"
+
+// Report is the outcome of checking configs
+type Report struct {
+	Rules    []lint.Rule // the rules that ran, described in the report
+	Findings []lint.Finding
+	Errors   []*parser.ParseError
+	// BaseDir is the source root, SARIF file URIs are relative to it with the
+	// %SRCROOT% base id when it is set, like GitHub code scanning expects
+	BaseDir string
+}
+
+// New returns a report of the findings of the linter
+func New(l *lint.Linter, findings []lint.Finding) *Report {
+	return &Report{Rules: l.Rules(), Findings: findings}
+}
+
+// AddError adds the errors of a parser, all the errors of an ErrorList are added and
+// an error that is not a *parser.ParseError is added without position
+func (r *Report) AddError(err error) {
+	var list parser.ErrorList
+	var parseErr *parser.ParseError
+	switch {
+	case err == nil:
+	case errors.As(err, &list):
+		r.Errors = append(r.Errors, list...)
+	case errors.As(err, &parseErr):
+		r.Errors = append(r.Errors, parseErr)
+	default:
+		r.Errors = append(r.Errors, &parser.ParseError{Message: err.Error(), Err: err})
+	}
+}
+
+// problem is a parse error or a finding
+type problem struct {
+	rule     string
+	severity lint.Severity
+	kind     string // the kind of a parse error, empty for a finding
+	message  string
+	fix      string
+	file     string
+	line     int
+	column   int
+}
+
+// problems returns the parse errors, then the findings
+func (r *Report) problems() []problem {
+	problems := make([]problem, 0, len(r.Errors)+len(r.Findings))
+	for _, e := range r.Errors {
+		message := e.Message
+		if e.Err != nil && e.Err.Error() != message {
+			message += ": " + e.Err.Error()
+		}
+		kind := e.Kind.String()
+		if kind == "" {
+			kind = "ParseError"
+		}
+		problems = append(problems, problem{
+			rule:     ParseErrorRule,
+			severity: lint.Error,
+			kind:     kind,
+			message:  message,
+			file:     e.File,
+			line:     e.Line,
+			column:   e.Column,
+		})
+	}
+	for _, f := range r.Findings {
+		problems = append(problems, problem{
+			rule:     f.Rule,
+			severity: f.Severity,
+			message:  f.Message,
+			fix:      f.Fix,
+			file:     f.File,
+			line:     f.Line,
+			column:   f.Column,
+		})
+	}
+	return problems
+}
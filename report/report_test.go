@@ -0,0 +1,186 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/lint"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+	c, err := parser.NewStringParser("http {\n    server {\n        listen 80;\n        autoindex on;\n    }\n}\n").Parse()
+	assert.NilError(t, err)
+	c.FilePath = "conf.d/site.conf"
+	l := lint.New(lint.SecurityRules()...).Disable("server-tokens", "https-redirect", "ssl-protocols", "ssl-ciphers", "ssl-prefer-server-ciphers", "hsts")
+	findings := l.Lint(c)
+	findings = append(findings, lint.Finding{Rule: "custom", Severity: lint.Info, Message: "a note"})
+
+	var buf bytes.Buffer
+	assert.NilError(t, New(l, findings).WriteSARIF(&buf))
+	assert.Equal(t, buf.String(), `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "gonginx",
+          "informationUri": "https://github.com/tufanbarisyildirim/gonginx",
+          "rules": [
+            {
+              "id": "autoindex",
+              "shortDescription": {
+                "text": "directory listing with autoindex on"
+              },
+              "defaultConfiguration": {
+                "level": "warning"
+              }
+            },
+            {
+              "id": "allow-all-sensitive",
+              "shortDescription": {
+                "text": "allow all in a sensitive location like /admin or /.git"
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "autoindex",
+          "ruleIndex": 0,
+          "level": "warning",
+          "message": {
+            "text": "autoindex on lists the files of the directories"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "conf.d/site.conf"
+                },
+                "region": {
+                  "startLine": 4,
+                  "startColumn": 9
+                }
+              }
+            }
+          ],
+          "properties": {
+            "fix": "set autoindex off, or restrict the location with allow and deny"
+          }
+        },
+        {
+          "ruleId": "custom",
+          "level": "note",
+          "message": {
+            "text": "a note"
+          }
+        }
+      ]
+    }
+  ]
+}
+`)
+}
+
+func parseErrors(t *testing.T) error {
+	t.Helper()
+	_, err := parser.NewStringParser("http {\n    server {\n        listn 80;\n        autoindex on;\n    }\n    gzip on on;\n}\n",
+		parser.WithErrorRecovery(), parser.WithDirectiveValidation()).Parse()
+	assert.ErrorContains(t, err, "unknown directive")
+	return err
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+	r := &Report{Findings: []lint.Finding{{
+		Rule: "autoindex", Severity: lint.Warning, Message: "autoindex on lists the files of the directories",
+		Fix: "set autoindex off", File: "site.conf", Line: 4, Column: 9,
+	}}}
+	r.AddError(parseErrors(t))
+	r.AddError(errors.New("open nginx.conf: no such file or directory"))
+	assert.Equal(t, len(r.Errors), 3)
+
+	var buf bytes.Buffer
+	assert.NilError(t, r.WriteJUnit(&buf))
+	assert.Equal(t, buf.String(), `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="gonginx" tests="4" failures="1" errors="3">
+  <testsuite name="config" tests="3" failures="0" errors="3">
+    <testcase name="config:3:9" classname="parse-error">
+      <error message="unknown directive &#39;listn&#39;" type="UnknownDirective">config:3:9: unknown directive &#39;listn&#39;</error>
+    </testcase>
+    <testcase name="config:6:5" classname="parse-error">
+      <error message="invalid number of arguments in &#39;gzip&#39; directive" type="InvalidArguments">config:6:5: invalid number of arguments in &#39;gzip&#39; directive</error>
+    </testcase>
+    <testcase name="config" classname="parse-error">
+      <error message="open nginx.conf: no such file or directory" type="ParseError">config: open nginx.conf: no such file or directory</error>
+    </testcase>
+  </testsuite>
+  <testsuite name="site.conf" tests="1" failures="1" errors="0">
+    <testcase name="site.conf:4:9" classname="autoindex">
+      <failure message="autoindex on lists the files of the directories" type="warning">site.conf:4:9: autoindex on lists the files of the directories&#xA;fix: set autoindex off</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`)
+
+	buf.Reset()
+	assert.NilError(t, (&Report{}).WriteJUnit(&buf))
+	assert.Equal(t, buf.String(), `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="gonginx" tests="0" failures="0" errors="0"></testsuites>
+`)
+}
+
+func TestWriteSARIF_ParseErrors(t *testing.T) {
+	t.Parallel()
+	r := &Report{}
+	r.AddError(parseErrors(t))
+	var buf bytes.Buffer
+	assert.NilError(t, r.WriteSARIF(&buf))
+	assert.Assert(t, strings.Contains(buf.String(), `"rules": [
+            {
+              "id": "parse-error",`))
+	assert.Assert(t, strings.Contains(buf.String(), `"region": {
+                  "startLine": 3,
+                  "startColumn": 9
+                }
+              }
+            }
+          ],
+          "properties": {
+            "kind": "UnknownDirective"
+          }`))
+}
+
+func TestWriteSARIF_BaseDir(t *testing.T) {
+	t.Parallel()
+	base := t.TempDir()
+	r := &Report{BaseDir: base, Findings: []lint.Finding{
+		{Rule: "autoindex", Severity: lint.Warning, Message: "autoindex on", File: filepath.Join(base, "conf.d", "site.conf"), Line: 4, Column: 9},
+		{Rule: "autoindex", Severity: lint.Warning, Message: "autoindex on", File: filepath.Join(filepath.Dir(base), "other.conf"), Line: 2},
+	}}
+
+	var buf bytes.Buffer
+	assert.NilError(t, r.WriteSARIF(&buf))
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &log))
+	run := log.Runs[0]
+	root := run.OriginalURIBaseIDs["%SRCROOT%"].URI
+	assert.Assert(t, strings.HasPrefix(root, "file:///") && strings.HasSuffix(root, "/"), root)
+	assert.DeepEqual(t, *run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation,
+		sarifArtifactLocation{URI: "conf.d/site.conf", URIBaseID: "%SRCROOT%"})
+	// a file out of the base directory keeps its path
+	assert.DeepEqual(t, *run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation,
+		sarifArtifactLocation{URI: filepath.ToSlash(filepath.Join(filepath.Dir(base), "other.conf"))})
+}
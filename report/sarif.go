@@ -0,0 +1,189 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/lint"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "gonginx"
+	toolURI      = "https://github.com/tufanbarisyildirim/gonginx"
+	srcRoot      = "%SRCROOT%"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  *int              `json:"ruleIndex,omitempty"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation,omitempty"`
+	Region           *sarifRegion           `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// level returns the SARIF level of a severity
+func level(s lint.Severity) string {
+	switch s {
+	case lint.Error:
+		return "error"
+	case lint.Warning:
+		return "warning"
+	}
+	return "note"
+}
+
+// artifact returns the location of a file, relative to the base directory when it is in it
+func artifact(file, base string) *sarifArtifactLocation {
+	if base != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(base, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return &sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: srcRoot}
+			}
+		}
+	}
+	return &sarifArtifactLocation{URI: filepath.ToSlash(file)}
+}
+
+// fileURI returns the file URI of a directory, with the trailing slash of a base id
+func fileURI(dir string) string {
+	path := filepath.ToSlash(dir)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // a Windows drive
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log with one run. Parse errors are results
+// of the parse-error rule with their kind in the kind property, the fix of a finding is in
+// the fix property of its result. The files in BaseDir are relative to the %SRCROOT% base id.
+func (r *Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{}
+	base := ""
+	if r.BaseDir != "" {
+		abs, err := filepath.Abs(r.BaseDir)
+		if err != nil {
+			return err
+		}
+		base = abs
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{srcRoot: {URI: fileURI(base)}}
+	}
+
+	driver := sarifDriver{Name: toolName, InformationURI: toolURI, Rules: []sarifRule{}}
+	index := map[string]int{}
+	addRule := func(id, description string, severity lint.Severity) {
+		index[id] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   id,
+			ShortDescription:     sarifMessage{Text: description},
+			DefaultConfiguration: sarifConfiguration{Level: level(severity)},
+		})
+	}
+	if len(r.Errors) > 0 {
+		addRule(ParseErrorRule, "the config can not be parsed", lint.Error)
+	}
+	for _, rule := range r.Rules {
+		addRule(rule.ID(), rule.Description(), rule.Severity())
+	}
+
+	problems := r.problems()
+	results := make([]sarifResult, 0, len(problems))
+	for _, p := range problems {
+		result := sarifResult{RuleID: p.rule, Level: level(p.severity), Message: sarifMessage{Text: p.message}}
+		if i, ok := index[p.rule]; ok {
+			result.RuleIndex = &i
+		}
+		if p.file != "" || p.line > 0 {
+			var loc sarifPhysicalLocation
+			if p.file != "" {
+				loc.ArtifactLocation = artifact(p.file, base)
+			}
+			if p.line > 0 {
+				loc.Region = &sarifRegion{StartLine: p.line, StartColumn: p.column}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		if p.kind != "" || p.fix != "" {
+			result.Properties = map[string]string{}
+			if p.kind != "" {
+				result.Properties["kind"] = p.kind
+			}
+			if p.fix != "" {
+				result.Properties["fix"] = p.fix
+			}
+		}
+		results = append(results, result)
+	}
+
+	run.Tool, run.Results = sarifTool{Driver: driver}, results
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,2 @@
+// Package routing simulates how nginx picks the server and the location handling a request.
+package routing
@@ -0,0 +1,167 @@
+package routing
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// listen is an address and port a server listens on
+type listen struct {
+	addr          string // empty for any address
+	ipv6          bool
+	port          int
+	defaultServer bool
+}
+
+// parseListen parses the parameters of a listen directive, false for unix sockets
+func parseListen(params []config.Parameter) (listen, bool) {
+	l := listen{port: 80}
+	if len(params) == 0 {
+		return l, false
+	}
+	value := params[0].GetValue()
+	if strings.HasPrefix(value, "unix:") {
+		return l, false
+	}
+	host, port := value, ""
+	switch {
+	case strings.HasPrefix(value, "["):
+		end := strings.Index(value, "]")
+		if end < 0 {
+			return l, false
+		}
+		host, port = value[1:end], strings.TrimPrefix(value[end+1:], ":")
+		l.ipv6 = true
+	case strings.Contains(value, ":"):
+		i := strings.LastIndex(value, ":")
+		host, port = value[:i], value[i+1:]
+	case isNumber(value):
+		host, port = "*", value
+	}
+	if port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return l, false
+		}
+		l.port = p
+	}
+	if host != "*" && host != "" && !(l.ipv6 && host == "::") {
+		l.addr = host
+	}
+	for _, p := range params[1:] {
+		if v := p.GetValue(); v == "default_server" || v == "default" {
+			l.defaultServer = true
+		}
+	}
+	return l, true
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func (l listen) String() string {
+	addr := l.addr
+	switch {
+	case addr == "" && l.ipv6:
+		addr = "[::]"
+	case addr == "":
+		addr = "*"
+	case l.ipv6:
+		addr = "[" + addr + "]"
+	}
+	return fmt.Sprintf("%s:%d", addr, l.port)
+}
+
+// listens returns the addresses the server listens on, *:80 if it has no listen
+func listens(s *config.Server) []listen {
+	all := make([]listen, 0)
+	for _, d := range config.Flatten(s.GetBlock()) {
+		if d.GetName() != "listen" {
+			continue
+		}
+		if l, ok := parseListen(d.GetParameters()); ok {
+			all = append(all, l)
+		}
+	}
+	if len(all) == 0 {
+		all = append(all, listen{port: 80})
+	}
+	return all
+}
+
+func listenAddress(req Request) string {
+	if req.Addr == "" {
+		return fmt.Sprintf("*:%d", req.Port)
+	}
+	if strings.Contains(req.Addr, ":") {
+		return fmt.Sprintf("[%s]:%d", req.Addr, req.Port)
+	}
+	return fmt.Sprintf("%s:%d", req.Addr, req.Port)
+}
+
+// sameAddr compares two addresses, as ips if they are
+func sameAddr(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA != nil && ipB != nil {
+		return ipA.Equal(ipB)
+	}
+	return strings.EqualFold(a, b)
+}
+
+// selectByListen returns the servers listening on the address and port of the request,
+// and the default one among them. nginx prefers the servers listening on the exact
+// address over the ones listening on any address.
+func selectByListen(servers []*config.Server, req Request, r *Result) ([]*config.Server, *config.Server) {
+	addr := strings.Trim(req.Addr, "[]")
+	ipv6 := strings.Contains(addr, ":")
+	var exact, wildcard, all []*config.Server
+	var exactDefault, wildcardDefault, allDefault *config.Server
+	add := func(list *[]*config.Server, def **config.Server, s *config.Server, l listen) {
+		if len(*list) == 0 || (*list)[len(*list)-1] != s {
+			*list = append(*list, s)
+		}
+		if l.defaultServer && *def == nil {
+			*def = s
+		}
+	}
+	for _, s := range servers {
+		for _, l := range listens(s) {
+			if l.port != req.Port {
+				continue
+			}
+			switch {
+			case addr == "":
+				add(&all, &allDefault, s, l)
+			case l.addr != "" && sameAddr(l.addr, addr):
+				add(&exact, &exactDefault, s, l)
+			case l.addr == "" && l.ipv6 == ipv6:
+				add(&wildcard, &wildcardDefault, s, l)
+			}
+		}
+	}
+
+	candidates, def := all, allDefault
+	switch {
+	case len(exact) > 0:
+		candidates, def = exact, exactDefault
+	case len(wildcard) > 0:
+		candidates, def = wildcard, wildcardDefault
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	r.tracef("listen: %d server(s) listen on %s", len(candidates), listenAddress(req))
+	if def == nil {
+		def = candidates[0]
+		r.tracef("listen: no default_server, the first one is the default: %s", describe(def))
+	} else {
+		r.tracef("listen: default_server is %s", describe(def))
+	}
+	return candidates, def
+}
@@ -0,0 +1,105 @@
+package routing
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// normalizeURI decodes the path and resolves the dot segments and double slashes, like nginx does
+func normalizeURI(uri string) string {
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	if decoded, err := url.PathUnescape(uri); err == nil {
+		uri = decoded
+	}
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+	cleaned := path.Clean(uri)
+	if strings.HasSuffix(uri, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+func describeLocation(l *config.Location) string {
+	m, match := l.ModifierMatch()
+	if m == "" {
+		return "location " + match
+	}
+	return "location " + m + " " + match
+}
+
+// findLocation looks up the location of the uri among the given directives like
+// nginx: an exact match wins, otherwise the longest prefix is searched for nested
+// locations, then regexes are checked in order unless the prefix has ^~
+func findLocation(block []config.IDirective, uri string, r *Result) *config.Location {
+	var prefix *config.Location
+	var prefixMod, prefixMatch string
+	var regexes []*config.Location
+	for _, d := range block {
+		l, ok := d.(*config.Location)
+		if !ok {
+			continue
+		}
+		m, match := l.ModifierMatch()
+		switch m {
+		case "=":
+			if match == uri {
+				r.tracef("location: %s matches exactly", describeLocation(l))
+				return l
+			}
+		case "~", "~*":
+			regexes = append(regexes, l)
+		case "", "^~":
+			if strings.HasPrefix(match, "@") || !strings.HasPrefix(uri, match) {
+				continue
+			}
+			if prefix == nil || len(match) > len(prefixMatch) {
+				prefix, prefixMod, prefixMatch = l, m, match
+			}
+		}
+	}
+
+	var found *config.Location
+	if prefix != nil {
+		r.tracef("location: %s is the longest prefix", describeLocation(prefix))
+		found = prefix
+		if nested := findLocation(config.Flatten(prefix.GetBlock()), uri, r); nested != nil {
+			found = nested
+			m, _ := nested.ModifierMatch()
+			if m != "" && m != "^~" {
+				// an exact or regex match in nested locations ends the lookup
+				return found
+			}
+		}
+		if prefixMod == "^~" {
+			r.tracef("location: %s has ^~, regexes are not checked", describeLocation(prefix))
+			return found
+		}
+	}
+
+	for _, l := range regexes {
+		m, match := l.ModifierMatch()
+		re, err := compileRegex(match, m == "~*")
+		if err != nil {
+			r.tracef("location: invalid regex in %s: %s", describeLocation(l), err)
+			continue
+		}
+		if re.MatchString(uri) {
+			r.tracef("location: %s is the first matching regex", describeLocation(l))
+			if nested := findLocation(config.Flatten(l.GetBlock()), uri, r); nested != nil {
+				return nested
+			}
+			return l
+		}
+	}
+	if found != nil {
+		r.tracef("location: using %s", describeLocation(found))
+	}
+	return found
+}
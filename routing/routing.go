@@ -0,0 +1,117 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// Request is the request to route
+type Request struct {
+	Scheme string // http or https, default is http
+	Host   string // value of the Host header, the port is ignored
+	Addr   string // local address the request is received on, any address if empty
+	Port   int    // local port, default is 80 for http and 443 for https
+	Path   string // request URI, the query string is ignored
+	Method string // default is GET
+}
+
+// Result is the routing decision
+type Result struct {
+	Server   *config.Server
+	Location *config.Location // nil when no location matches
+	// LimitExcept is the limit_except block of the location whose access
+	// rules apply to the request method, nil if there is none
+	LimitExcept config.IDirective
+	Trace       []string // the steps of the decision
+}
+
+func (r *Result) tracef(format string, args ...interface{}) {
+	r.Trace = append(r.Trace, fmt.Sprintf(format, args...))
+}
+
+// Resolve returns the server and the location of the config nginx would use to handle the request.
+// The http, server and location blocks must be wrapped by the parser, which is the default.
+func Resolve(cfg *config.Config, req Request) (*Result, error) {
+	if req.Scheme == "" {
+		req.Scheme = "http"
+	}
+	if req.Port == 0 {
+		req.Port = 80
+		if req.Scheme == "https" {
+			req.Port = 443
+		}
+	}
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+
+	servers := httpServers(cfg)
+	if len(servers) == 0 {
+		return nil, errors.New("no http server in config")
+	}
+
+	r := &Result{}
+	candidates, defaultServer := selectByListen(servers, req, r)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no server listens on %s", listenAddress(req))
+	}
+	r.Server = selectByName(candidates, defaultServer, req.Host, r)
+
+	uri := normalizeURI(req.Path)
+	r.tracef("location: looking up %q", uri)
+	r.Location = findLocation(config.Flatten(r.Server.GetBlock()), uri, r)
+	if r.Location == nil {
+		r.tracef("location: no location matches, the server handles the request")
+		return r, nil
+	}
+	r.LimitExcept = findLimitExcept(r.Location, req.Method, r)
+	return r, nil
+}
+
+// httpServers returns the server blocks of the http blocks in config order
+func httpServers(cfg *config.Config) []*config.Server {
+	servers := make([]*config.Server, 0)
+	for _, d := range config.Flatten(cfg.Block) {
+		if d.GetName() != "http" || d.GetBlock() == nil {
+			continue
+		}
+		for _, s := range config.Flatten(d.GetBlock()) {
+			if server, ok := s.(*config.Server); ok {
+				servers = append(servers, server)
+			}
+		}
+	}
+	return servers
+}
+
+// describe names a server by its first server_name in the trace
+func describe(s *config.Server) string {
+	for _, d := range config.Flatten(s.GetBlock()) {
+		if d.GetName() == "server_name" && len(d.GetParameters()) > 0 {
+			return fmt.Sprintf("server %q", d.GetParameters()[0].GetValue())
+		}
+	}
+	return "server without server_name"
+}
+
+// findLimitExcept returns the limit_except block that applies to the method, if any
+func findLimitExcept(l *config.Location, method string, r *Result) config.IDirective {
+	for _, d := range config.Flatten(l.GetBlock()) {
+		if d.GetName() != "limit_except" {
+			continue
+		}
+		for _, p := range d.GetParameters() {
+			allowed := strings.ToUpper(p.GetValue())
+			if allowed == method || (allowed == "GET" && method == "HEAD") {
+				r.tracef("limit_except: %s is allowed", method)
+				return nil
+			}
+		}
+		r.tracef("limit_except: %s is not listed, the access rules of limit_except apply", method)
+		return d
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+const routingConf = `http {
+	server {
+		listen 80;
+		server_name example.com www.example.com;
+
+		location = / {
+			return 200 exact;
+		}
+		location / {
+			return 200 root;
+		}
+		location /static/ {
+			location ~ \.css$ {
+				return 200 nested-css;
+			}
+		}
+		location ^~ /images/ {
+			return 200 images;
+		}
+		location ~* \.(gif|jpg|png)$ {
+			return 200 regex-images;
+		}
+		location /api/ {
+			limit_except GET POST {
+				deny all;
+			}
+		}
+		location ~ ^/api/v[0-9]+/ {
+			return 200 api-regex;
+		}
+	}
+	server {
+		listen 80 default_server;
+		server_name _;
+	}
+	server {
+		listen 80;
+		server_name *.example.com;
+	}
+	server {
+		listen 80;
+		server_name *.api.example.com;
+	}
+	server {
+		listen 80;
+		server_name mail.*;
+	}
+	server {
+		listen 80;
+		server_name ~^(?<user>[a-z]+)\.users\.example\.org$;
+	}
+	server {
+		listen 127.0.0.1:8080;
+		server_name local;
+	}
+	server {
+		listen 8080;
+		server_name other;
+	}
+	server {
+		listen [::]:443 ssl;
+		server_name .secure.example.com;
+	}
+}`
+
+func parseConf(t *testing.T) *config.Config {
+	t.Helper()
+	c, err := parser.NewStringParser(routingConf).Parse()
+	assert.NilError(t, err)
+	return c
+}
+
+func serverName(s *config.Server) string {
+	return s.FindDirectives("server_name")[0].GetParameters()[0].GetValue()
+}
+
+func TestResolve_Server(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	tests := []struct {
+		name   string
+		req    Request
+		server string
+	}{
+		{"exact", Request{Host: "www.example.com"}, "example.com"},
+		{"exact with port", Request{Host: "Example.COM:80"}, "example.com"},
+		{"leading wildcard", Request{Host: "foo.example.com"}, "*.example.com"},
+		{"longest leading wildcard", Request{Host: "v1.api.example.com"}, "*.api.example.com"},
+		{"trailing wildcard", Request{Host: "mail.example.net"}, "mail.*"},
+		{"regex", Request{Host: "bob.users.example.org"}, `~^(?<user>[a-z]+)\.users\.example\.org$`},
+		{"default server", Request{Host: "unknown.net"}, "_"},
+		{"exact address first", Request{Host: "other", Addr: "127.0.0.1", Port: 8080}, "local"},
+		{"wildcard address", Request{Host: "local", Addr: "10.0.0.1", Port: 8080}, "other"},
+		{"ipv6 special wildcard", Request{Scheme: "https", Host: "secure.example.com", Addr: "::1"}, ".secure.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Resolve(c, tt.req)
+			assert.NilError(t, err)
+			assert.Equal(t, serverName(r.Server), tt.server, "%v", r.Trace)
+		})
+	}
+
+	_, err := Resolve(c, Request{Host: "example.com", Port: 9000})
+	assert.Error(t, err, "no server listens on *:9000")
+	_, err = Resolve(&config.Config{Block: &config.Block{}}, Request{})
+	assert.Error(t, err, "no http server in config")
+}
+
+func TestResolve_Location(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	tests := []struct {
+		path     string
+		location string
+	}{
+		{"/", "location = /"},
+		{"/index.html", "location /"},
+		{"/static/app.css", `location ~ \.css$`},
+		{"/static/app.js", "location /static/"},
+		{"/images/a.png", "location ^~ /images/"},
+		{"/photos/a.PNG", `location ~* \.(gif|jpg|png)$`},
+		{"/api/users", "location /api/"},
+		{"/api/v2/users", `location ~ ^/api/v[0-9]+/`},
+		{"/static/../api/v1/x?a=b", `location ~ ^/api/v[0-9]+/`},
+		{"//static//%61pp.css", `location ~ \.css$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r, err := Resolve(c, Request{Host: "example.com", Path: tt.path})
+			assert.NilError(t, err)
+			assert.Assert(t, r.Location != nil, "%v", r.Trace)
+			assert.Equal(t, describeLocation(r.Location), tt.location, "%v", r.Trace)
+		})
+	}
+
+	r, err := Resolve(c, Request{Host: "unknown.net", Path: "/"})
+	assert.NilError(t, err)
+	assert.Assert(t, r.Location == nil)
+	assert.Equal(t, r.Trace[len(r.Trace)-1], "location: no location matches, the server handles the request")
+}
+
+func TestResolve_LimitExcept(t *testing.T) {
+	t.Parallel()
+	c := parseConf(t)
+	r, err := Resolve(c, Request{Host: "example.com", Path: "/api/users", Method: "HEAD"})
+	assert.NilError(t, err)
+	assert.Assert(t, r.LimitExcept == nil)
+
+	r, err = Resolve(c, Request{Host: "example.com", Path: "/api/users", Method: "DELETE"})
+	assert.NilError(t, err)
+	assert.Equal(t, r.LimitExcept.GetName(), "limit_except")
+}
+
+func TestResolve_Trace(t *testing.T) {
+	t.Parallel()
+	r, err := Resolve(parseConf(t), Request{Host: "www.example.com", Path: "/images/logo.png"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r.Trace, []string{
+		"listen: 6 server(s) listen on *:80",
+		`listen: default_server is server "_"`,
+		`server_name: "www.example.com" matches exactly server "example.com"`,
+		`location: looking up "/images/logo.png"`,
+		"location: location ^~ /images/ is the longest prefix",
+		"location: location ^~ /images/ has ^~, regexes are not checked",
+	})
+}
+
+func TestParseListen(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"80", "*:80"},
+		{"8080", "*:8080"},
+		{"127.0.0.1", "127.0.0.1:80"},
+		{"127.0.0.1:81", "127.0.0.1:81"},
+		{"*:82", "*:82"},
+		{"[::]:83", "[::]:83"},
+		{"[::1]", "[::1]:80"},
+		{"localhost:84", "localhost:84"},
+	}
+	for _, tt := range tests {
+		l, ok := parseListen([]config.Parameter{{Value: tt.value}})
+		assert.Assert(t, ok, tt.value)
+		assert.Equal(t, l.String(), tt.want)
+	}
+	_, ok := parseListen([]config.Parameter{{Value: "unix:/var/run/nginx.sock"}})
+	assert.Assert(t, !ok)
+}
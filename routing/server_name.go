@@ -0,0 +1,96 @@
+package routing
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+)
+
+// serverNames returns the names of the server, "" if it has no server_name
+func serverNames(s *config.Server) []string {
+	names := make([]string, 0)
+	found := false
+	for _, d := range config.Flatten(s.GetBlock()) {
+		if d.GetName() != "server_name" {
+			continue
+		}
+		found = true
+		for _, p := range d.GetParameters() {
+			names = append(names, config.Unquote(p.GetValue()))
+		}
+	}
+	if !found {
+		names = append(names, "")
+	}
+	return names
+}
+
+// compileRegex compiles a pcre regex, named captures use the (?<name>) syntax
+func compileRegex(expr string, caseInsensitive bool) (*regexp.Regexp, error) {
+	expr = strings.ReplaceAll(expr, "(?<", "(?P<")
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// selectByName picks the server by the Host header like nginx: exact name, longest
+// wildcard starting with an asterisk, longest wildcard ending with an asterisk,
+// first matching regex, and the default server at last
+func selectByName(servers []*config.Server, defaultServer *config.Server, host string, r *Result) *config.Server {
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "]") {
+		host = host[:i]
+	}
+	host = strings.TrimSuffix(host, ".")
+
+	var headMatch, tailMatch, regexMatch *config.Server
+	var head, tail, regexName string
+	for _, s := range servers {
+		for _, name := range serverNames(s) {
+			lower := strings.ToLower(name)
+			switch {
+			case strings.HasPrefix(name, "~"):
+				if regexMatch != nil {
+					continue
+				}
+				re, err := compileRegex(name[1:], false)
+				if err != nil {
+					r.tracef("server_name: invalid regex %s: %s", name, err)
+					continue
+				}
+				if re.MatchString(host) {
+					regexMatch, regexName = s, name
+				}
+			case lower == host:
+				r.tracef("server_name: %q matches exactly %s", host, describe(s))
+				return s
+			case strings.HasPrefix(lower, "*.") || strings.HasPrefix(lower, "."):
+				suffix := strings.TrimPrefix(lower, "*")
+				matches := strings.HasSuffix(host, suffix) || (lower[0] == '.' && host == lower[1:])
+				if matches && len(suffix) > len(head) {
+					headMatch, head = s, name
+				}
+			case strings.HasSuffix(lower, ".*"):
+				prefix := strings.TrimSuffix(lower, "*")
+				if strings.HasPrefix(host, prefix) && len(prefix) > len(tail) {
+					tailMatch, tail = s, name
+				}
+			}
+		}
+	}
+	switch {
+	case headMatch != nil:
+		r.tracef("server_name: %q matches the wildcard %s of %s", host, head, describe(headMatch))
+		return headMatch
+	case tailMatch != nil:
+		r.tracef("server_name: %q matches the wildcard %s of %s", host, tail, describe(tailMatch))
+		return tailMatch
+	case regexMatch != nil:
+		r.tracef("server_name: %q matches the regex %s of %s", host, regexName, describe(regexMatch))
+		return regexMatch
+	}
+	r.tracef("server_name: %q matches no name, using the default server %s", host, describe(defaultServer))
+	return defaultServer
+}
@@ -0,0 +1,232 @@
+package yamlconf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+)
+
+// Compile decodes a document and returns its config, see Document.Config
+func Compile(data []byte, opts ...parser.Option) (*config.Config, error) {
+	doc, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Config(opts...)
+}
+
+// Config builds the config of the document. Each directive is parsed and validated
+// with the parser in its context, the options like parser.WithCustomDirectives are
+// passed to the parser. The line of each directive is its line in the document.
+// It returns an ErrorList and no config when the document is not valid.
+func (d *Document) Config(opts ...parser.Option) (*config.Config, error) {
+	c := &compiler{opts: opts}
+	directives := c.directives(parser.MainContext, d.Directives)
+	if len(d.Events) > 0 {
+		directives = append(directives, c.wrap(block("events", nil, c.directives(parser.EventsContext, d.Events))))
+	}
+	if d.HTTP != nil {
+		directives = append(directives, c.http(d.HTTP))
+	}
+	if len(c.errors) > 0 {
+		return nil, c.errors
+	}
+	return &config.Config{Block: &config.Block{Directives: directives}}, nil
+}
+
+type compiler struct {
+	opts   []parser.Option
+	errors ErrorList
+}
+
+func (c *compiler) errorf(line, column int, format string, args ...interface{}) {
+	c.errors = append(c.errors, &Error{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *compiler) http(h *HTTP) config.IDirective {
+	directives := c.directives(parser.HTTPContext, h.Directives)
+	for _, u := range h.Upstreams {
+		directives = append(directives, c.upstream(u))
+	}
+	for _, s := range h.Servers {
+		directives = append(directives, c.server(s))
+	}
+	d := block("http", nil, directives)
+	d.SetLine(h.Line)
+	return c.wrap(d)
+}
+
+func (c *compiler) upstream(u *Upstream) config.IDirective {
+	if u.Name == "" {
+		c.errorf(u.Line, u.Column, "upstream has no name")
+	}
+	if len(u.Servers) == 0 {
+		c.errorf(u.Line, u.Column, "upstream %s has no servers", u.Name)
+	}
+	var directives []config.IDirective
+	for _, s := range u.Servers {
+		if s.Address == "" {
+			c.errorf(s.Line, s.Column, "upstream server has no address")
+			continue
+		}
+		params := []string{s.Address}
+		names := make([]string, 0, len(s.Parameters))
+		for name := range s.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			params = append(params, name+"="+s.Parameters[name])
+		}
+		params = append(params, s.Flags...)
+		directives = append(directives, c.directive(parser.UpstreamContext, &Directive{Name: "server", Value: strings.Join(params, " "), Line: s.Line, Column: s.Column})...)
+	}
+	directives = append(directives, c.directives(parser.UpstreamContext, u.Directives)...)
+	d := block("upstream", []string{u.Name}, directives)
+	d.SetLine(u.Line)
+	return c.wrap(d)
+}
+
+func (c *compiler) server(s *Server) config.IDirective {
+	directives := c.directives(parser.ServerContext, s.Listen)
+	if len(s.ServerName) > 0 {
+		names := make([]string, 0, len(s.ServerName))
+		for _, name := range s.ServerName {
+			names = append(names, name.Value)
+		}
+		first := s.ServerName[0]
+		directives = append(directives, c.directive(parser.ServerContext, &Directive{Name: "server_name", Value: strings.Join(names, " "), Line: first.Line, Column: first.Column})...)
+	}
+	directives = append(directives, c.directives(parser.ServerContext, s.Directives)...)
+	for _, l := range s.Locations {
+		directives = append(directives, c.location(l))
+	}
+	d := block("server", nil, directives)
+	d.SetLine(s.Line)
+	return c.wrap(d)
+}
+
+func (c *compiler) location(l *Location) config.IDirective {
+	switch l.Modifier {
+	case "", "=", "~", "~*", "^~":
+	default:
+		c.errorf(l.Line, l.Column, "invalid location modifier %q", l.Modifier)
+	}
+	if l.Match == "" {
+		c.errorf(l.Line, l.Column, "location has no match")
+	}
+	directives := c.directives(parser.LocationContext, l.Directives)
+	for _, nested := range l.Locations {
+		directives = append(directives, c.location(nested))
+	}
+	params := []string{quote(l.Match)}
+	if l.Modifier != "" {
+		params = []string{l.Modifier, quote(l.Match)}
+	}
+	d := block("location", params, directives)
+	d.SetLine(l.Line)
+	return c.wrap(d)
+}
+
+func (c *compiler) directives(ctx parser.Context, directives Directives) []config.IDirective {
+	var parsed []config.IDirective
+	for _, d := range directives {
+		parsed = append(parsed, c.directive(ctx, d)...)
+	}
+	return parsed
+}
+
+// directive parses the directive with the parser, it must be a single directive without block
+func (c *compiler) directive(ctx parser.Context, d *Directive) []config.IDirective {
+	text := d.Name + ";"
+	if d.Value != "" {
+		text = d.Name + " " + quoteComments(d.Value) + ";"
+	}
+	opts := append([]parser.Option{parser.WithDirectiveValidation(), parser.WithRootContext(ctx)}, c.opts...)
+	cfg, err := parser.NewStringParser(text, opts...).Parse()
+	if err != nil {
+		var parseErr *parser.ParseError
+		if errors.As(err, &parseErr) {
+			err = errors.New(parseErr.Message)
+		}
+		c.errorf(d.Line, d.Column, "%s", err)
+		return nil
+	}
+	if len(cfg.Directives) != 1 || cfg.Directives[0].GetBlock() != nil || cfg.Directives[0].GetName() != d.Name {
+		c.errorf(d.Line, d.Column, "the value of %s must be the parameters of one directive", d.Name)
+		return nil
+	}
+	parsed := cfg.Directives[0]
+	parsed.SetLine(d.Line)
+	return []config.IDirective{parsed}
+}
+
+// wrap turns the directive into its typed wrapper from config.BlockWrappers, like the
+// parser does, and sets the parent of its sub directives
+func (c *compiler) wrap(d *config.Directive) config.IDirective {
+	var s config.IDirective = d
+	if wrapper, ok := config.BlockWrappers[d.Name]; ok {
+		wrapped, err := wrapper(d)
+		if err != nil {
+			c.errorf(d.Line, 0, "%s", err)
+			return d
+		}
+		wrapped.SetLine(d.Line)
+		s = wrapped
+	}
+	if block, ok := s.GetBlock().(*config.Block); ok {
+		block.SetParent(s)
+	}
+	for _, sub := range s.GetBlock().GetDirectives() {
+		sub.SetParent(s)
+	}
+	return s
+}
+
+func block(name string, params []string, directives []config.IDirective) *config.Directive {
+	d := &config.Directive{Name: name, Block: &config.Block{Directives: append([]config.IDirective{}, directives...)}}
+	for _, p := range params {
+		d.Parameters = append(d.Parameters, config.Parameter{Value: p})
+	}
+	return d
+}
+
+// quoteComments quotes the parameters of a value that start with #, nginx would read
+// them and the rest of the directive as a comment
+func quoteComments(value string) string {
+	var sb strings.Builder
+	var quoted byte // the quote of the parameter being read
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		start := i == 0 || strings.IndexByte(" \t\r\n", value[i-1]) >= 0
+		switch {
+		case quoted != 0 && ch == '\\' && i+1 < len(value):
+			sb.WriteByte(ch)
+			i++
+			ch = value[i]
+		case quoted != 0 && ch == quoted:
+			quoted = 0
+		case quoted == 0 && start && (ch == '"' || ch == '\''):
+			quoted = ch
+		case quoted == 0 && start && ch == '#':
+			end := i + strings.IndexAny(value[i:]+" ", " \t\r\n")
+			sb.WriteString(quote(value[i:end]))
+			i = end - 1
+			continue
+		}
+		sb.WriteByte(ch)
+	}
+	return sb.String()
+}
+
+// quote quotes a location match that nginx would otherwise split or read as syntax
+func quote(value string) string {
+	if !strings.ContainsAny(value, " \t;{}#\"'") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
@@ -0,0 +1,40 @@
+// Package yamlconf compiles vhosts described in YAML into a config that the dumper
+// writes as nginx syntax. JSON documents are read as well. The schema is:
+//
+//	directives:                  # directives of the main context
+//	  worker_processes: auto
+//	events:                      # directives of the events block
+//	  worker_connections: 1024
+//	http:
+//	  directives:
+//	    sendfile: true           # true and false are on and off
+//	    add_header:              # a list repeats the directive
+//	      - X-Frame-Options DENY
+//	      - X-Content-Type-Options nosniff
+//	  upstreams:
+//	    - name: api
+//	      servers:
+//	        - 10.0.0.3:8080 weight=3
+//	        - address: 10.0.0.4:8080
+//	          parameters: {max_fails: 3}
+//	          flags: [backup]
+//	      directives:
+//	        keepalive: 16
+//	  servers:
+//	    - listen: [80, 443 ssl]
+//	      server_name: example.com
+//	      directives:
+//	        root: /srv/www
+//	      locations:
+//	        - match: /api
+//	          directives:
+//	            proxy_pass: http://api
+//	        - modifier: "="
+//	          match: /health
+//	          directives:
+//	            return: 200
+//
+// The value of a directive is its parameters as nginx text, a directive without
+// parameters has an empty value. Directives are checked against the directive
+// registry of the parser, errors point to the line and column in the document.
+package yamlconf
@@ -0,0 +1,383 @@
+package yamlconf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the root of a YAML document
+type Document struct {
+	Directives Directives // the main context
+	Events     Directives
+	HTTP       *HTTP
+}
+
+// HTTP is the http block
+type HTTP struct {
+	Directives Directives
+	Upstreams  []*Upstream
+	Servers    []*Server
+	Line       int
+	Column     int
+}
+
+// Upstream is an upstream block of http
+type Upstream struct {
+	Name       string
+	Servers    []*UpstreamServer
+	Directives Directives
+	Line       int
+	Column     int
+}
+
+// UpstreamServer is a server of an upstream, written as "10.0.0.3:8080 weight=3 backup"
+// or as a mapping with address, parameters and flags
+type UpstreamServer struct {
+	Address    string
+	Parameters map[string]string // written sorted by name
+	Flags      []string
+	Line       int
+	Column     int
+}
+
+// Server is a server block of http
+type Server struct {
+	Listen     Directives // a listen directive for each item
+	ServerName Directives // the names of the server_name directive
+	Directives Directives
+	Locations  []*Location
+	Line       int
+	Column     int
+}
+
+// Location is a location block of a server or of a location
+type Location struct {
+	Modifier   string
+	Match      string
+	Directives Directives
+	Locations  []*Location
+	Line       int
+	Column     int
+}
+
+// Directive is a directive with its parameters as nginx text
+type Directive struct {
+	Name   string
+	Value  string
+	Line   int
+	Column int
+}
+
+// Directives are the directives of a block, in the document order
+type Directives []*Directive
+
+// Error is an invalid part of a document
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error returns the message with its position in the document
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ErrorList is the list of errors of a document
+type ErrorList []*Error
+
+// Error returns the first error and the number of other errors
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Unwrap returns the errors in the list
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+func errorf(node *yaml.Node, format string, args ...interface{}) *Error {
+	return &Error{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Decode reads a YAML or JSON document, unknown fields are errors
+func Decode(data []byte) (*Document, error) {
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&root); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	doc := &Document{}
+	if len(root.Content) == 0 {
+		return doc, nil
+	}
+	if err := doc.UnmarshalYAML(root.Content[0]); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// UnmarshalYAML decodes the document
+func (d *Document) UnmarshalYAML(node *yaml.Node) error {
+	return fields(node, map[string]func(*yaml.Node) error{
+		"directives": d.Directives.UnmarshalYAML,
+		"events":     d.Events.UnmarshalYAML,
+		"http": func(n *yaml.Node) error {
+			d.HTTP = &HTTP{}
+			return d.HTTP.UnmarshalYAML(n)
+		},
+	})
+}
+
+// UnmarshalYAML decodes the http block
+func (h *HTTP) UnmarshalYAML(node *yaml.Node) error {
+	h.Line, h.Column = node.Line, node.Column
+	return fields(node, map[string]func(*yaml.Node) error{
+		"directives": h.Directives.UnmarshalYAML,
+		"upstreams": func(n *yaml.Node) error {
+			return sequence(n, func(item *yaml.Node) error {
+				u := &Upstream{}
+				h.Upstreams = append(h.Upstreams, u)
+				return u.UnmarshalYAML(item)
+			})
+		},
+		"servers": func(n *yaml.Node) error {
+			return sequence(n, func(item *yaml.Node) error {
+				s := &Server{}
+				h.Servers = append(h.Servers, s)
+				return s.UnmarshalYAML(item)
+			})
+		},
+	})
+}
+
+// UnmarshalYAML decodes an upstream
+func (u *Upstream) UnmarshalYAML(node *yaml.Node) error {
+	u.Line, u.Column = node.Line, node.Column
+	return fields(node, map[string]func(*yaml.Node) error{
+		"name":       scalar(&u.Name),
+		"directives": u.Directives.UnmarshalYAML,
+		"servers": func(n *yaml.Node) error {
+			return sequence(n, func(item *yaml.Node) error {
+				s := &UpstreamServer{}
+				u.Servers = append(u.Servers, s)
+				return s.UnmarshalYAML(item)
+			})
+		},
+	})
+}
+
+// UnmarshalYAML decodes an upstream server from a scalar or a mapping
+func (s *UpstreamServer) UnmarshalYAML(node *yaml.Node) error {
+	node = resolve(node)
+	s.Line, s.Column = node.Line, node.Column
+	if node.Kind == yaml.ScalarNode {
+		fields := strings.Fields(node.Value)
+		if len(fields) > 0 {
+			s.Address = fields[0]
+			fields = fields[1:]
+		}
+		for _, f := range fields {
+			if name, value, ok := strings.Cut(f, "="); ok {
+				if s.Parameters == nil {
+					s.Parameters = map[string]string{}
+				}
+				s.Parameters[name] = value
+			} else {
+				s.Flags = append(s.Flags, f)
+			}
+		}
+		return nil
+	}
+	return fields(node, map[string]func(*yaml.Node) error{
+		"address": scalar(&s.Address),
+		"parameters": func(n *yaml.Node) error {
+			s.Parameters = map[string]string{}
+			return mapping(n, func(key, value *yaml.Node) error {
+				if value.Kind != yaml.ScalarNode {
+					return errorf(value, "parameter %s must be a scalar", key.Value)
+				}
+				s.Parameters[key.Value] = value.Value
+				return nil
+			})
+		},
+		"flags": scalars(&s.Flags),
+	})
+}
+
+// UnmarshalYAML decodes a server
+func (s *Server) UnmarshalYAML(node *yaml.Node) error {
+	s.Line, s.Column = node.Line, node.Column
+	return fields(node, map[string]func(*yaml.Node) error{
+		"listen":      items("listen", &s.Listen),
+		"server_name": items("server_name", &s.ServerName),
+		"directives":  s.Directives.UnmarshalYAML,
+		"locations":   locations(&s.Locations),
+	})
+}
+
+// UnmarshalYAML decodes a location
+func (l *Location) UnmarshalYAML(node *yaml.Node) error {
+	l.Line, l.Column = node.Line, node.Column
+	return fields(node, map[string]func(*yaml.Node) error{
+		"modifier":   scalar(&l.Modifier),
+		"match":      scalar(&l.Match),
+		"directives": l.Directives.UnmarshalYAML,
+		"locations":  locations(&l.Locations),
+	})
+}
+
+// UnmarshalYAML decodes a mapping of directive names to values, a list of values repeats the directive
+func (d *Directives) UnmarshalYAML(node *yaml.Node) error {
+	return mapping(node, func(key, value *yaml.Node) error {
+		value = resolve(value)
+		values := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			values = value.Content
+		}
+		for _, v := range values {
+			v = resolve(v)
+			if v.Kind != yaml.ScalarNode {
+				return errorf(v, "the value of %s must be a scalar, blocks are not supported", key.Value)
+			}
+			text := v.Value
+			switch {
+			case v.Tag == "!!bool" && strings.EqualFold(v.Value, "true"):
+				text = "on"
+			case v.Tag == "!!bool":
+				text = "off"
+			case v.Tag == "!!null":
+				text = ""
+			}
+			pos := key // the item of a list is where the repeated directive is
+			if value.Kind == yaml.SequenceNode {
+				pos = v
+			}
+			*d = append(*d, &Directive{Name: key.Value, Value: text, Line: pos.Line, Column: pos.Column})
+		}
+		return nil
+	})
+}
+
+func locations(dst *[]*Location) func(*yaml.Node) error {
+	return func(n *yaml.Node) error {
+		return sequence(n, func(item *yaml.Node) error {
+			l := &Location{}
+			*dst = append(*dst, l)
+			return l.UnmarshalYAML(item)
+		})
+	}
+}
+
+// fields decodes each field of a mapping with its decoder, unknown fields are errors
+func fields(node *yaml.Node, decoders map[string]func(*yaml.Node) error) error {
+	return mapping(node, func(key, value *yaml.Node) error {
+		decode, ok := decoders[key.Value]
+		if !ok {
+			return errorf(key, "unknown field %s", key.Value)
+		}
+		return decode(value)
+	})
+}
+
+func mapping(node *yaml.Node, fn func(key, value *yaml.Node) error) error {
+	node = resolve(node)
+	if node.Tag == "!!null" {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return errorf(node, "expected a mapping")
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i], node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sequence(node *yaml.Node, fn func(item *yaml.Node) error) error {
+	node = resolve(node)
+	if node.Tag == "!!null" {
+		return nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return errorf(node, "expected a list")
+	}
+	for _, item := range node.Content {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scalar(dst *string) func(*yaml.Node) error {
+	return func(n *yaml.Node) error {
+		n = resolve(n)
+		if n.Kind != yaml.ScalarNode {
+			return errorf(n, "expected a scalar")
+		}
+		*dst = n.Value
+		return nil
+	}
+}
+
+// scalars decodes a scalar or a list of scalars
+func scalars(dst *[]string) func(*yaml.Node) error {
+	return func(n *yaml.Node) error {
+		n = resolve(n)
+		if n.Kind == yaml.ScalarNode {
+			*dst = append(*dst, n.Value)
+			return nil
+		}
+		return sequence(n, func(item *yaml.Node) error {
+			if item.Kind != yaml.ScalarNode {
+				return errorf(item, "expected a scalar")
+			}
+			*dst = append(*dst, item.Value)
+			return nil
+		})
+	}
+}
+
+// items decodes a scalar or a list of scalars into directives, each with the position of its item
+func items(name string, dst *Directives) func(*yaml.Node) error {
+	return func(n *yaml.Node) error {
+		n = resolve(n)
+		add := func(item *yaml.Node) error {
+			item = resolve(item)
+			if item.Kind != yaml.ScalarNode {
+				return errorf(item, "expected a scalar")
+			}
+			*dst = append(*dst, &Directive{Name: name, Value: item.Value, Line: item.Line, Column: item.Column})
+			return nil
+		}
+		if n.Kind == yaml.ScalarNode {
+			return add(n)
+		}
+		return sequence(n, add)
+	}
+}
+
+// resolve returns the node an alias refers to
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
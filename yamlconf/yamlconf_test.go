@@ -0,0 +1,172 @@
+package yamlconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tufanbarisyildirim/gonginx/config"
+	"github.com/tufanbarisyildirim/gonginx/dumper"
+	"github.com/tufanbarisyildirim/gonginx/parser"
+	"gotest.tools/v3/assert"
+)
+
+const document = `directives:
+  worker_processes: auto
+events:
+  worker_connections: 1024
+http:
+  directives:
+    sendfile: true
+    add_header:
+      - X-Frame-Options DENY
+      - X-Content-Type-Options nosniff
+  upstreams:
+    - name: api
+      servers:
+        - 10.0.0.3:8080 weight=3
+        - address: 10.0.0.4:8080
+          parameters: {max_fails: 3, fail_timeout: 10s}
+          flags: [backup]
+      directives:
+        keepalive: 16
+  servers:
+    - listen: [80, 443 ssl]
+      server_name: [example.com, www.example.com]
+      directives:
+        root: /srv/www
+      locations:
+        - match: /api
+          directives:
+            proxy_pass: http://api
+            proxy_set_header: Host $host
+        - modifier: "="
+          match: /health
+          directives:
+            access_log: off
+            return: 200 "ok"
+`
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+	c, err := Compile([]byte(document))
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.IndentedStyle), `worker_processes auto;
+events {
+    worker_connections 1024;
+}
+http {
+    sendfile on;
+    add_header X-Frame-Options DENY;
+    add_header X-Content-Type-Options nosniff;
+    upstream api {
+        server 10.0.0.3:8080 weight=3;
+        server 10.0.0.4:8080 fail_timeout=10s max_fails=3 backup;
+        keepalive 16;
+    }
+    server {
+        listen 80;
+        listen 443 ssl;
+        server_name example.com www.example.com;
+        root /srv/www;
+        location /api {
+            proxy_pass http://api;
+            proxy_set_header Host $host;
+        }
+        location = /health {
+            access_log off;
+            return 200 "ok";
+        }
+    }
+}`)
+
+	upstream := c.FindUpstreams()[0]
+	assert.Equal(t, upstream.UpstreamServers[1].Parameters["max_fails"], "3")
+	assert.DeepEqual(t, upstream.UpstreamServers[1].Flags, []string{"backup"})
+	assert.Equal(t, upstream.GetLine(), 12)
+	http := c.FindDirectives("http")[0].(*config.HTTP)
+	assert.Equal(t, len(http.Servers), 1)
+	location := c.FindDirectives("location")[1].(*config.Location)
+	assert.Equal(t, location.Modifier, "=")
+	assert.Equal(t, location.GetParent(), config.IDirective(http.Servers[0]))
+	assert.Equal(t, c.FindDirectives("proxy_pass")[0].GetLine(), 28)
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := Compile([]byte(`http:
+  upstreams:
+    - name: api
+  servers:
+    - listen: 80
+      directives:
+        worker_connections: 1024
+        gzip: maybe
+      locations:
+        - modifier: "=~"
+          match: /
+          directives:
+            proxy_pass: http://a; root /
+`))
+	var list ErrorList
+	assert.Assert(t, errors.As(err, &list))
+	messages := make([]string, 0, len(list))
+	for _, e := range list {
+		messages = append(messages, e.Error())
+	}
+	assert.DeepEqual(t, messages, []string{
+		"line 3, column 7: upstream api has no servers",
+		"line 7, column 9: 'worker_connections' directive is not allowed in server context",
+		"line 8, column 9: invalid value 'maybe' in 'gzip' directive, it must be 'on' or 'off'",
+		"line 10, column 11: invalid location modifier \"=~\"",
+		"line 13, column 13: the value of proxy_pass must be the parameters of one directive",
+	})
+
+	_, err = Decode([]byte("http:\n  servers:\n    - listen: 80\n      root: /srv\n"))
+	assert.Error(t, err, "line 4, column 7: unknown field root")
+	_, err = Decode([]byte("http:\n  directives:\n    if: {set: 1}\n"))
+	assert.Error(t, err, "line 3, column 9: the value of if must be a scalar, blocks are not supported")
+}
+
+func TestCompile_CustomDirectives(t *testing.T) {
+	t.Parallel()
+	doc := []byte("http:\n  directives:\n    my_directive: value\n")
+	_, err := Compile(doc)
+	assert.ErrorContains(t, err, "line 3, column 5: unknown directive 'my_directive'")
+	c, err := Compile(doc, parser.WithCustomDirectives("my_directive"))
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.NoIndentStyle), "http {\nmy_directive value;\n}")
+}
+
+func TestCompile_Positions(t *testing.T) {
+	t.Parallel()
+	doc := []byte(`http:
+  servers:
+    - listen:
+        - 80
+        - 443 ssl
+      server_name:
+        - example.com
+`)
+	c, err := Compile(doc)
+	assert.NilError(t, err)
+	listens := c.FindDirectives("listen")
+	assert.Equal(t, listens[0].GetLine(), 4)
+	assert.Equal(t, listens[1].GetLine(), 5)
+	assert.Equal(t, c.FindDirectives("server_name")[0].GetLine(), 7)
+
+	_, err = Compile([]byte("http:\n  servers:\n    - server_name: example.com\n      listen:\n        - 80\n        - ''\n"))
+	assert.Error(t, err, "line 6, column 11: invalid number of arguments in 'listen' directive")
+}
+
+func TestCompile_Comments(t *testing.T) {
+	t.Parallel()
+	// a # that starts a parameter is quoted, nginx would read a comment
+	c, err := Compile([]byte(`http:
+  servers:
+    - directives:
+        add_header: ['X-Channel #general', 'X-Id "#1"']
+        return: '200 #2'
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, dumper.DumpConfig(c, dumper.NoIndentStyle), "http {\nserver {\nadd_header X-Channel \"#general\";\nadd_header X-Id \"#1\";\nreturn 200 \"#2\";\n}\n}")
+}